@@ -48,13 +48,52 @@ func setDefaults() {
 	// HTTP server defaults
 	viper.SetDefault("http.port", 8080)
 	viper.SetDefault("http.mode", "debug")
+	viper.SetDefault("http.trusted_proxies", []string{})
 
 	// gRPC server defaults
 	viper.SetDefault("grpc.port", 50051)
+	viper.SetDefault("grpc.reflection", false)
+	viper.SetDefault("grpc.health_watch_interval", time.Second*5)
+
+	// Health check defaults
+	viper.SetDefault("health.timeout", time.Second*2)
+	viper.SetDefault("health.cache_ttl", time.Second*5)
+
+	// Rate limiting defaults
+	viper.SetDefault("rate_limit.enabled", true)
+	viper.SetDefault("rate_limit.limit", 600)
+	viper.SetDefault("rate_limit.period", time.Minute)
+	viper.SetDefault("rate_limit.burst", 0)
+
+	// HTTP response caching defaults
+	viper.SetDefault("http_cache.enabled", true)
+
+	// Foo cache defaults
+	viper.SetDefault("cache.ttl", time.Minute*15)
+	viper.SetDefault("cache.jitter", 0.1)
+	viper.SetDefault("cache.stale_ttl", time.Minute)
+	viper.SetDefault("cache.missing_ttl", time.Second*30)
+	viper.SetDefault("cache.list_ttl", time.Minute)
+	viper.SetDefault("cache.refresh_timeout", time.Second*5)
+	viper.SetDefault("cache.local.enabled", true)
+	viper.SetDefault("cache.local.size", 10000)
+	viper.SetDefault("cache.local.ttl", time.Second*30)
+	viper.SetDefault("cache.bloom.enabled", false)
+	viper.SetDefault("cache.bloom.size", 1<<24)
+	viper.SetDefault("cache.bloom.hashes", 7)
+	viper.SetDefault("cache.codec.name", "json")
+	viper.SetDefault("cache.codec.compression_threshold", 1024)
+
+	// Stream (SSE / WebSocket) defaults
+	viper.SetDefault("stream.history_size", 1000)
+	viper.SetDefault("stream.client_buffer_size", 64)
+	viper.SetDefault("stream.heartbeat_interval", time.Second*15)
+	viper.SetDefault("stream.allowed_origins", []string{})
 
 	// Auth configuration defaults
 	viper.SetDefault("auth.issuer", "http://localhost:8080/realms/astigo")
 	viper.SetDefault("auth.client_id", "astigo-api")
+	viper.SetDefault("auth.static_token", "dev-token")
 
 	// Logging configuration defaults
 	viper.SetDefault("log.level", "info")
@@ -75,12 +114,55 @@ func setDefaults() {
 	viper.SetDefault("postgres.migration_path", "file://migrations/postgres")
 
 	// Redis connection defaults
+	viper.SetDefault("redis.mode", "standalone")
 	viper.SetDefault("redis.host", "localhost")
 	viper.SetDefault("redis.port", 6379)
+	viper.SetDefault("redis.addrs", []string{})
+	viper.SetDefault("redis.master_name", "")
+	viper.SetDefault("redis.username", "")
 	viper.SetDefault("redis.db", 0)
+	viper.SetDefault("redis.tls.enabled", false)
+	viper.SetDefault("redis.tls.ca_file", "")
+	viper.SetDefault("redis.tls.cert_file", "")
+	viper.SetDefault("redis.tls.key_file", "")
+	viper.SetDefault("redis.tls.server_name", "")
+	viper.SetDefault("redis.pool.size", 0)
+	viper.SetDefault("redis.pool.min_idle", 0)
 
 	// NATS connection defaults
 	viper.SetDefault("nats.url", "nats://localhost:4222")
+	viper.SetDefault("nats.jetstream.enabled", false)
+	viper.SetDefault("nats.jetstream.stream", "FOO")
+	viper.SetDefault("nats.jetstream.replicas", 1)
+	viper.SetDefault("nats.jetstream.max_age", time.Hour*24*7)
+	viper.SetDefault("nats.jetstream.duplicate_window", time.Minute*2)
+	viper.SetDefault("nats.cloudevents.mode", "structured")
+	viper.SetDefault("nats.cloudevents.source", "/astigo")
+
+	// Foo worker defaults, used with JetStream
+	viper.SetDefault("worker.max_deliver", 5)
+	viper.SetDefault("worker.ack_wait", time.Second*30)
+	viper.SetDefault("worker.backoff", []time.Duration{time.Second, time.Second * 10, time.Minute})
+	viper.SetDefault("worker.max_ack_pending", 256)
+	viper.SetDefault("worker.concurrency", 4)
+
+	// Webhook defaults
+	viper.SetDefault("webhook.enabled", true)
+	viper.SetDefault("webhook.max_attempts", 8)
+	viper.SetDefault("webhook.backoff", time.Second*30)
+	viper.SetDefault("webhook.max_backoff", time.Hour*6)
+	viper.SetDefault("webhook.failure_threshold", 20)
+	viper.SetDefault("webhook.batch_size", 10)
+	viper.SetDefault("webhook.lease", time.Minute)
+	viper.SetDefault("webhook.poll_interval", time.Second)
+	viper.SetDefault("webhook.timeout", time.Second*10)
+	viper.SetDefault("webhook.allow_private_networks", false)
+
+	// File defaults
+	viper.SetDefault("file.max_size", 100<<20)
+	viper.SetDefault("file.upload_ttl", time.Hour*24)
+	viper.SetDefault("file.expire_batch_size", 100)
+	viper.SetDefault("file.sweep_interval", time.Minute)
 
 	// S3 storage configuration defaults
 	viper.SetDefault("s3.bucket", "default")
@@ -19,6 +19,7 @@ func init() {
 	rootCmd.PersistentFlags().Int("http.port", 8080, "HTTP server port")
 	rootCmd.PersistentFlags().Int("grpc.port", 50051, "gRPC server port")
 	rootCmd.PersistentFlags().String("log.level", "info", "logging level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().String("profile", "default", "adapters profile (default, memory: in-memory adapters without any infrastructure)")
 
 	if err := viper.BindPFlags(rootCmd.PersistentFlags()); err != nil {
 		fmt.Printf("failed to bind flags: %v\n", err)
@@ -1,36 +1,153 @@
 package event
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+const messagingSystem = "nats"
+
 type ConsumerNats struct {
 	Logger *zap.Logger
 	conn   *nats.Conn
 
-	fooWorker *FooWorkerNats
+	subscribers []interface{ Close() error }
+	fooStream   *FooStreamNats
 }
 
 func (c *ConsumerNats) Close() error {
-	if err := c.fooWorker.Close(); err != nil {
-		return fmt.Errorf("failed to close foo consumer: %w", err)
+	if err := c.closeSubscribers(); err != nil {
+		return err
+	}
+	if err := c.fooStream.Close(); err != nil {
+		return fmt.Errorf("failed to close foo stream: %w", err)
+	}
+	return nil
+}
+
+// closeSubscribers stops every subscriber, waiting for the messages they are processing.
+func (c *ConsumerNats) closeSubscribers() error {
+	for _, subscriber := range c.subscribers {
+		if err := subscriber.Close(); err != nil {
+			return fmt.Errorf("failed to close subscriber: %w", err)
+		}
 	}
 	return nil
 }
 
-func NewConsumerNats(logger *zap.Logger, conn *nats.Conn) (*ConsumerNats, error) {
-	var err error
+// startMessage starts the consumer span handling a message and returns the context used to process it.
+// The span continues the trace propagated in the message headers by the publisher. The context carries the request id
+// received in the headers, or a new one, and a logger enriched with it and with the trace id.
+func startMessage(logger *zap.Logger, msg *nats.Msg, spanName string) (context.Context, trace.Span) {
+	var group string
+	if msg.Sub != nil {
+		group = msg.Sub.Queue
+	}
+	ctx, span := startSpan(context.Background(), msg.Subject, msg.Header, len(msg.Data), group, spanName)
+	return startCorrelation(ctx, logger, msg.Subject, msg.Header), span
+}
+
+// startSpan starts a consumer span continuing the trace propagated in the message headers.
+func startSpan(ctx context.Context, subject string, header nats.Header, size int, group, spanName string) (context.Context, trace.Span) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, correlation.NatsHeaderCarrier(header))
+
+	attributes := []attribute.KeyValue{
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(subject),
+		semconv.MessagingOperationName("process"),
+		semconv.MessagingOperationTypeProcess,
+		semconv.MessagingMessageBodySize(size),
+	}
+	if group != "" {
+		attributes = append(attributes, semconv.MessagingConsumerGroupName(group))
+	}
+
+	tracer := otel.Tracer("ConsumerNats")
+	return tracer.Start(ctx, spanName,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// startCorrelation attaches to ctx the request id received in the message headers, or a new one, and a logger
+// enriched with it and with the trace id.
+func startCorrelation(ctx context.Context, logger *zap.Logger, subject string, header nats.Header) context.Context {
+	id := header.Get(correlation.HeaderRequestID)
+	if !correlation.ValidRequestID(id) {
+		id = correlation.NewRequestID()
+	}
+
+	return correlation.Start(ctx, logger.With(zap.String("subject", subject)), id)
+}
+
+// NewConsumerNats starts the subscriptions of the registry and the Foo stream. Each subscription consumes the given
+// JetStream stream through a durable consumer when js is not nil, and subscribes to core NATS otherwise. The stream
+// always subscribes to core NATS, which also delivers the messages published to JetStream. The durable consumers
+// created while the legacy single-worker consumer exists take over from its first unacknowledged event, then the
+// legacy consumer is deleted.
+func NewConsumerNats(
+	ctx context.Context,
+	logger *zap.Logger,
+	conn *nats.Conn,
+	js jetstream.JetStream,
+	jsStream string,
+	workerConfig WorkerConfig,
+	registry *Registry,
+	hub *stream.Hub,
+) (*ConsumerNats, error) {
 	consumer := &ConsumerNats{
 		Logger: logger,
 		conn:   conn,
 	}
 
-	if consumer.fooWorker, err = NewFooWorkerNats(logger, conn, "foo"); err != nil {
-		return nil, fmt.Errorf("fail to create foo consumer: %w", err)
+	var startSequence uint64
+	if js != nil {
+		var err error
+		if startSequence, err = legacyStartSequence(ctx, js, jsStream); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, subscription := range registry.Subscriptions() {
+		var subscriber interface{ Close() error }
+		var err error
+		if js != nil {
+			subscriber, err = NewSubscriberJetStream(ctx, logger, js, jsStream, workerConfig, subscription, startSequence)
+		} else {
+			subscriber, err = NewSubscriberNats(logger, conn, subscription)
+		}
+		if err != nil {
+			_ = consumer.closeSubscribers()
+			return nil, fmt.Errorf("fail to create subscriber %s: %w", subscription.Name, err)
+		}
+		consumer.subscribers = append(consumer.subscribers, subscriber)
+	}
+
+	if startSequence > 0 {
+		if err := deleteLegacyConsumer(ctx, js, jsStream); err != nil {
+			_ = consumer.closeSubscribers()
+			return nil, err
+		}
+		logger.Info("replace legacy consumer", zap.String("consumer", legacyDurable), zap.Uint64("start_sequence", startSequence))
+	}
+
+	fooStream, err := NewFooStreamNats(logger, conn, hub)
+	if err != nil {
+		_ = consumer.closeSubscribers()
+		return nil, fmt.Errorf("fail to create foo stream: %w", err)
 	}
+	consumer.fooStream = fooStream
 
 	return consumer, nil
 }
@@ -0,0 +1,45 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func TestStartMessage(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	msg := nats.NewMsg("foo.created")
+	msg.Data = []byte(`{"id":"20000000-0000-0000-0000-000000000001"}`)
+	msg.Header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	msg.Header.Set(correlation.HeaderRequestID, "req-42")
+
+	ctx, span := startMessage(zap.NewNop(), msg, "FooStreamNats.OnEvent")
+	span.End()
+
+	assert.Equal(t, "req-42", correlation.RequestID(ctx))
+	assert.Equal(t, span.SpanContext().SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	ended := spans[0]
+	assert.Equal(t, "FooStreamNats.OnEvent", ended.Name())
+	assert.Equal(t, trace.SpanKindConsumer, ended.SpanKind())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", ended.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", ended.Parent().SpanID().String())
+	assert.Contains(t, ended.Attributes(), attribute.String("messaging.system", "nats"))
+	assert.Contains(t, ended.Attributes(), attribute.String("messaging.destination.name", "foo.created"))
+	assert.Contains(t, ended.Attributes(), attribute.String("messaging.operation.type", "process"))
+}
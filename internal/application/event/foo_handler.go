@@ -0,0 +1,51 @@
+package event
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"go.uber.org/zap"
+)
+
+const (
+	fooCreatedSubject = "foo.created"
+	fooUpdatedSubject = "foo.updated"
+	fooDeletedSubject = "foo.deleted"
+)
+
+// FooHandler processes the Foo events, whatever the transport delivering them.
+type FooHandler struct {
+	Logger *zap.Logger
+}
+
+func (h *FooHandler) OnCreated(ctx context.Context, event message.FooMessage) error {
+	correlation.Logger(ctx, h.Logger).Info("on created",
+		zap.Stringer("id", event.Id),
+		zap.String("label", event.Label),
+	)
+	return nil
+}
+
+func (h *FooHandler) OnUpdated(ctx context.Context, event message.FooMessage) error {
+	correlation.Logger(ctx, h.Logger).Info("on updated",
+		zap.Stringer("id", event.Id),
+		zap.String("label", event.Label),
+	)
+	return nil
+}
+
+// OnDeleted handles the deletion of a Foo, whose event only carries the id.
+func (h *FooHandler) OnDeleted(ctx context.Context, event message.FooMessage) error {
+	correlation.Logger(ctx, h.Logger).Info("on deleted", zap.Stringer("id", event.Id))
+	return nil
+}
+
+// RegisterFooHandlers registers the subscriptions processing the Foo events.
+func RegisterFooHandlers(registry *Registry, logger *zap.Logger, opts ...SubscriptionOption) {
+	handler := &FooHandler{Logger: logger}
+	registry.Register("foo-created", fooCreatedSubject, Typed(handler.OnCreated), opts...)
+	registry.Register("foo-updated", fooUpdatedSubject, Typed(handler.OnUpdated), opts...)
+	registry.Register("foo-deleted", fooDeletedSubject, Typed(handler.OnDeleted), opts...)
+}
@@ -0,0 +1,96 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+const fooAllSubject = "foo.*"
+
+// FooStreamNats forwards every Foo event received from NATS to the stream hub.
+// It uses a plain subscription instead of a queue group so that each replica sees all events.
+type FooStreamNats struct {
+	Logger       *zap.Logger
+	conn         *nats.Conn
+	hub          *stream.Hub
+	subscription *nats.Subscription
+}
+
+// OnEvent forwards the Foo payload of a message to the hub, unwrapped from its CloudEvent envelope and upcast to the
+// current version of the events catalog, along with the Foo id carried by the subject of the CloudEvent. Messages that
+// are not CloudEvents are forwarded as is, the Foo id being read from their payload.
+func (f *FooStreamNats) OnEvent(msg *nats.Msg) {
+	ctx, span := startMessage(f.Logger, msg, "FooStreamNats.OnEvent")
+	defer span.End()
+
+	fooID, data, err := fooStreamPayload(&Message{Subject: msg.Subject, Data: msg.Data, Header: msg.Header})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to decode event")
+		correlation.Logger(ctx, f.Logger).Warn("failed to decode stream event", zap.Error(err))
+		return
+	}
+
+	event := f.hub.Publish(msg.Subject, fooID, data)
+	span.SetAttributes(attribute.Int64("stream.event_id", int64(event.ID)))
+	correlation.Logger(ctx, f.Logger).Debug("on stream event",
+		zap.Uint64("event_id", event.ID),
+	)
+	span.SetStatus(codes.Ok, "")
+}
+
+// fooStreamPayload returns the Foo id and the payload of the Foo event carried by the message.
+func fooStreamPayload(msg *Message) (uuid.UUID, []byte, error) {
+	event, err := msg.Event()
+	switch {
+	case errors.Is(err, cloudevents.ErrNotCloudEvent):
+		var payload struct {
+			Id uuid.UUID `json:"id"`
+		}
+		_ = json.Unmarshal(msg.Data, &payload)
+		return payload.Id, msg.Data, nil
+	case err != nil:
+		return uuid.Nil, nil, err
+	}
+
+	fooID, err := uuid.Parse(event.Subject)
+	if err != nil {
+		return uuid.Nil, nil, fmt.Errorf("invalid foo id %q: %w", event.Subject, err)
+	}
+	return fooID, event.Data, nil
+}
+
+func (f *FooStreamNats) Close() error {
+	if err := f.subscription.Unsubscribe(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewFooStreamNats(logger *zap.Logger, conn *nats.Conn, hub *stream.Hub) (*FooStreamNats, error) {
+	foo := &FooStreamNats{
+		Logger: logger,
+		conn:   conn,
+		hub:    hub,
+	}
+
+	sub, err := conn.Subscribe(fooAllSubject, foo.OnEvent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to foo.*: %w", err)
+	}
+	foo.subscription = sub
+
+	return foo, nil
+}
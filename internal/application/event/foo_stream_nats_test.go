@@ -0,0 +1,86 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestFooStreamNats_OnEvent(t *testing.T) {
+	t.Parallel()
+	id1 := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	id2 := uuid.MustParse("20000000-0000-0000-0000-000000000002")
+	payload := `{"id":"20000000-0000-0000-0000-000000000001","label":"foo","secret":"s","value":1,"weight":1.5}`
+
+	newMsg := func(t *testing.T, mode string) *nats.Msg {
+		encoder, err := cloudevents.NewEncoder(cloudevents.Config{Mode: mode, Source: "/astigo"})
+		assert.NoError(t, err)
+		msg := nats.NewMsg("foo.created")
+		assert.NoError(t, encoder.Encode(msg, cloudevents.Event{
+			ID:         "foo.created:" + id1.String() + ":0",
+			Type:       events.FooCreatedV1.Type,
+			DataSchema: events.FooCreatedV1.DataSchema(),
+			Subject:    id1.String(),
+			Data:       json.RawMessage(payload),
+		}))
+		return msg
+	}
+
+	testCases := []struct {
+		name string
+		msg  func(t *testing.T) *nats.Msg
+	}{
+		{name: "Success Case - Structured Mode", msg: func(t *testing.T) *nats.Msg { return newMsg(t, cloudevents.ModeStructured) }},
+		{name: "Success Case - Binary Mode", msg: func(t *testing.T) *nats.Msg { return newMsg(t, cloudevents.ModeBinary) }},
+		{name: "Success Case - Not A CloudEvent", msg: func(t *testing.T) *nats.Msg {
+			msg := nats.NewMsg("foo.created")
+			msg.Data = []byte(payload)
+			return msg
+		}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			hub := stream.NewHub(stream.Config{ClientBufferSize: 4})
+			matching, _ := hub.Subscribe(stream.NewFilter(nil, []uuid.UUID{id1}), 0)
+			defer matching.Close()
+			other, _ := hub.Subscribe(stream.NewFilter(nil, []uuid.UUID{id2}), 0)
+			defer other.Close()
+			consumer := &FooStreamNats{Logger: zap.NewNop(), hub: hub}
+
+			consumer.OnEvent(testCase.msg(t))
+
+			if assert.Len(t, matching.C, 1) {
+				event := <-matching.C
+				assert.Equal(t, "foo.created", event.Type)
+				assert.Equal(t, id1, event.FooID)
+				assert.JSONEq(t, payload, string(event.Data))
+			}
+			assert.Len(t, other.C, 0)
+		})
+	}
+
+	t.Run("Failure Case - Invalid Foo Id", func(t *testing.T) {
+		t.Parallel()
+		hub := stream.NewHub(stream.Config{ClientBufferSize: 4})
+		all, _ := hub.Subscribe(stream.NewFilter(nil, nil), 0)
+		defer all.Close()
+		msg := nats.NewMsg("foo.created")
+		msg.Header.Set(cloudevents.HeaderContentType, cloudevents.ContentTypeStructured)
+		msg.Data = []byte(`{"specversion":"1.0","id":"1","source":"/astigo","type":"com.astigo.foo.created","subject":"invalid","data":{}}`)
+		consumer := &FooStreamNats{Logger: zap.NewNop(), hub: hub}
+
+		consumer.OnEvent(msg)
+
+		assert.Len(t, all.C, 0)
+	})
+}
@@ -0,0 +1,111 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/nats-io/nats.go"
+)
+
+// ErrPermanent marks a failure that delivering the message again cannot fix, such as a malformed payload.
+// Such messages are neither retried nor redelivered.
+var ErrPermanent = errors.New("permanent failure")
+
+// Message is an event received by a subscription, whatever the transport delivering it.
+type Message struct {
+	Subject string
+	Data    []byte
+	Header  nats.Header
+	// Subscription is the name of the subscription receiving the message.
+	Subscription string
+	// Delivered counts the deliveries of the message, this one included.
+	Delivered uint64
+}
+
+// Handler processes a message. An error means that the message was not processed and must be delivered again,
+// unless it wraps ErrPermanent.
+type Handler func(ctx context.Context, msg *Message) error
+
+// Middleware wraps a handler with a cross-cutting concern.
+type Middleware func(next Handler) Handler
+
+// Chain wraps the handler with the middlewares, the first one being the outermost.
+func Chain(handler Handler, middlewares ...Middleware) Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// Permanent marks err as a failure that delivering the message again cannot fix.
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %w", ErrPermanent, err)
+}
+
+// Event returns the CloudEvent carried by the message, in structured or binary mode, with its data upcast from the
+// version identified by its dataschema to the current version of the events catalog. A CloudEvent without dataschema
+// is of the first version. Messages that are not CloudEvents fail with cloudevents.ErrNotCloudEvent.
+func (m *Message) Event() (*cloudevents.Event, error) {
+	event, err := cloudevents.Decode(m.Header, m.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if event.DataSchema != "" {
+		eventType, v, err := events.ParseDataSchema(event.DataSchema)
+		if err != nil {
+			return nil, err
+		}
+		if eventType != event.Type {
+			return nil, fmt.Errorf("dataschema %s does not describe %s", event.DataSchema, event.Type)
+		}
+		version = v
+	}
+	if event.Data, err = events.Default.Upcast(event.Type, version, event.Data); err != nil {
+		return nil, err
+	}
+	if current, ok := events.Default.Current(event.Type); ok {
+		event.DataSchema = current.DataSchema()
+	}
+	return event, nil
+}
+
+// Payload returns the data of the CloudEvent carried by the message, upcast to the current version of the events
+// catalog. Messages that are not CloudEvents, such as the ones published before the envelope was introduced, are
+// returned as is.
+func (m *Message) Payload() ([]byte, error) {
+	event, err := m.Event()
+	switch {
+	case errors.Is(err, cloudevents.ErrNotCloudEvent):
+		return m.Data, nil
+	case err != nil:
+		return nil, err
+	}
+	return event.Data, nil
+}
+
+// Typed adapts a handler of the JSON payload decoded as T. A payload that cannot be decoded fails permanently, unless
+// it is of a version newer than the known ones: a replica running a more recent release may process it instead.
+func Typed[T any](handler func(ctx context.Context, event T) error) Handler {
+	return func(ctx context.Context, msg *Message) error {
+		data, err := msg.Payload()
+		if errors.Is(err, events.ErrUnsupportedVersion) {
+			return fmt.Errorf("fail to decode %s: %w", msg.Subject, err)
+		}
+		if err != nil {
+			return Permanent(fmt.Errorf("fail to decode %s: %w", msg.Subject, err))
+		}
+
+		var event T
+		if err := json.Unmarshal(data, &event); err != nil {
+			return Permanent(fmt.Errorf("fail to decode %s: %w", msg.Subject, err))
+		}
+		return handler(ctx, event)
+	}
+}
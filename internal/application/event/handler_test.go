@@ -0,0 +1,136 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, msg *Message) error {
+				calls = append(calls, name+":before")
+				err := next(ctx, msg)
+				calls = append(calls, name+":after")
+				return err
+			}
+		}
+	}
+
+	handler := Chain(func(context.Context, *Message) error {
+		calls = append(calls, "handler")
+		return nil
+	}, record("outer"), record("inner"))
+
+	assert.NoError(t, handler(context.Background(), &Message{}))
+	assert.Equal(t, []string{"outer:before", "inner:before", "handler", "inner:after", "outer:after"}, calls)
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+
+	testCases := []struct {
+		name          string
+		header        nats.Header
+		data          string
+		handlerErr    error
+		expectedID    uuid.UUID
+		expectedErr   bool
+		expectedPerma bool
+	}{
+		{
+			name:       "Success Case - Decode Payload",
+			data:       `{"id":"20000000-0000-0000-0000-000000000001","label":"foo","value":1}`,
+			expectedID: id,
+		},
+		{
+			name:       "Success Case - Decode Deletion Payload",
+			data:       `{"id":"20000000-0000-0000-0000-000000000001"}`,
+			expectedID: id,
+		},
+		{
+			name:       "Success Case - Decode Structured CloudEvent",
+			header:     nats.Header{cloudevents.HeaderContentType: {cloudevents.ContentTypeStructured}},
+			data:       `{"specversion":"1.0","id":"1","source":"/astigo","type":"com.astigo.foo.created","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+			expectedID: id,
+		},
+		{
+			name: "Success Case - Decode Binary CloudEvent",
+			header: nats.Header{
+				cloudevents.HeaderSpecVersion: {"1.0"},
+				cloudevents.HeaderID:          {"1"},
+				cloudevents.HeaderSource:      {"/astigo"},
+				cloudevents.HeaderType:        {"com.astigo.foo.created"},
+			},
+			data:       `{"id":"20000000-0000-0000-0000-000000000001"}`,
+			expectedID: id,
+		},
+		{
+			name:       "Success Case - Decode Versioned CloudEvent",
+			header:     nats.Header{cloudevents.HeaderContentType: {cloudevents.ContentTypeStructured}},
+			data:       `{"specversion":"1.0","id":"1","source":"/astigo","type":"com.astigo.foo.created","dataschema":"urn:astigo:schema:com.astigo.foo.created:v1","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+			expectedID: id,
+		},
+		{
+			name:        "Failure Case - Newer Version Retried",
+			header:      nats.Header{cloudevents.HeaderContentType: {cloudevents.ContentTypeStructured}},
+			data:        `{"specversion":"1.0","id":"1","source":"/astigo","type":"com.astigo.foo.created","dataschema":"urn:astigo:schema:com.astigo.foo.created:v99","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+			expectedErr: true,
+		},
+		{
+			name:          "Failure Case - Foreign Dataschema",
+			header:        nats.Header{cloudevents.HeaderContentType: {cloudevents.ContentTypeStructured}},
+			data:          `{"specversion":"1.0","id":"1","source":"/astigo","type":"com.astigo.foo.created","dataschema":"urn:astigo:schema:com.astigo.foo.deleted:v1","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+			expectedErr:   true,
+			expectedPerma: true,
+		},
+		{
+			name:          "Failure Case - Invalid CloudEvent",
+			header:        nats.Header{cloudevents.HeaderContentType: {cloudevents.ContentTypeStructured}},
+			data:          `{"specversion":"1.0","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+			expectedErr:   true,
+			expectedPerma: true,
+		},
+		{
+			name:          "Failure Case - Malformed Payload",
+			data:          `{"id":`,
+			expectedErr:   true,
+			expectedPerma: true,
+		},
+		{
+			name:        "Failure Case - Handler Error",
+			data:        `{"id":"20000000-0000-0000-0000-000000000001"}`,
+			handlerErr:  errors.New("handler error"),
+			expectedID:  id,
+			expectedErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var received message.FooMessage
+			handler := Typed(func(_ context.Context, event message.FooMessage) error {
+				received = event
+				return testCase.handlerErr
+			})
+
+			err := handler(context.Background(), &Message{Subject: fooCreatedSubject, Header: testCase.header, Data: []byte(testCase.data)})
+
+			assert.Equal(t, testCase.expectedErr, err != nil)
+			assert.Equal(t, testCase.expectedPerma, errors.Is(err, ErrPermanent))
+			assert.Equal(t, testCase.expectedID, received.Id)
+		})
+	}
+}
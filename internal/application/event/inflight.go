@@ -0,0 +1,37 @@
+package event
+
+import "sync"
+
+// inflight tracks the messages being processed by a subscriber, so that closing it waits for them. Once closed, it
+// refuses new messages: adding to the wait group while waiting for it would race.
+type inflight struct {
+	mu     sync.Mutex
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// start registers a message being processed. It returns false once closed, in which case the message must be dropped.
+func (i *inflight) start() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.closed {
+		return false
+	}
+	i.wg.Add(1)
+	return true
+}
+
+// done unregisters a message registered by start.
+func (i *inflight) done() {
+	i.wg.Done()
+}
+
+// close refuses the new messages and waits for the ones being processed.
+func (i *inflight) close() {
+	i.mu.Lock()
+	i.closed = true
+	i.mu.Unlock()
+
+	i.wg.Wait()
+}
@@ -0,0 +1,51 @@
+package event
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultAck        = "ack"
+	resultRetry      = "retry"
+	resultDeadLetter = "dead_letter"
+	resultError      = "error"
+	resultSuccess    = "success"
+)
+
+var (
+	Messages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "event_messages_total",
+			Help: "Total number of event messages processed by workers, by outcome",
+		},
+		[]string{"subject", "result"},
+	)
+
+	HandlerDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "event_handler_duration_seconds",
+			Help:    "Duration of the event handlers in seconds, by subscription and outcome",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"subscription", "result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(Messages)
+	prometheus.MustRegister(HandlerDuration)
+}
+
+func observeMessage(subject, result string) {
+	Messages.WithLabelValues(subject, result).Inc()
+}
+
+func observeHandler(subscription string, err error, start time.Time) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	HandlerDuration.WithLabelValues(subscription, result).Observe(time.Since(start).Seconds())
+}
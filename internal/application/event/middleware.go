@@ -0,0 +1,131 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
+)
+
+// Tracing processes every message in a consumer span continuing the trace propagated by the publisher.
+func Tracing() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			ctx, span := startSpan(ctx, msg.Subject, msg.Header, len(msg.Data), msg.Subscription, "process "+msg.Subject)
+			defer span.End()
+			if id := msg.Header.Get(jetstream.MsgIDHeader); id != "" {
+				span.SetAttributes(attribute.String("messaging.message.id", id))
+			}
+			span.SetAttributes(attribute.Int64("messaging.nats.delivered", int64(msg.Delivered)))
+
+			if err := next(ctx, msg); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to process message")
+				return err
+			}
+
+			span.SetStatus(codes.Ok, "")
+			return nil
+		}
+	}
+}
+
+// Logging carries in the context the request id received in the message headers, or a new one, and a logger
+// enriched with it, then logs the outcome of the message.
+func Logging(logger *zap.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			ctx = startCorrelation(ctx, logger.With(zap.String("subscription", msg.Subscription)), msg.Subject, msg.Header)
+			start := time.Now()
+
+			err := next(ctx, msg)
+			fields := []zap.Field{
+				zap.Uint64("delivered", msg.Delivered),
+				zap.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				correlation.Logger(ctx, logger).Warn("fail to process message", append(fields, zap.Error(err))...)
+				return err
+			}
+
+			correlation.Logger(ctx, logger).Debug("message processed", fields...)
+			return nil
+		}
+	}
+}
+
+// Recovery turns a panic raised by the handler into an error, so that the message is delivered again instead of
+// crashing the consumer.
+func Recovery(logger *zap.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					correlation.Logger(ctx, logger).Error("panic recovered",
+						zap.Any("error", r),
+						zap.String("subject", msg.Subject),
+						zap.ByteString("stack", debug.Stack()),
+					)
+					err = fmt.Errorf("panic: %v", r)
+				}
+			}()
+
+			return next(ctx, msg)
+		}
+	}
+}
+
+// Metrics records the count and latency of the processed messages per subscription and outcome.
+func Metrics() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			start := time.Now()
+
+			err := next(ctx, msg)
+			observeHandler(msg.Subscription, err, start)
+
+			return err
+		}
+	}
+}
+
+// Retry calls the handler again, up to attempts times in total, while it fails with an error that is not permanent.
+// The backOff delay of the failed attempt is awaited before the next one, the last delay applying to the following
+// attempts. Attempts lower than or equal to one disable retries. It suits transports without redelivery, such as
+// core NATS.
+func Retry(attempts int, backOff []time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, msg *Message) error {
+			var err error
+			for attempt := 1; ; attempt++ {
+				if err = next(ctx, msg); err == nil || errors.Is(err, ErrPermanent) || attempt >= attempts {
+					return err
+				}
+
+				timer := time.NewTimer(backOffDelay(backOff, uint64(attempt)))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return err
+				case <-timer.C:
+				}
+			}
+		}
+	}
+}
+
+// backOffDelay returns the delay after the delivered-th failure of a message.
+func backOffDelay(backOff []time.Duration, delivered uint64) time.Duration {
+	if len(backOff) == 0 {
+		return 0
+	}
+	return backOff[min(int(delivered)-1, len(backOff)-1)]
+}
@@ -0,0 +1,134 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		errors        []error
+		expectedCalls int
+		expectedErr   bool
+	}{
+		{
+			name:          "Success Case - First Attempt",
+			errors:        []error{nil},
+			expectedCalls: 1,
+		},
+		{
+			name:          "Success Case - After Transient Failures",
+			errors:        []error{errors.New("transient"), errors.New("transient"), nil},
+			expectedCalls: 3,
+		},
+		{
+			name:          "Failure Case - Attempts Exhausted",
+			errors:        []error{errors.New("transient"), errors.New("transient"), errors.New("transient"), nil},
+			expectedCalls: 3,
+			expectedErr:   true,
+		},
+		{
+			name:          "Failure Case - Permanent Failure Not Retried",
+			errors:        []error{Permanent(errors.New("malformed")), nil},
+			expectedCalls: 1,
+			expectedErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			calls := 0
+			handler := Retry(3, []time.Duration{time.Millisecond})(func(context.Context, *Message) error {
+				err := testCase.errors[calls]
+				calls++
+				return err
+			})
+
+			err := handler(context.Background(), &Message{})
+
+			assert.Equal(t, testCase.expectedErr, err != nil)
+			assert.Equal(t, testCase.expectedCalls, calls)
+		})
+	}
+}
+
+func TestRetry_ContextCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	handler := Retry(3, []time.Duration{time.Hour})(func(context.Context, *Message) error {
+		calls++
+		return errors.New("transient")
+	})
+
+	assert.Error(t, handler(ctx, &Message{}))
+	assert.Equal(t, 1, calls)
+}
+
+func TestRecovery(t *testing.T) {
+	t.Parallel()
+	handler := Recovery(zap.NewNop())(func(context.Context, *Message) error {
+		panic("boom")
+	})
+
+	err := handler(context.Background(), &Message{Subject: fooCreatedSubject})
+
+	assert.EqualError(t, err, "panic: boom")
+	assert.False(t, errors.Is(err, ErrPermanent))
+}
+
+func TestTracingLogging(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	header := nats.Header{}
+	header.Set("traceparent", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	header.Set(correlation.HeaderRequestID, "req-42")
+
+	var requestID string
+	var spanContext trace.SpanContext
+	handler := Chain(func(ctx context.Context, _ *Message) error {
+		requestID = correlation.RequestID(ctx)
+		spanContext = trace.SpanContextFromContext(ctx)
+		return errors.New("handler error")
+	}, Tracing(), Logging(zap.NewNop()))
+
+	err := handler(context.Background(), &Message{
+		Subject:      fooCreatedSubject,
+		Header:       header,
+		Subscription: "foo-created",
+		Delivered:    2,
+	})
+
+	assert.EqualError(t, err, "handler error")
+	assert.Equal(t, "req-42", requestID)
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", spanContext.TraceID().String())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	ended := spans[0]
+	assert.Equal(t, "process foo.created", ended.Name())
+	assert.Equal(t, codes.Error, ended.Status().Code)
+	assert.Contains(t, ended.Attributes(), attribute.String("messaging.consumer.group.name", "foo-created"))
+	assert.Contains(t, ended.Attributes(), attribute.Int64("messaging.nats.delivered", 2))
+}
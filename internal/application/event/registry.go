@@ -0,0 +1,96 @@
+package event
+
+import "sync"
+
+// Subscription binds a handler to the subject of the events it processes.
+type Subscription struct {
+	// Name identifies the subscription. It names the queue group or the durable consumer shared by the replicas.
+	Name    string
+	Subject string
+	Handler Handler
+	// Concurrency bounds the messages of the subscription processed at the same time by a replica.
+	Concurrency int
+
+	middlewares []Middleware
+}
+
+// SubscriptionOption customizes how a subscription is registered.
+type SubscriptionOption func(*Subscription)
+
+// WithConcurrency processes up to n messages of the subscription at the same time. Values lower than 1 are ignored.
+func WithConcurrency(n int) SubscriptionOption {
+	return func(s *Subscription) {
+		if n > 0 {
+			s.Concurrency = n
+		}
+	}
+}
+
+// WithMiddleware wraps the handler of the subscription with middlewares, inside the ones of the registry.
+func WithMiddleware(middlewares ...Middleware) SubscriptionOption {
+	return func(s *Subscription) {
+		s.middlewares = append(s.middlewares, middlewares...)
+	}
+}
+
+// Registry holds the subscriptions that the consumers start, so that modules register their handlers without
+// knowing the transport delivering the events.
+type Registry struct {
+	mu            sync.RWMutex
+	middlewares   []Middleware
+	names         []string
+	subscriptions map[string]Subscription
+}
+
+// Use appends middlewares wrapping the handler of every subscription, the first one being the outermost.
+func (r *Registry) Use(middlewares ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middlewares = append(r.middlewares, middlewares...)
+}
+
+// Register adds or replaces the subscription identified by name. Messages are processed one at a time unless
+// WithConcurrency is given.
+func (r *Registry) Register(name, subject string, handler Handler, opts ...SubscriptionOption) {
+	s := Subscription{
+		Name:        name,
+		Subject:     subject,
+		Handler:     handler,
+		Concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.subscriptions[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.subscriptions[name] = s
+}
+
+// Subscriptions returns the registered subscriptions in registration order, their handler wrapped with the
+// middlewares of the registry then with their own.
+func (r *Registry) Subscriptions() []Subscription {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscriptions := make([]Subscription, 0, len(r.names))
+	for _, name := range r.names {
+		s := r.subscriptions[name]
+		middlewares := append(append([]Middleware{}, r.middlewares...), s.middlewares...)
+		s.Handler = Chain(s.Handler, middlewares...)
+		s.middlewares = nil
+		subscriptions = append(subscriptions, s)
+	}
+	return subscriptions
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		subscriptions: make(map[string]Subscription),
+	}
+}
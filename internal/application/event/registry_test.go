@@ -0,0 +1,46 @@
+package event
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Subscriptions(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, msg *Message) error {
+				calls = append(calls, name)
+				return next(ctx, msg)
+			}
+		}
+	}
+	handler := func(name string) Handler {
+		return func(context.Context, *Message) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	registry := NewRegistry()
+	registry.Use(record("global"))
+	registry.Register("foo-created", fooCreatedSubject, handler("created"), WithConcurrency(4), WithMiddleware(record("local")))
+	registry.Register("foo-deleted", fooDeletedSubject, handler("first"), WithConcurrency(0))
+	registry.Register("foo-deleted", fooDeletedSubject, handler("deleted"))
+
+	subscriptions := registry.Subscriptions()
+
+	assert.Len(t, subscriptions, 2)
+	assert.Equal(t, "foo-created", subscriptions[0].Name)
+	assert.Equal(t, fooCreatedSubject, subscriptions[0].Subject)
+	assert.Equal(t, 4, subscriptions[0].Concurrency)
+	assert.Equal(t, "foo-deleted", subscriptions[1].Name)
+	assert.Equal(t, 1, subscriptions[1].Concurrency)
+
+	assert.NoError(t, subscriptions[0].Handler(context.Background(), &Message{}))
+	assert.NoError(t, subscriptions[1].Handler(context.Background(), &Message{}))
+	assert.Equal(t, []string{"global", "local", "created", "global", "deleted"}, calls)
+}
@@ -0,0 +1,284 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.uber.org/zap"
+)
+
+const (
+	// deadLetterPrefix is prepended to the subject of dead-lettered events, which the dead-letter stream captures.
+	deadLetterPrefix = "dlq."
+
+	HeaderDeadLetterReason     = "Astigo-Dead-Letter-Reason"
+	HeaderDeadLetterDeliveries = "Astigo-Dead-Letter-Deliveries"
+
+	// legacyDurable is the durable consumer through which a single worker processed every Foo event, before each
+	// subscription got its own durable consumer.
+	legacyDurable = "foo-worker"
+)
+
+// WorkerConfig defines how the subscriptions process their events. A failed event is delivered again after the
+// BackOff delay of its attempt, the last delay applying to the following attempts. After MaxDeliver failed
+// deliveries it is moved to the dead-letter subject; a MaxDeliver lower than or equal to zero retries forever.
+// An event that is not acknowledged within AckWait, for instance because its worker stopped, is delivered again.
+// Without JetStream, failed events are retried in process up to MaxDeliver attempts, then dropped.
+// Concurrency bounds the events of each subscription processed at the same time by a replica.
+type WorkerConfig struct {
+	MaxDeliver    int             `mapstructure:"max_deliver"`
+	AckWait       time.Duration   `mapstructure:"ack_wait"`
+	BackOff       []time.Duration `mapstructure:"backoff"`
+	MaxAckPending int             `mapstructure:"max_ack_pending"`
+	Concurrency   int             `mapstructure:"concurrency"`
+}
+
+// jetStreamPublisher is the part of jetstream.JetStream used to dead-letter events.
+type jetStreamPublisher interface {
+	PublishMsg(ctx context.Context, msg *nats.Msg, opts ...jetstream.PublishOpt) (*jetstream.PubAck, error)
+}
+
+// SubscriberJetStream runs a subscription over the events persisted in JetStream, through a durable pull consumer
+// named after it and shared by every replica. Events are acknowledged once processed, so that they are neither lost
+// while no worker runs nor when their processing fails.
+// The handlers run with a context cancelled on close: the events they were processing are delivered again.
+type SubscriberJetStream struct {
+	Logger       *zap.Logger
+	publisher    jetStreamPublisher
+	config       WorkerConfig
+	subscription Subscription
+	consume      jetstream.ConsumeContext
+	slots        chan struct{}
+	ctx          context.Context
+	cancel       context.CancelFunc
+	inflight     inflight
+}
+
+// OnMessage processes the message in the background once one of the concurrency slots of the subscription is free.
+// Messages received once the subscriber is closing are delivered again.
+func (s *SubscriberJetStream) OnMessage(msg jetstream.Msg) {
+	s.slots <- struct{}{}
+	if !s.inflight.start() {
+		<-s.slots
+		_ = msg.Nak()
+		return
+	}
+	go func() {
+		defer func() {
+			<-s.slots
+			s.inflight.done()
+		}()
+		s.process(msg)
+	}()
+}
+
+// process handles the message then settles it: it is acknowledged on success, dead-lettered once its deliveries are
+// exhausted or its failure is permanent, and delivered again after a backoff otherwise. A failure caused by the
+// subscriber closing does not count: the message is delivered again at once, possibly to another replica.
+func (s *SubscriberJetStream) process(msg jetstream.Msg) {
+	var delivered uint64 = 1
+	if metadata, err := msg.Metadata(); err == nil {
+		delivered = metadata.NumDelivered
+	}
+
+	ctx := s.ctx
+	logger := s.Logger.With(
+		zap.String("subject", msg.Subject()),
+		zap.String("subscription", s.subscription.Name),
+		zap.String("request_id", msg.Headers().Get(correlation.HeaderRequestID)),
+		zap.Uint64("delivered", delivered),
+	)
+
+	err := s.subscription.Handler(ctx, &Message{
+		Subject:      msg.Subject(),
+		Data:         msg.Data(),
+		Header:       msg.Headers(),
+		Subscription: s.subscription.Name,
+		Delivered:    delivered,
+	})
+	if err == nil {
+		if err := msg.Ack(); err != nil {
+			logger.Warn("fail to ack message", zap.Error(err))
+		}
+		observeMessage(msg.Subject(), resultAck)
+		return
+	}
+
+	if ctx.Err() != nil {
+		logger.Debug("message interrupted by close, deliver again")
+		observeMessage(msg.Subject(), resultRetry)
+		if err := msg.Nak(); err != nil {
+			logger.Warn("fail to nak message", zap.Error(err))
+		}
+		return
+	}
+
+	permanent := errors.Is(err, ErrPermanent)
+	if permanent || (s.config.MaxDeliver > 0 && delivered >= uint64(s.config.MaxDeliver)) {
+		dlqErr := s.deadLetter(ctx, msg, delivered, err)
+		if dlqErr == nil {
+			logger.Error("move message to dead-letter subject", zap.Error(err))
+			observeMessage(msg.Subject(), resultDeadLetter)
+			return
+		}
+		// Keep the event rather than losing it: it is retried until it can be dead-lettered.
+		logger.Error("fail to dead-letter message", zap.Error(dlqErr))
+	}
+
+	delay := backOffDelay(s.config.BackOff, delivered)
+	// The failure itself is logged by the Logging middleware.
+	logger.Debug("retry message later", zap.Duration("delay", delay))
+	observeMessage(msg.Subject(), resultRetry)
+	if err := msg.NakWithDelay(delay); err != nil {
+		logger.Warn("fail to nak message", zap.Error(err))
+	}
+}
+
+// deadLetter publishes the event to the dead-letter subject along with the reason of its failure, then terminates it.
+func (s *SubscriberJetStream) deadLetter(ctx context.Context, msg jetstream.Msg, delivered uint64, cause error) error {
+	dlq := nats.NewMsg(deadLetterPrefix + msg.Subject())
+	dlq.Data = msg.Data()
+	// Keep the correlation and trace headers, but not the JetStream ones such as the message id.
+	for key, values := range msg.Headers() {
+		if !strings.HasPrefix(key, "Nats-") {
+			dlq.Header[key] = values
+		}
+	}
+	dlq.Header.Set(HeaderDeadLetterReason, cause.Error())
+	dlq.Header.Set(HeaderDeadLetterDeliveries, strconv.FormatUint(delivered, 10))
+
+	var opts []jetstream.PublishOpt
+	if metadata, err := msg.Metadata(); err == nil {
+		// Dead-lettering the same stream message twice, if the termination below is lost, yields a single entry.
+		opts = append(opts, jetstream.WithMsgID(fmt.Sprintf("dlq:%s:%d", metadata.Stream, metadata.Sequence.Stream)))
+	}
+
+	if _, err := s.publisher.PublishMsg(ctx, dlq, opts...); err != nil {
+		return fmt.Errorf("fail to publish to dead-letter subject: %w", err)
+	}
+
+	if err := msg.TermWithReason(cause.Error()); err != nil {
+		s.Logger.Warn("fail to terminate message", zap.String("subject", msg.Subject()), zap.Error(err))
+	}
+	return nil
+}
+
+// Close stops consuming, cancels the context of the messages being processed and waits for them.
+func (s *SubscriberJetStream) Close() error {
+	if s.consume != nil {
+		s.consume.Stop()
+	}
+	s.cancel()
+	s.inflight.close()
+	return nil
+}
+
+// legacyStartSequence returns the stream sequence of the first event that the legacy consumer did not acknowledge, from
+// which the durable consumers of the subscriptions start so that no event is lost nor replayed since the beginning.
+// It returns 0 when there is no legacy consumer.
+func legacyStartSequence(ctx context.Context, js jetstream.JetStream, stream string) (uint64, error) {
+	consumer, err := js.Consumer(ctx, stream, legacyDurable)
+	if errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get consumer %s: %w", legacyDurable, err)
+	}
+	return consumer.CachedInfo().AckFloor.Stream + 1, nil
+}
+
+// deleteLegacyConsumer removes the legacy consumer, once the durable consumers of the subscriptions took over.
+func deleteLegacyConsumer(ctx context.Context, js jetstream.JetStream, stream string) error {
+	err := js.DeleteConsumer(ctx, stream, legacyDurable)
+	if err != nil && !errors.Is(err, jetstream.ErrConsumerNotFound) {
+		return fmt.Errorf("failed to delete consumer %s: %w", legacyDurable, err)
+	}
+	return nil
+}
+
+// NewSubscriberJetStream creates or updates the durable consumer of the subscription on the stream and starts
+// consuming it. A new consumer only receives the events published from now on, or from startSequence when it is not
+// zero, while an existing consumer resumes where it stopped.
+func NewSubscriberJetStream(
+	ctx context.Context,
+	logger *zap.Logger,
+	js jetstream.JetStream,
+	stream string,
+	config WorkerConfig,
+	subscription Subscription,
+	startSequence uint64,
+) (*SubscriberJetStream, error) {
+	subscriber := newSubscriberJetStream(logger, js, config, subscription)
+
+	// Deliveries are bounded by the subscriber rather than by the server, so that an event which cannot be
+	// dead-lettered is kept instead of being dropped silently.
+	consumerConfig := jetstream.ConsumerConfig{
+		Durable:       subscription.Name,
+		FilterSubject: subscription.Subject,
+		DeliverPolicy: jetstream.DeliverNewPolicy,
+		AckPolicy:     jetstream.AckExplicitPolicy,
+		AckWait:       config.AckWait,
+		MaxDeliver:    -1,
+		MaxAckPending: config.MaxAckPending,
+	}
+	if startSequence > 0 {
+		consumerConfig.DeliverPolicy = jetstream.DeliverByStartSequencePolicy
+		consumerConfig.OptStartSeq = startSequence
+	}
+	// The deliver policy of a consumer cannot be updated: keep the one it was created with.
+	existing, err := js.Consumer(ctx, stream, subscription.Name)
+	switch {
+	case err == nil:
+		info := existing.CachedInfo().Config
+		consumerConfig.DeliverPolicy = info.DeliverPolicy
+		consumerConfig.OptStartSeq = info.OptStartSeq
+		consumerConfig.OptStartTime = info.OptStartTime
+	case !errors.Is(err, jetstream.ErrConsumerNotFound):
+		subscriber.cancel()
+		return nil, fmt.Errorf("failed to get consumer %s: %w", subscription.Name, err)
+	}
+
+	consumer, err := js.CreateOrUpdateConsumer(ctx, stream, consumerConfig)
+	if err != nil {
+		subscriber.cancel()
+		return nil, fmt.Errorf("failed to create consumer %s: %w", subscription.Name, err)
+	}
+
+	subscriber.consume, err = consumer.Consume(subscriber.OnMessage, jetstream.ConsumeErrHandler(
+		func(_ jetstream.ConsumeContext, err error) {
+			logger.Warn("jetstream consume error", zap.String("consumer", subscription.Name), zap.Error(err))
+		},
+	))
+	if err != nil {
+		subscriber.cancel()
+		return nil, fmt.Errorf("failed to consume %s: %w", subscription.Name, err)
+	}
+
+	return subscriber, nil
+}
+
+func newSubscriberJetStream(
+	logger *zap.Logger,
+	publisher jetStreamPublisher,
+	config WorkerConfig,
+	subscription Subscription,
+) *SubscriberJetStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SubscriberJetStream{
+		Logger:       logger,
+		publisher:    publisher,
+		config:       config,
+		subscription: subscription,
+		slots:        make(chan struct{}, max(subscription.Concurrency, 1)),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
@@ -0,0 +1,190 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// stubMsg is a JetStream message recording how it was settled.
+type stubMsg struct {
+	subject   string
+	data      []byte
+	header    nats.Header
+	delivered uint64
+
+	acked      bool
+	nakDelay   *time.Duration
+	termReason string
+}
+
+func (m *stubMsg) Metadata() (*jetstream.MsgMetadata, error) {
+	return &jetstream.MsgMetadata{
+		Stream:       "FOO",
+		Sequence:     jetstream.SequencePair{Stream: 42, Consumer: 7},
+		NumDelivered: m.delivered,
+	}, nil
+}
+func (m *stubMsg) Data() []byte                           { return m.data }
+func (m *stubMsg) Headers() nats.Header                   { return m.header }
+func (m *stubMsg) Subject() string                        { return m.subject }
+func (m *stubMsg) Reply() string                          { return "" }
+func (m *stubMsg) Ack() error                             { m.acked = true; return nil }
+func (m *stubMsg) DoubleAck(context.Context) error        { m.acked = true; return nil }
+func (m *stubMsg) Nak() error                             { return m.NakWithDelay(0) }
+func (m *stubMsg) NakWithDelay(delay time.Duration) error { m.nakDelay = &delay; return nil }
+func (m *stubMsg) InProgress() error                      { return nil }
+func (m *stubMsg) Term() error                            { return m.TermWithReason("") }
+func (m *stubMsg) TermWithReason(reason string) error     { m.termReason = reason; return nil }
+
+// stubPublisher records the messages published to JetStream.
+type stubPublisher struct {
+	err       error
+	published []*nats.Msg
+}
+
+func (p *stubPublisher) PublishMsg(_ context.Context, msg *nats.Msg, _ ...jetstream.PublishOpt) (*jetstream.PubAck, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	p.published = append(p.published, msg)
+	return &jetstream.PubAck{}, nil
+}
+
+func TestSubscriberJetStream_Process(t *testing.T) {
+	t.Parallel()
+	config := WorkerConfig{
+		MaxDeliver: 3,
+		BackOff:    []time.Duration{time.Second, 10 * time.Second},
+	}
+	failing := func(context.Context, *Message) error { return errors.New("handler error") }
+	malformed := func(context.Context, *Message) error { return Permanent(errors.New("malformed payload")) }
+	succeeding := func(context.Context, *Message) error { return nil }
+	interrupted := func(ctx context.Context, _ *Message) error { return ctx.Err() }
+
+	testCases := []struct {
+		name      string
+		subject   string
+		delivered uint64
+		handler   Handler
+		publisher *stubPublisher
+		closed    bool
+
+		expectedAck        bool
+		expectedNakDelay   *time.Duration
+		expectedTerm       string
+		expectedDeadLetter bool
+	}{
+		{
+			name:        "Success Case - Ack",
+			subject:     fooCreatedSubject,
+			delivered:   1,
+			handler:     succeeding,
+			publisher:   &stubPublisher{},
+			expectedAck: true,
+		},
+		{
+			name:             "Failure Case - Retry With First Backoff",
+			subject:          fooCreatedSubject,
+			delivered:        1,
+			handler:          failing,
+			publisher:        &stubPublisher{},
+			expectedNakDelay: durationPtr(time.Second),
+		},
+		{
+			name:             "Failure Case - Retry With Last Backoff",
+			subject:          fooUpdatedSubject,
+			delivered:        2,
+			handler:          failing,
+			publisher:        &stubPublisher{},
+			expectedNakDelay: durationPtr(10 * time.Second),
+		},
+		{
+			name:               "Failure Case - Dead Letter",
+			subject:            fooDeletedSubject,
+			delivered:          3,
+			handler:            failing,
+			publisher:          &stubPublisher{},
+			expectedTerm:       "handler error",
+			expectedDeadLetter: true,
+		},
+		{
+			name:             "Failure Case - Dead Letter Unavailable",
+			subject:          fooDeletedSubject,
+			delivered:        3,
+			handler:          failing,
+			publisher:        &stubPublisher{err: errors.New("no responders")},
+			expectedNakDelay: durationPtr(10 * time.Second),
+		},
+		{
+			name:               "Failure Case - Permanent Failure Dead Lettered At Once",
+			subject:            fooCreatedSubject,
+			delivered:          1,
+			handler:            malformed,
+			publisher:          &stubPublisher{},
+			expectedTerm:       "permanent failure: malformed payload",
+			expectedDeadLetter: true,
+		},
+		{
+			name:             "Failure Case - Interrupted By Close Delivered Again",
+			subject:          fooDeletedSubject,
+			delivered:        3,
+			handler:          interrupted,
+			publisher:        &stubPublisher{},
+			closed:           true,
+			expectedNakDelay: durationPtr(0),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			subscriber := newSubscriberJetStream(zap.NewNop(), testCase.publisher, config, Subscription{
+				Name:    "foo-worker",
+				Subject: testCase.subject,
+				Handler: testCase.handler,
+			})
+			if testCase.closed {
+				assert.NoError(t, subscriber.Close())
+			}
+			msg := &stubMsg{
+				subject: testCase.subject,
+				data:    []byte(`{"id":"20000000-0000-0000-0000-000000000001"}`),
+				header: nats.Header{
+					"Nats-Msg-Id":  {"foo.created:20000000-0000-0000-0000-000000000001:1"},
+					"X-Request-ID": {"req-42"},
+				},
+				delivered: testCase.delivered,
+			}
+
+			subscriber.process(msg)
+
+			assert.Equal(t, testCase.expectedAck, msg.acked)
+			assert.Equal(t, testCase.expectedNakDelay, msg.nakDelay)
+			assert.Equal(t, testCase.expectedTerm, msg.termReason)
+			if !testCase.expectedDeadLetter {
+				assert.Empty(t, testCase.publisher.published)
+				return
+			}
+			assert.Len(t, testCase.publisher.published, 1)
+			dlq := testCase.publisher.published[0]
+			assert.Equal(t, "dlq."+testCase.subject, dlq.Subject)
+			assert.Equal(t, msg.data, dlq.Data)
+			assert.Equal(t, testCase.expectedTerm, dlq.Header.Get(HeaderDeadLetterReason))
+			assert.Equal(t, strconv.FormatUint(testCase.delivered, 10), dlq.Header.Get(HeaderDeadLetterDeliveries))
+			assert.Equal(t, "req-42", dlq.Header.Get("X-Request-ID"))
+			assert.Empty(t, dlq.Header.Get("Nats-Msg-Id"))
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -0,0 +1,93 @@
+package event
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+// SubscriberNats runs a subscription over core NATS, shared between replicas through a queue group named after it.
+// Messages published while no replica is subscribed, or whose processing fails, are lost.
+// The handlers run with a context cancelled on close, which interrupts the retries waiting for their backoff.
+type SubscriberNats struct {
+	Logger       *zap.Logger
+	subscription Subscription
+	sub          *nats.Subscription
+	slots        chan struct{}
+	ctx          context.Context
+	cancel       context.CancelFunc
+	inflight     inflight
+}
+
+// OnMessage processes the message in the background once one of the concurrency slots of the subscription is free.
+// Waiting for a slot holds the following messages in the pending buffer of the NATS subscription. Messages received
+// once the subscriber is closing are dropped.
+func (s *SubscriberNats) OnMessage(msg *nats.Msg) {
+	select {
+	case s.slots <- struct{}{}:
+	case <-s.ctx.Done():
+		return
+	}
+	if !s.inflight.start() {
+		<-s.slots
+		return
+	}
+	go func() {
+		defer func() {
+			<-s.slots
+			s.inflight.done()
+		}()
+		s.process(msg)
+	}()
+}
+
+func (s *SubscriberNats) process(msg *nats.Msg) {
+	err := s.subscription.Handler(s.ctx, &Message{
+		Subject:      msg.Subject,
+		Data:         msg.Data,
+		Header:       msg.Header,
+		Subscription: s.subscription.Name,
+		Delivered:    1,
+	})
+	if err != nil {
+		observeMessage(msg.Subject, resultError)
+		return
+	}
+
+	observeMessage(msg.Subject, resultAck)
+}
+
+// Close stops the subscription, cancels the context of the messages being processed and waits for them.
+func (s *SubscriberNats) Close() error {
+	err := s.sub.Unsubscribe()
+	s.cancel()
+	s.inflight.close()
+
+	return err
+}
+
+func NewSubscriberNats(logger *zap.Logger, conn *nats.Conn, subscription Subscription) (*SubscriberNats, error) {
+	subscriber := newSubscriberNats(logger, subscription)
+
+	sub, err := conn.QueueSubscribe(subscription.Subject, subscription.Name, subscriber.OnMessage)
+	if err != nil {
+		subscriber.cancel()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", subscription.Subject, err)
+	}
+	subscriber.sub = sub
+
+	return subscriber, nil
+}
+
+func newSubscriberNats(logger *zap.Logger, subscription Subscription) *SubscriberNats {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SubscriberNats{
+		Logger:       logger,
+		subscription: subscription,
+		slots:        make(chan struct{}, max(subscription.Concurrency, 1)),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
@@ -0,0 +1,42 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSubscriberNats_Close(t *testing.T) {
+	t.Parallel()
+	started := make(chan struct{}, 1)
+	calls := 0
+	handler := Retry(5, []time.Duration{time.Hour})(func(context.Context, *Message) error {
+		calls++
+		started <- struct{}{}
+		return errors.New("transient")
+	})
+	subscriber := newSubscriberNats(zap.NewNop(), Subscription{Name: "foo-created", Subject: "foo.created", Handler: handler, Concurrency: 1})
+	subscriber.sub = new(nats.Subscription)
+
+	subscriber.OnMessage(nats.NewMsg("foo.created"))
+	<-started
+
+	closed := make(chan struct{})
+	go func() {
+		_ = subscriber.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("close waits for the backoff of the retried message")
+	}
+
+	subscriber.OnMessage(nats.NewMsg("foo.created"))
+	assert.Equal(t, 1, calls, "messages received once closed are dropped")
+}
@@ -0,0 +1,48 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+)
+
+// fooSubjects matches the subjects of every Foo event.
+const fooSubjects = "foo.*"
+
+// WebhookHandler enqueues the Foo events for delivery to the webhook subscriptions of their type. Each delivery carries
+// the CloudEvent in structured mode, its data upcast to the current version.
+type WebhookHandler struct {
+	Service service.IWebhookService
+}
+
+func (h *WebhookHandler) Handle(ctx context.Context, msg *Message) error {
+	event, err := msg.Event()
+	if errors.Is(err, events.ErrUnsupportedVersion) {
+		return fmt.Errorf("fail to decode %s: %w", msg.Subject, err)
+	}
+	if err != nil {
+		return Permanent(fmt.Errorf("fail to decode %s: %w", msg.Subject, err))
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return Permanent(fmt.Errorf("fail to encode %s: %w", msg.Subject, err))
+	}
+
+	return h.Service.Enqueue(ctx, data.WebhookEventInput{
+		Id:      event.ID,
+		Type:    event.Type,
+		Payload: payload,
+	})
+}
+
+// RegisterWebhookHandlers registers the subscription enqueuing the Foo events for the webhooks.
+func RegisterWebhookHandlers(registry *Registry, svc service.IWebhookService, opts ...SubscriptionOption) {
+	handler := &WebhookHandler{Service: svc}
+	registry.Register("webhook", fooSubjects, handler.Handle, opts...)
+}
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookHandler_Handle(t *testing.T) {
+	t.Parallel()
+	encoder, err := cloudevents.NewEncoder(cloudevents.Config{Mode: cloudevents.ModeBinary, Source: "/astigo"})
+	assert.NoError(t, err)
+	deleted := nats.NewMsg("foo.deleted")
+	assert.NoError(t, encoder.Encode(deleted, cloudevents.Event{
+		ID:   "foo.deleted:20000000-0000-0000-0000-000000000001:0",
+		Type: events.FooDeletedType,
+		Data: json.RawMessage(`{"id":"20000000-0000-0000-0000-000000000001"}`),
+	}))
+
+	testCases := []struct {
+		name          string
+		msg           *Message
+		enqueueErr    error
+		expectedErr   bool
+		expectedPerma bool
+	}{
+		{name: "Success Case - Enqueued", msg: &Message{Subject: deleted.Subject, Header: deleted.Header, Data: deleted.Data}},
+		{name: "Failure Case - Enqueue Error", msg: &Message{Subject: deleted.Subject, Header: deleted.Header, Data: deleted.Data}, enqueueErr: errors.New("repository error"), expectedErr: true},
+		{name: "Failure Case - Not A CloudEvent", msg: &Message{Subject: "foo.deleted", Header: nats.Header{}, Data: []byte(`{}`)}, expectedErr: true, expectedPerma: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockService := new(service.MockWebhookService)
+			mockService.On("Enqueue", mock.Anything, mock.MatchedBy(func(input data.WebhookEventInput) bool {
+				var event cloudevents.Event
+				if err := json.Unmarshal(input.Payload, &event); err != nil {
+					return false
+				}
+				return input.Id == "foo.deleted:20000000-0000-0000-0000-000000000001:0" && input.Type == events.FooDeletedType &&
+					event.SpecVersion == cloudevents.SpecVersion && event.DataSchema == events.FooDeletedV1.DataSchema()
+			})).Return(testCase.enqueueErr)
+			handler := &WebhookHandler{Service: mockService}
+
+			err := handler.Handle(context.Background(), testCase.msg)
+
+			if !testCase.expectedErr {
+				assert.NoError(t, err)
+				mockService.AssertExpectations(t)
+				return
+			}
+			assert.Error(t, err)
+			assert.Equal(t, testCase.expectedPerma, errors.Is(err, ErrPermanent))
+		})
+	}
+}
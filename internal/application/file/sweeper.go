@@ -0,0 +1,90 @@
+// Package file runs the expiration of the incomplete file uploads in the background.
+package file
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+
+	"go.uber.org/zap"
+)
+
+const defaultSweepInterval = time.Minute
+
+// Config holds the settings of the sweeper.
+// SweepInterval is the time waited for new expired uploads once none is left.
+type Config struct {
+	SweepInterval time.Duration `mapstructure:"sweep_interval"`
+}
+
+// Sweeper removes the expired uploads until it is closed. Every replica runs one: an upload is failed before being
+// removed, so that replicas sweeping the same upload do not complete it.
+type Sweeper struct {
+	logger *zap.Logger
+	svc    service.IFileService
+	config Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Start runs the sweeper in the background until Close is called.
+func (s *Sweeper) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+}
+
+// run removes the expired uploads batch after batch, and waits for the sweep interval once none is left or on failure.
+func (s *Sweeper) run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		for ctx.Err() == nil {
+			// A batch being removed is completed on close, so that no upload is left failed with its object.
+			count, err := s.svc.ExpireUploads(context.WithoutCancel(ctx))
+			if err != nil {
+				s.logger.Warn("fail to expire uploads", zap.Error(err))
+				break
+			}
+			if count == 0 {
+				break
+			}
+		}
+		timer.Reset(s.config.SweepInterval)
+	}
+}
+
+// Close stops the sweeper and waits for the uploads being removed.
+func (s *Sweeper) Close() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	s.wg.Wait()
+}
+
+// NewSweeper initializes a new Sweeper of the expired uploads with the provided logger, configuration and service.
+func NewSweeper(logger *zap.Logger, config Config, svc service.IFileService) *Sweeper {
+	if config.SweepInterval <= 0 {
+		config.SweepInterval = defaultSweepInterval
+	}
+
+	return &Sweeper{
+		logger: logger,
+		svc:    svc,
+		config: config,
+	}
+}
@@ -0,0 +1,67 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestSweeper(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name  string
+		setup func(*service.MockFileService, chan struct{})
+	}{
+		{
+			name: "Success Case - Drain Batches Then Wait",
+			setup: func(mockService *service.MockFileService, swept chan struct{}) {
+				mockService.On("ExpireUploads", mock.Anything).Return(10, nil).Twice()
+				mockService.On("ExpireUploads", mock.Anything).Return(0, nil).Once()
+				mockService.On("ExpireUploads", mock.Anything).Return(0, nil).Run(func(mock.Arguments) {
+					select {
+					case swept <- struct{}{}:
+					default:
+					}
+				})
+			},
+		},
+		{
+			name: "Failure Case - Sweep Again After Error",
+			setup: func(mockService *service.MockFileService, swept chan struct{}) {
+				mockService.On("ExpireUploads", mock.Anything).Return(0, errors.New("repository error")).Once()
+				mockService.On("ExpireUploads", mock.Anything).Return(0, nil).Run(func(mock.Arguments) {
+					select {
+					case swept <- struct{}{}:
+					default:
+					}
+				})
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockService := new(service.MockFileService)
+			swept := make(chan struct{})
+			testCase.setup(mockService, swept)
+			sweeper := NewSweeper(zap.NewNop(), Config{SweepInterval: 10 * time.Millisecond}, mockService)
+
+			sweeper.Start(context.Background())
+			select {
+			case <-swept:
+			case <-time.After(time.Second):
+				t.Fatal("sweeper did not sweep again")
+			}
+			sweeper.Close()
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/TancelinMazzotti/astigo/pkg/proto"
+	"github.com/TancelinMazzotti/astigo/pkg/proto/protoconnect"
+
+	"connectrpc.com/connect"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	_ protoconnect.FooServiceHandler = (*FooConnectService)(nil)
+)
+
+// FooConnectService exposes the gRPC FooService implementation through the Connect protocol,
+// which also serves gRPC and gRPC-Web clients over plain HTTP handlers.
+type FooConnectService struct {
+	svc proto.FooServiceServer
+}
+
+func (s *FooConnectService) Create(ctx context.Context, req *connect.Request[proto.CreateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return unary(ctx, req, s.svc.Create)
+}
+
+func (s *FooConnectService) Get(ctx context.Context, req *connect.Request[proto.GetFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return unary(ctx, req, s.svc.Get)
+}
+
+func (s *FooConnectService) Update(ctx context.Context, req *connect.Request[proto.UpdateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return unary(ctx, req, s.svc.Update)
+}
+
+func (s *FooConnectService) Patch(ctx context.Context, req *connect.Request[proto.PatchFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return unary(ctx, req, s.svc.Patch)
+}
+
+func (s *FooConnectService) Delete(ctx context.Context, req *connect.Request[proto.DeleteFooRequest]) (*connect.Response[proto.DeleteFooResponse], error) {
+	return unary(ctx, req, s.svc.Delete)
+}
+
+func (s *FooConnectService) List(ctx context.Context, req *connect.Request[proto.ListFoosRequest]) (*connect.Response[proto.ListFoosResponse], error) {
+	return unary(ctx, req, s.svc.List)
+}
+
+// unary forwards a Connect request to the matching gRPC method and maps gRPC status errors to Connect errors.
+func unary[Req, Res any](
+	ctx context.Context,
+	req *connect.Request[Req],
+	method func(context.Context, *Req) (*Res, error),
+) (*connect.Response[Res], error) {
+	resp, err := method(ctx, req.Msg)
+	if err != nil {
+		st := status.Convert(err)
+		return nil, connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+func NewFooConnectService(svc proto.FooServiceServer) *FooConnectService {
+	return &FooConnectService{svc: svc}
+}
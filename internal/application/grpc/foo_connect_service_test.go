@@ -0,0 +1,123 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+	"github.com/TancelinMazzotti/astigo/pkg/proto"
+	"github.com/TancelinMazzotti/astigo/pkg/proto/protoconnect"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFooConnectService_Get(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		request        *proto.GetFooRequest
+		options        []connect.ClientOption
+		expectedResult *proto.Foo
+		expectedCode   connect.Code
+
+		setupMockHandler func(*service.MockFooService)
+	}{
+		{
+			name:    "Success Case - Connect",
+			request: &proto.GetFooRequest{Id: "20000000-0000-0000-0000-000000000001"},
+			expectedResult: &proto.Foo{
+				Id:     "20000000-0000-0000-0000-000000000001",
+				Label:  "foo1",
+				Value:  1,
+				Weight: 1.5,
+			},
+
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, mock.Anything).Return(&model.Foo{
+					Id:     uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+					Label:  "foo1",
+					Value:  1,
+					Weight: 1.5,
+				}, nil)
+			},
+		},
+		{
+			name:    "Success Case - gRPC-Web",
+			request: &proto.GetFooRequest{Id: "20000000-0000-0000-0000-000000000001"},
+			options: []connect.ClientOption{connect.WithGRPCWeb()},
+			expectedResult: &proto.Foo{
+				Id:    "20000000-0000-0000-0000-000000000001",
+				Label: "foo1",
+			},
+
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, mock.Anything).Return(&model.Foo{
+					Id:    uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+					Label: "foo1",
+				}, nil)
+			},
+		},
+		{
+			name:         "Failure Case - Service Error",
+			request:      &proto.GetFooRequest{Id: "20000000-0000-0000-0000-000000000001"},
+			expectedCode: connect.CodeUnknown,
+
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, mock.Anything).
+					Return((*model.Foo)(nil), fmt.Errorf("service error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockHandler := new(service.MockFooService)
+			testCase.setupMockHandler(mockHandler)
+
+			client := newFooConnectClient(t, mockHandler, testCase.options...)
+			resp, err := client.Get(context.Background(), connect.NewRequest(testCase.request))
+
+			if testCase.expectedCode != 0 {
+				assert.Error(t, err)
+				assert.Equal(t, testCase.expectedCode, connect.CodeOf(err))
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedResult.Id, resp.Msg.Foo.Id)
+				assert.Equal(t, testCase.expectedResult.Label, resp.Msg.Foo.Label)
+				assert.Equal(t, testCase.expectedResult.Value, resp.Msg.Foo.Value)
+				assert.Equal(t, testCase.expectedResult.Weight, resp.Msg.Foo.Weight)
+			}
+		})
+	}
+}
+
+func TestFooConnectService_Patch(t *testing.T) {
+	t.Parallel()
+	mockHandler := new(service.MockFooService)
+	client := newFooConnectClient(t, mockHandler)
+
+	_, err := client.Patch(context.Background(), connect.NewRequest(&proto.PatchFooRequest{
+		Id:  "20000000-0000-0000-0000-000000000001",
+		Foo: &proto.FooPatch{Label: "foo_patch"},
+	}))
+
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	mockHandler.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func newFooConnectClient(t *testing.T, mockHandler *service.MockFooService, options ...connect.ClientOption) protoconnect.FooServiceClient {
+	t.Helper()
+	_, handler := protoconnect.NewFooServiceHandler(NewFooConnectService(NewFooService(mockHandler)))
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return protoconnect.NewFooServiceClient(server.Client(), server.URL, options...)
+}
@@ -4,11 +4,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
 	"github.com/TancelinMazzotti/astigo/pkg/proto"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
@@ -31,12 +36,7 @@ func (s *FooService) List(ctx context.Context, req *proto.ListFoosRequest) (*pro
 
 	foosProto := make([]*proto.Foo, len(foos))
 	for i, foo := range foos {
-		foosProto[i] = &proto.Foo{
-			Id:     foo.Id.String(),
-			Label:  foo.Label,
-			Value:  int32(foo.Value),
-			Weight: foo.Weight,
-		}
+		foosProto[i] = newFooProto(foo)
 	}
 
 	return &proto.ListFoosResponse{Foos: foosProto}, nil
@@ -54,12 +54,7 @@ func (s *FooService) Get(ctx context.Context, req *proto.GetFooRequest) (*proto.
 	}
 
 	return &proto.FooResponse{
-		Foo: &proto.Foo{
-			Id:     foo.Id.String(),
-			Label:  foo.Label,
-			Value:  int32(foo.Value),
-			Weight: foo.Weight,
-		},
+		Foo: newFooProto(foo),
 	}, nil
 }
 
@@ -75,12 +70,7 @@ func (s *FooService) Create(ctx context.Context, req *proto.CreateFooRequest) (*
 	}
 
 	return &proto.FooResponse{
-		Foo: &proto.Foo{
-			Id:     foo.Id.String(),
-			Label:  foo.Label,
-			Value:  int32(foo.Value),
-			Weight: foo.Weight,
-		},
+		Foo: newFooProto(foo),
 	}, nil
 }
 
@@ -90,23 +80,41 @@ func (s *FooService) Update(ctx context.Context, req *proto.UpdateFooRequest) (*
 		return nil, fmt.Errorf("fail to parse id: %w", err)
 	}
 
-	if err := s.svc.Update(ctx, &data.FooUpdateInput{
+	foo, err := s.svc.Update(ctx, &data.FooUpdateInput{
 		Id:     id,
 		Label:  req.Label,
 		Secret: req.Secret,
 		Value:  int(req.Value),
 		Weight: req.Weight,
-	}); err != nil {
+	})
+	if err != nil {
 		return nil, fmt.Errorf("fail to update foo: %w", err)
 	}
 
 	return &proto.FooResponse{
-		Foo: &proto.Foo{
-			Id:     id.String(),
-			Label:  req.Label,
-			Value:  req.Value,
-			Weight: req.Weight,
-		},
+		Foo: newFooProto(foo),
+	}, nil
+}
+
+// Patch applies only the fields listed in the request update mask and returns the persisted Foo.
+func (s *FooService) Patch(ctx context.Context, req *proto.PatchFooRequest) (*proto.FooResponse, error) {
+	id, err := uuid.Parse(req.Id)
+	if err != nil {
+		return nil, fmt.Errorf("fail to parse id: %w", err)
+	}
+
+	input, err := newFooPatchInput(id, req.Foo, req.UpdateMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	foo, err := s.svc.Update(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("fail to patch foo: %w", err)
+	}
+
+	return &proto.FooResponse{
+		Foo: newFooProto(foo),
 	}, nil
 }
 
@@ -124,6 +132,50 @@ func (s *FooService) Delete(ctx context.Context, req *proto.DeleteFooRequest) (*
 	}, nil
 }
 
+// newFooPatchInput maps the paths of a field mask onto a FooPatchInput. Unknown paths and empty masks are rejected.
+func newFooPatchInput(id uuid.UUID, patch *proto.FooPatch, mask *fieldmaskpb.FieldMask) (*data.FooPatchInput, error) {
+	if len(mask.GetPaths()) == 0 {
+		return nil, fmt.Errorf("update_mask is required")
+	}
+	if !mask.IsValid(patch) {
+		return nil, fmt.Errorf("invalid update_mask paths: %v", mask.GetPaths())
+	}
+
+	input := &data.FooPatchInput{Id: id}
+	for _, path := range mask.GetPaths() {
+		switch path {
+		case "label":
+			input.Label = data.Optional[string]{Value: patch.GetLabel(), Set: true}
+		case "secret":
+			input.Secret = data.Optional[string]{Value: patch.GetSecret(), Set: true}
+		case "value":
+			input.Value = data.Optional[int]{Value: int(patch.GetValue()), Set: true}
+		case "weight":
+			input.Weight = data.Optional[float32]{Value: patch.GetWeight(), Set: true}
+		}
+	}
+
+	return input, nil
+}
+
+// newFooProto converts a domain Foo into its protobuf representation.
+func newFooProto(foo *model.Foo) *proto.Foo {
+	fooProto := &proto.Foo{
+		Id:     foo.Id.String(),
+		Label:  foo.Label,
+		Value:  int32(foo.Value),
+		Weight: foo.Weight,
+	}
+	if !foo.CreatedAt.IsZero() {
+		fooProto.CreatedAt = timestamppb.New(foo.CreatedAt)
+	}
+	if foo.UpdatedAt != nil {
+		fooProto.UpdatedAt = timestamppb.New(*foo.UpdatedAt)
+	}
+
+	return fooProto
+}
+
 func NewFooService(svc service.IFooService) proto.FooServiceServer {
 	return &FooService{
 		svc: svc,
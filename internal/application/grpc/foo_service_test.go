@@ -14,6 +14,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestFooService_List(t *testing.T) {
@@ -98,6 +102,7 @@ func TestFooService_List(t *testing.T) {
 
 func TestFooService_Get(t *testing.T) {
 	t.Parallel()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name           string
 		request        *proto.GetFooRequest
@@ -114,10 +119,11 @@ func TestFooService_Get(t *testing.T) {
 			expectedError: nil,
 			expectedResult: &proto.FooResponse{
 				Foo: &proto.Foo{
-					Id:     "20000000-0000-0000-0000-000000000001",
-					Label:  "Foo1",
-					Value:  1,
-					Weight: 1.5,
+					Id:        "20000000-0000-0000-0000-000000000001",
+					Label:     "Foo1",
+					Value:     1,
+					Weight:    1.5,
+					CreatedAt: timestamppb.New(createdAt),
 				},
 			},
 
@@ -131,7 +137,7 @@ func TestFooService_Get(t *testing.T) {
 						Label:     "Foo1",
 						Value:     1,
 						Weight:    1.5,
-						CreatedAt: time.Now(),
+						CreatedAt: createdAt,
 						UpdatedAt: nil,
 					}, nil)
 			},
@@ -171,6 +177,7 @@ func TestFooService_Get(t *testing.T) {
 
 func TestFooService_Create(t *testing.T) {
 	t.Parallel()
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name           string
 		request        *proto.CreateFooRequest
@@ -189,10 +196,11 @@ func TestFooService_Create(t *testing.T) {
 			},
 			expectedResult: &proto.FooResponse{
 				Foo: &proto.Foo{
-					Id:     "20000000-0000-0000-0000-000000000001",
-					Label:  "foo_create",
-					Value:  1,
-					Weight: 1.5,
+					Id:        "20000000-0000-0000-0000-000000000001",
+					Label:     "foo_create",
+					Value:     1,
+					Weight:    1.5,
+					CreatedAt: timestamppb.New(createdAt),
 				},
 			},
 			expectedError: nil,
@@ -212,7 +220,7 @@ func TestFooService_Create(t *testing.T) {
 					Secret:    "secret_create",
 					Value:     1,
 					Weight:    1.5,
-					CreatedAt: time.Now(),
+					CreatedAt: createdAt,
 					UpdatedAt: nil,
 				}, nil)
 			},
@@ -244,6 +252,7 @@ func TestFooService_Create(t *testing.T) {
 
 func TestFooService_Update(t *testing.T) {
 	t.Parallel()
+	updatedAt := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
 	testCases := []struct {
 		name           string
 		request        *proto.UpdateFooRequest
@@ -263,10 +272,12 @@ func TestFooService_Update(t *testing.T) {
 			},
 			expectedResult: &proto.FooResponse{
 				Foo: &proto.Foo{
-					Id:     "20000000-0000-0000-0000-000000000001",
-					Label:  "foo_update",
-					Value:  1,
-					Weight: 1.5,
+					Id:        "20000000-0000-0000-0000-000000000001",
+					Label:     "foo_update",
+					Value:     1,
+					Weight:    1.5,
+					CreatedAt: timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+					UpdatedAt: timestamppb.New(updatedAt),
 				},
 			},
 			expectedError: nil,
@@ -278,7 +289,32 @@ func TestFooService_Update(t *testing.T) {
 					Secret: "secret_update",
 					Value:  1,
 					Weight: 1.5,
-				}).Return(nil)
+				}).Return(&model.Foo{
+					Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+					Label:     "foo_update",
+					Secret:    "secret_update",
+					Value:     1,
+					Weight:    1.5,
+					CreatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					UpdatedAt: &updatedAt,
+				}, nil)
+			},
+		},
+		{
+			name: "Failure Case - Service Error",
+			request: &proto.UpdateFooRequest{
+				Id:     "20000000-0000-0000-0000-000000000001",
+				Label:  "foo_update",
+				Secret: "secret_update",
+				Value:  1,
+				Weight: 1.5,
+			},
+			expectedResult: nil,
+			expectedError:  fmt.Errorf("fail to update foo: service error"),
+
+			setupMockHandler: func(mockRepo *service.MockFooService) {
+				mockRepo.On("Update", mock.Anything, mock.Anything).
+					Return((*model.Foo)(nil), fmt.Errorf("service error"))
 			},
 		},
 	}
@@ -297,6 +333,114 @@ func TestFooService_Update(t *testing.T) {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), testCase.expectedError.Error())
 				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedResult, resp)
+			}
+		})
+	}
+}
+
+func TestFooService_Patch(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		request        *proto.PatchFooRequest
+		expectedResult *proto.FooResponse
+		expectedCode   codes.Code
+		expectedError  error
+
+		setupMockHandler func(*service.MockFooService)
+	}{
+		{
+			name: "Success Case",
+			request: &proto.PatchFooRequest{
+				Id:         "20000000-0000-0000-0000-000000000001",
+				Foo:        &proto.FooPatch{Label: "foo_patch", Value: 0, Weight: 2.5},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"label", "value"}},
+			},
+			expectedResult: &proto.FooResponse{
+				Foo: &proto.Foo{
+					Id:     "20000000-0000-0000-0000-000000000001",
+					Label:  "foo_patch",
+					Value:  0,
+					Weight: 1.5,
+				},
+			},
+
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("Update", mock.Anything, &data.FooPatchInput{
+					Id:    uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+					Label: data.Optional[string]{Value: "foo_patch", Set: true},
+					Value: data.Optional[int]{Value: 0, Set: true},
+				}).Return(&model.Foo{
+					Id:     uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+					Label:  "foo_patch",
+					Secret: "secret1",
+					Value:  0,
+					Weight: 1.5,
+				}, nil)
+			},
+		},
+		{
+			name: "Failure Case - Missing Update Mask",
+			request: &proto.PatchFooRequest{
+				Id:  "20000000-0000-0000-0000-000000000001",
+				Foo: &proto.FooPatch{Label: "foo_patch"},
+			},
+			expectedCode:     codes.InvalidArgument,
+			expectedError:    fmt.Errorf("update_mask is required"),
+			setupMockHandler: func(mockHandler *service.MockFooService) {},
+		},
+		{
+			name: "Failure Case - Unknown Path",
+			request: &proto.PatchFooRequest{
+				Id:         "20000000-0000-0000-0000-000000000001",
+				Foo:        &proto.FooPatch{Label: "foo_patch"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"label", "id"}},
+			},
+			expectedCode:     codes.InvalidArgument,
+			expectedError:    fmt.Errorf("invalid update_mask paths"),
+			setupMockHandler: func(mockHandler *service.MockFooService) {},
+		},
+		{
+			name: "Failure Case - Service Error",
+			request: &proto.PatchFooRequest{
+				Id:         "20000000-0000-0000-0000-000000000001",
+				Foo:        &proto.FooPatch{Secret: "secret_patch"},
+				UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"secret"}},
+			},
+			expectedCode:  codes.Unknown,
+			expectedError: fmt.Errorf("fail to patch foo: service error"),
+
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("Update", mock.Anything, mock.Anything).
+					Return((*model.Foo)(nil), fmt.Errorf("service error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockHandler := new(service.MockFooService)
+			svc := NewFooService(mockHandler)
+
+			testCase.setupMockHandler(mockHandler)
+
+			resp, err := svc.Patch(context.Background(), testCase.request)
+
+			if testCase.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, testCase.expectedCode, status.Code(err))
+				assert.Contains(t, err.Error(), testCase.expectedError.Error())
+				assert.Nil(t, resp)
+				if testCase.expectedCode == codes.InvalidArgument {
+					mockHandler.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedResult, resp)
 			}
 		})
 	}
@@ -0,0 +1,100 @@
+package grpc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
+	"github.com/TancelinMazzotti/astigo/pkg/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+const defaultHealthWatchInterval = 5 * time.Second
+
+var (
+	_ grpc_health_v1.HealthServer = (*HealthService)(nil)
+)
+
+// HealthService implements the standard grpc.health.v1 service on top of the readiness checks used by /health/readiness.
+// The empty service name reports the overall server health.
+type HealthService struct {
+	grpc_health_v1.UnimplementedHealthServer
+	checker  health.IReadinessChecker
+	interval time.Duration
+	services []string
+}
+
+func (s *HealthService) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if !s.known(req.Service) {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", req.Service)
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: s.status(ctx)}, nil
+}
+
+func (s *HealthService) List(ctx context.Context, _ *grpc_health_v1.HealthListRequest) (*grpc_health_v1.HealthListResponse, error) {
+	current := s.status(ctx)
+
+	statuses := make(map[string]*grpc_health_v1.HealthCheckResponse, len(s.services))
+	for _, service := range s.services {
+		statuses[service] = &grpc_health_v1.HealthCheckResponse{Status: current}
+	}
+
+	return &grpc_health_v1.HealthListResponse{Statuses: statuses}, nil
+}
+
+func (s *HealthService) Watch(req *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	ctx := stream.Context()
+
+	if !s.known(req.Service) {
+		// As per the health checking protocol, unknown services are reported instead of failing the call.
+		return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN})
+	}
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_UNKNOWN
+	for {
+		if current := s.status(ctx); current != last {
+			if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: current}); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HealthService) status(ctx context.Context) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if ready, _ := s.checker.Ready(ctx); !ready {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+
+	return grpc_health_v1.HealthCheckResponse_SERVING
+}
+
+func (s *HealthService) known(service string) bool {
+	return slices.Contains(s.services, service)
+}
+
+func NewHealthService(checker health.IReadinessChecker, interval time.Duration) *HealthService {
+	if interval <= 0 {
+		interval = defaultHealthWatchInterval
+	}
+
+	return &HealthService{
+		checker:  checker,
+		interval: interval,
+		services: []string{"", proto.FooService_ServiceDesc.ServiceName},
+	}
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestHealthService_Check(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		service        string
+		check          health.Check
+		expectedStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "Success Case - Serving",
+			service:        "",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Success Case - Foo service serving",
+			service:        "proto.FooService",
+			check:          func(ctx context.Context) error { return nil },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Success Case - Not serving",
+			service:        "",
+			check:          func(ctx context.Context) error { return errors.New("postgres unreachable") },
+			expectedStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:         "Failure Case - Unknown service",
+			service:      "proto.BarService",
+			check:        func(ctx context.Context) error { return nil },
+			expectedCode: codes.NotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			registry := health.NewRegistry(health.Config{})
+			registry.Register("postgres", testCase.check)
+			svc := NewHealthService(registry, 0)
+
+			result, err := svc.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: testCase.service})
+
+			assert.Equal(t, testCase.expectedCode, status.Code(err))
+			if testCase.expectedCode == codes.OK {
+				assert.Equal(t, testCase.expectedStatus, result.Status)
+			}
+		})
+	}
+}
+
+func TestHealthService_List(t *testing.T) {
+	t.Parallel()
+	registry := health.NewRegistry(health.Config{})
+	registry.Register("postgres", func(ctx context.Context) error { return errors.New("postgres unreachable") })
+	svc := NewHealthService(registry, 0)
+
+	result, err := svc.List(context.Background(), &grpc_health_v1.HealthListRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Statuses, 2)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, result.Statuses[""].Status)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, result.Statuses["proto.FooService"].Status)
+}
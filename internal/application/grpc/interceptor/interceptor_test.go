@@ -0,0 +1,193 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/ratelimit/memory"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (f *fakeServerStream) Context() context.Context {
+	return f.ctx
+}
+
+func TestUnaryRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name         string
+		handler      grpc.UnaryHandler
+		expectedCode codes.Code
+	}{
+		{
+			name: "Success Case",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return "ok", nil
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name: "Success Case - Handler Error",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, status.Error(codes.NotFound, "not found")
+			},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name: "Failure Case - Panic",
+			handler: func(ctx context.Context, req interface{}) (interface{}, error) {
+				panic("boom")
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			interceptor := UnaryRecoveryInterceptor(zap.NewNop())
+
+			_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/astigo.FooService/Get"}, testCase.handler)
+
+			assert.Equal(t, testCase.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestStreamRecoveryInterceptor(t *testing.T) {
+	t.Parallel()
+	interceptor := StreamRecoveryInterceptor(zap.NewNop())
+
+	err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/grpc.health.v1.Health/Watch"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			panic(errors.New("boom"))
+		},
+	)
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUnaryMetricsInterceptor(t *testing.T) {
+	t.Parallel()
+	interceptor := UnaryMetricsInterceptor()
+	method := "/astigo.FooService/TestUnaryMetricsInterceptor"
+
+	_, _ = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.InvalidArgument, "invalid")
+		},
+	)
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(GrpcRequestsTotal.WithLabelValues(method, codes.InvalidArgument.String())))
+}
+
+func TestUnaryTracingInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	parent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("traceparent", parent))
+
+	var handlerSpan trace.SpanContext
+	_, err := UnaryTracingInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/astigo.FooService/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			handlerSpan = trace.SpanContextFromContext(ctx)
+			return nil, status.Error(codes.Internal, "boom")
+		},
+	)
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+	assert.Equal(t, "astigo.FooService/Get", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "0af7651916cd43dd8448eb211c80319c", span.SpanContext().TraceID().String())
+	assert.Equal(t, "b7ad6b7169203331", span.Parent().SpanID().String())
+	assert.Equal(t, span.SpanContext().SpanID(), handlerSpan.SpanID())
+	assert.Equal(t, "Error", span.Status().Code.String())
+}
+
+func TestUnaryRateLimitInterceptor(t *testing.T) {
+	t.Parallel()
+	policy := ratelimit.NewPolicy(ratelimit.Config{Enabled: true, Limit: 1, Period: time.Minute})
+	interceptor := UnaryRateLimitInterceptor(memory.NewRateLimiterMemory(), policy)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 4242}})
+
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.FooService/Get"}, handler)
+	assert.NoError(t, err)
+
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.FooService/Get"}, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// A fresh API key does not reset the limit of the peer.
+	keyCtx := metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", "random"))
+	_, err = interceptor(keyCtx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.FooService/Get"}, handler)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	assert.NoError(t, err)
+	_, err = interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+	assert.NoError(t, err)
+}
+
+func TestUnaryRequestIDInterceptor(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		incoming   string
+		expectSame bool
+	}{
+		{name: "Success Case - Reuse incoming id", incoming: "req-42", expectSame: true},
+		{name: "Success Case - Generate missing id", incoming: "", expectSame: false},
+		{name: "Success Case - Replace invalid id", incoming: "req 42", expectSame: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if testCase.incoming != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(correlation.MetadataRequestID, testCase.incoming))
+			}
+
+			var id string
+			interceptor := UnaryRequestIDInterceptor(zap.NewNop())
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/proto.FooService/Get"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				id = correlation.RequestID(ctx)
+				return "ok", nil
+			})
+
+			assert.NoError(t, err)
+			assert.True(t, correlation.ValidRequestID(id))
+			assert.Equal(t, testCase.expectSame, id == testCase.incoming)
+		})
+	}
+}
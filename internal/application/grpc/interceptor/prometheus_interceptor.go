@@ -0,0 +1,65 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	GrpcRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "grpc_requests_total",
+			Help: "Total number of gRPC calls handled by the server",
+		},
+		[]string{"method", "code"},
+	)
+
+	GrpcRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "grpc_request_duration_seconds",
+			Help:    "Duration of gRPC calls in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"method", "code"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(GrpcRequestsTotal)
+	prometheus.MustRegister(GrpcRequestDuration)
+}
+
+// UnaryMetricsInterceptor records the count and latency of unary calls per method and status code.
+func UnaryMetricsInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+		observe(info.FullMethod, err, start)
+
+		return resp, err
+	}
+}
+
+// StreamMetricsInterceptor records the count and duration of streaming calls per method and status code.
+func StreamMetricsInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+		observe(info.FullMethod, err, start)
+
+		return err
+	}
+}
+
+func observe(method string, err error, start time.Time) {
+	code := status.Code(err).String()
+
+	GrpcRequestsTotal.WithLabelValues(method, code).Inc()
+	GrpcRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,91 @@
+package interceptor
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	ratelimit2 "github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRateLimitInterceptor rejects unary calls exceeding the limit of their method with RESOURCE_EXHAUSTED.
+// The RateLimit-* and Retry-After values are sent as response headers.
+func UnaryRateLimitInterceptor(limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		headers, err := allow(ctx, limiter, policy, info.FullMethod)
+		if headers != nil {
+			_ = grpc.SetHeader(ctx, headers)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor is the streaming counterpart of UnaryRateLimitInterceptor. Only the opening of a stream is counted.
+func StreamRateLimitInterceptor(limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		headers, err := allow(ss.Context(), limiter, policy, info.FullMethod)
+		if headers != nil {
+			_ = ss.SetHeader(headers)
+		}
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+func allow(ctx context.Context, limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy, method string) (metadata.MD, error) {
+	// Infrastructure services (health, reflection, channelz) are probed by the platform and never limited.
+	if strings.HasPrefix(method, "/grpc.") {
+		return nil, nil
+	}
+
+	limit, ok := policy.Limit(method)
+	if !ok {
+		return nil, nil
+	}
+
+	result, err := limiter.Allow(ctx, ratelimit.Key(method, callerIdentity(ctx)), limit)
+	if err != nil {
+		// Fail open: an unavailable limiter must not take the API down.
+		return nil, nil
+	}
+	ratelimit.Observe(method, result.Allowed)
+
+	headers := metadata.MD{}
+	for key, value := range ratelimit.Headers(limit, result) {
+		headers.Set(strings.ToLower(key), value)
+	}
+
+	if !result.Allowed {
+		return headers, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+
+	return headers, nil
+}
+
+// callerIdentity identifies the caller by the address of its peer. The metadata are never trusted: a caller could
+// pick a fresh bucket on every call.
+func callerIdentity(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return ratelimit.IPIdentity(host)
+	}
+
+	return ratelimit.IPIdentity("unknown")
+}
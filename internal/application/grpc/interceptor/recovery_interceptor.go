@@ -0,0 +1,49 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRecoveryInterceptor recovers from panics raised by unary handlers, logs them and returns an INTERNAL error.
+func UnaryRecoveryInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecoveryInterceptor recovers from panics raised by stream handlers, logs them and returns an INTERNAL error.
+func StreamRecoveryInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func recovered(ctx context.Context, logger *zap.Logger, method string, r any) error {
+	correlation.Logger(ctx, logger).Error("panic recovered",
+		zap.Any("error", r),
+		zap.String("method", method),
+		zap.ByteString("stack", debug.Stack()),
+	)
+
+	return status.Error(codes.Internal, "internal error")
+}
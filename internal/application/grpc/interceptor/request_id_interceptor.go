@@ -0,0 +1,45 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// UnaryRequestIDInterceptor accepts the x-request-id metadata sent by the client or generates a new one, returns it
+// as a response header and stores it in the context along with a logger enriched with the request id and trace id.
+func UnaryRequestIDInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingRequestID(ctx)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(correlation.MetadataRequestID, id))
+
+		return handler(correlation.Start(ctx, logger, id), req)
+	}
+}
+
+// StreamRequestIDInterceptor is the streaming counterpart of UnaryRequestIDInterceptor.
+func StreamRequestIDInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingRequestID(ss.Context())
+		_ = ss.SetHeader(metadata.Pairs(correlation.MetadataRequestID, id))
+
+		return handler(srv, &wrappedServerStream{
+			ServerStream: ss,
+			ctx:          correlation.Start(ss.Context(), logger, id),
+		})
+	}
+}
+
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(correlation.MetadataRequestID); len(values) > 0 && correlation.ValidRequestID(values[0]) {
+			return values[0]
+		}
+	}
+
+	return correlation.NewRequestID()
+}
@@ -0,0 +1,126 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const tracerName = "github.com/TancelinMazzotti/astigo/internal/application/grpc/interceptor"
+
+var _ propagation.TextMapCarrier = (*metadataCarrier)(nil)
+
+// metadataCarrier adapts incoming gRPC metadata to the OpenTelemetry propagation API.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c metadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// UnaryTracingInterceptor starts a server span for each unary call, continuing the trace found in the incoming metadata.
+func UnaryTracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		defer span.End()
+
+		resp, err := handler(ctx, req)
+		endServerSpan(span, err)
+
+		return resp, err
+	}
+}
+
+// StreamTracingInterceptor is the streaming counterpart of UnaryTracingInterceptor.
+// The span covers the whole lifetime of the stream.
+func StreamTracingInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+		defer span.End()
+
+		err := handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+		endServerSpan(span, err)
+
+		return err
+	}
+}
+
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+	name := strings.TrimPrefix(fullMethod, "/")
+	attributes := []attribute.KeyValue{semconv.RPCSystemGRPC}
+	if service, method, ok := strings.Cut(name, "/"); ok {
+		attributes = append(attributes, semconv.RPCService(service), semconv.RPCMethod(method))
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		attributes = append(attributes, semconv.NetworkPeerAddress(p.Addr.String()))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+func endServerSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(code)))
+
+	if err == nil {
+		span.SetStatus(otelcodes.Ok, "")
+		return
+	}
+
+	span.RecordError(err)
+	// Following the RPC semantic conventions, only server faults mark a server span as failed.
+	if isServerError(code) {
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+}
+
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented, codes.Internal, codes.Unavailable, codes.DataLoss:
+		return true
+	default:
+		return false
+	}
+}
+
+// wrappedServerStream overrides the context of a grpc.ServerStream so that handlers see the values added by interceptors.
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedServerStream) Context() context.Context {
+	return w.ctx
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -15,27 +17,42 @@ func UnaryLoggerInterceptor(logger *zap.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
 
-		p, _ := peer.FromContext(ctx)
-		clientIP := "unknown"
-		if p != nil {
-			clientIP = p.Addr.String()
-		}
-
 		resp, err := handler(ctx, req)
+		logRequest(ctx, logger, "gRPC request", info.FullMethod, err, start)
 
-		statusCode := codes.OK
-		if err != nil {
-			st, _ := status.FromError(err)
-			statusCode = st.Code()
-		}
+		return resp, err
+	}
+}
 
-		logger.Info("gRPC request",
-			zap.String("status", statusCode.String()),
-			zap.String("method", info.FullMethod),
-			zap.String("client_ip", clientIP),
-			zap.Duration("latency", time.Since(start)),
-		)
+// StreamLoggerInterceptor logs streaming calls once they complete.
+func StreamLoggerInterceptor(logger *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
 
-		return resp, err
+		err := handler(srv, ss)
+		logRequest(ss.Context(), logger, "gRPC stream", info.FullMethod, err, start)
+
+		return err
+	}
+}
+
+func logRequest(ctx context.Context, logger *zap.Logger, msg string, method string, err error, start time.Time) {
+	p, _ := peer.FromContext(ctx)
+	clientIP := "unknown"
+	if p != nil {
+		clientIP = p.Addr.String()
 	}
+
+	statusCode := codes.OK
+	if err != nil {
+		st, _ := status.FromError(err)
+		statusCode = st.Code()
+	}
+
+	correlation.Logger(ctx, logger).Info(msg,
+		zap.String("status", statusCode.String()),
+		zap.String("method", method),
+		zap.String("client_ip", clientIP),
+		zap.Duration("latency", time.Since(start)),
+	)
 }
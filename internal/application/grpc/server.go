@@ -1,22 +1,62 @@
 package grpc
 
 import (
+	"time"
+
 	"github.com/TancelinMazzotti/astigo/internal/application/grpc/interceptor"
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	ratelimit2 "github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
 	"github.com/TancelinMazzotti/astigo/pkg/proto"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	channelz "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type Config struct {
-	Port int `mapstructure:"port"`
+	Port                int           `mapstructure:"port"`
+	Reflection          bool          `mapstructure:"reflection"`
+	HealthWatchInterval time.Duration `mapstructure:"health_watch_interval"`
 }
 
-func NewGrpcServer(logger *zap.Logger, fooService proto.FooServiceServer) *grpc.Server {
+func NewGrpcServer(
+	config Config,
+	logger *zap.Logger,
+	limiter ratelimit2.IRateLimiter,
+	rateLimitPolicy *ratelimit.Policy,
+	healthService grpc_health_v1.HealthServer,
+	fooService proto.FooServiceServer,
+) *grpc.Server {
+	interceptor.RegisterMetrics()
+
+	// Recovery runs innermost so that panics turned into INTERNAL errors are still traced, logged and counted.
 	server := grpc.NewServer(
-		grpc.UnaryInterceptor(interceptor.UnaryLoggerInterceptor(logger)),
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryTracingInterceptor(),
+			interceptor.UnaryRequestIDInterceptor(logger),
+			interceptor.UnaryLoggerInterceptor(logger),
+			interceptor.UnaryMetricsInterceptor(),
+			interceptor.UnaryRateLimitInterceptor(limiter, rateLimitPolicy),
+			interceptor.UnaryRecoveryInterceptor(logger),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamTracingInterceptor(),
+			interceptor.StreamRequestIDInterceptor(logger),
+			interceptor.StreamLoggerInterceptor(logger),
+			interceptor.StreamMetricsInterceptor(),
+			interceptor.StreamRateLimitInterceptor(limiter, rateLimitPolicy),
+			interceptor.StreamRecoveryInterceptor(logger),
+		),
 	)
 	server.RegisterService(&proto.FooService_ServiceDesc, fooService)
+	grpc_health_v1.RegisterHealthServer(server, healthService)
+	channelz.RegisterChannelzServiceToServer(server)
+
+	if config.Reflection {
+		reflection.Register(server)
+	}
 
 	return server
 }
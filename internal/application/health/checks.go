@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+	"github.com/redis/go-redis/v9"
+)
+
+// Pinger is implemented by dependencies exposing a context-aware ping, such as *sql.DB.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingCheck probes a database connection pool with PingContext.
+func PingCheck(db Pinger) Check {
+	return db.PingContext
+}
+
+// RedisCheck sends a PING command to Redis.
+func RedisCheck(client redis.Cmdable) Check {
+	return func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}
+}
+
+// NatsCheck verifies that the NATS connection is established. It does not perform any network round trip.
+func NatsCheck(conn *nats.Conn) Check {
+	return func(ctx context.Context) error {
+		if status := conn.Status(); status != nats.CONNECTED {
+			return fmt.Errorf("nats connection is %s", status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package health
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	CheckStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_check_status",
+			Help: "Result of the last execution of a health check (1 = up, 0 = down)",
+		},
+		[]string{"check", "critical"},
+	)
+
+	CheckDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "health_check_duration_seconds",
+			Help:    "Duration of health check executions in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"check"},
+	)
+
+	CheckFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_check_failures_total",
+			Help: "Total number of failed health check executions",
+		},
+		[]string{"check"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(CheckStatus)
+	prometheus.MustRegister(CheckDuration)
+	prometheus.MustRegister(CheckFailures)
+}
+
+func observe(name string, result CheckResult) {
+	critical := "false"
+	if result.Critical {
+		critical = "true"
+	}
+
+	value := 1.0
+	if result.Status == StatusDown {
+		value = 0
+		CheckFailures.WithLabelValues(name).Inc()
+	}
+
+	CheckStatus.WithLabelValues(name, critical).Set(value)
+	CheckDuration.WithLabelValues(name).Observe(result.Duration.Seconds())
+}
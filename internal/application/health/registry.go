@@ -0,0 +1,242 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	defaultTimeout  = 2 * time.Second
+	defaultCacheTTL = 5 * time.Second
+)
+
+var (
+	_ IReadinessChecker = (*Registry)(nil)
+	_ IHealthChecker    = (*Registry)(nil)
+)
+
+// Config holds the settings applied to every registered check unless overridden at registration.
+type Config struct {
+	Timeout  time.Duration `mapstructure:"timeout"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl"`
+}
+
+// IReadinessChecker defines the readiness source shared by the HTTP and gRPC health endpoints.
+// Ready runs the registered checks and reports whether the service can receive traffic, along with the error of each failing check.
+type IReadinessChecker interface {
+	Ready(ctx context.Context) (bool, map[string]error)
+}
+
+// IHealthChecker extends IReadinessChecker with the detailed report and the startup state exposed over HTTP.
+// Report returns the result of every registered check.
+// Started reports whether every critical check has succeeded at least once since the process started.
+type IHealthChecker interface {
+	IReadinessChecker
+	Report(ctx context.Context) Report
+	Started(ctx context.Context) bool
+}
+
+// Check probes a single dependency and returns an error when it is not usable.
+type Check func(ctx context.Context) error
+
+// Option customizes how a check is registered.
+type Option func(*entry)
+
+// NonCritical marks a check whose failure degrades the service without making it unready.
+func NonCritical() Option {
+	return func(e *entry) {
+		e.critical = false
+	}
+}
+
+// WithTimeout overrides the configured timeout for a single check.
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *entry) {
+		e.timeout = timeout
+	}
+}
+
+type entry struct {
+	check    Check
+	critical bool
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	result *CheckResult
+}
+
+// Registry holds the named dependency checks used to decide readiness.
+// Results are cached for the configured TTL and concurrent probes of the same check are coalesced,
+// so frequent probes from Kubernetes or gRPC Watch streams do not hammer the dependencies.
+type Registry struct {
+	mu      sync.RWMutex
+	names   []string
+	entries map[string]*entry
+
+	timeout  time.Duration
+	cacheTTL time.Duration
+	group    singleflight.Group
+	started  atomic.Bool
+	now      func() time.Time
+}
+
+// Register adds or replaces the check identified by name. Checks are critical unless NonCritical is given.
+func (r *Registry) Register(name string, check Check, opts ...Option) {
+	e := &entry{
+		check:    check,
+		critical: true,
+		timeout:  r.timeout,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.entries[name] = e
+}
+
+// Ready reports whether every critical check succeeds. The returned map holds the error of every failing check, critical or not.
+func (r *Registry) Ready(ctx context.Context) (bool, map[string]error) {
+	report := r.Report(ctx)
+
+	failures := make(map[string]error)
+	for name, result := range report.Checks {
+		if result.Status == StatusDown {
+			failures[name] = errors.New(result.Error)
+		}
+	}
+
+	return report.Status != StatusDown, failures
+}
+
+// Report runs every registered check concurrently, reusing cached results that are still fresh.
+func (r *Registry) Report(ctx context.Context) Report {
+	r.mu.RLock()
+	names := make([]string, len(r.names))
+	copy(names, r.names)
+	entries := make([]*entry, len(names))
+	for i, name := range names {
+		entries[i] = r.entries[name]
+	}
+	r.mu.RUnlock()
+
+	results := make([]CheckResult, len(entries))
+	var wg sync.WaitGroup
+	for i, e := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = r.run(ctx, names[i], e)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(results)),
+	}
+	for i, result := range results {
+		report.Checks[names[i]] = result
+		if result.Status != StatusDown {
+			continue
+		}
+		if result.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+
+	return report
+}
+
+// Started latches to true the first time no critical check fails.
+func (r *Registry) Started(ctx context.Context) bool {
+	if r.started.Load() {
+		return true
+	}
+
+	if ready, _ := r.Ready(ctx); ready {
+		r.started.Store(true)
+		return true
+	}
+
+	return false
+}
+
+func (r *Registry) run(ctx context.Context, name string, e *entry) CheckResult {
+	if result, ok := r.cached(e); ok {
+		return result
+	}
+
+	// The probe is shared by concurrent callers: it must not be cancelled when the caller that started it goes away.
+	value, _, _ := r.group.Do(name, func() (interface{}, error) {
+		if result, ok := r.cached(e); ok {
+			return result, nil
+		}
+
+		checkCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.timeout)
+		defer cancel()
+
+		start := r.now()
+		err := e.check(checkCtx)
+		result := CheckResult{
+			Status:    StatusUp,
+			Critical:  e.critical,
+			Duration:  r.now().Sub(start),
+			CheckedAt: start,
+		}
+		if err != nil {
+			result.Status = StatusDown
+			result.Error = err.Error()
+		}
+		observe(name, result)
+
+		e.mu.Lock()
+		e.result = &result
+		e.mu.Unlock()
+
+		return result, nil
+	})
+
+	return value.(CheckResult)
+}
+
+func (r *Registry) cached(e *entry) (CheckResult, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.result == nil || r.now().Sub(e.result.CheckedAt) >= r.cacheTTL {
+		return CheckResult{}, false
+	}
+
+	return *e.result, true
+}
+
+// NewRegistry creates an empty Registry. A registry without checks is always ready.
+// Unset timeout and cache TTL fall back to sane defaults; a negative cache TTL disables caching.
+func NewRegistry(config Config) *Registry {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+
+	return &Registry{
+		entries:  make(map[string]*entry),
+		timeout:  config.Timeout,
+		cacheTTL: config.CacheTTL,
+		now:      time.Now,
+	}
+}
@@ -0,0 +1,172 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Ready(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name             string
+		checks           map[string]Check
+		expectedReady    bool
+		expectedFailures map[string]error
+	}{
+		{
+			name:             "Success Case - No checks",
+			checks:           map[string]Check{},
+			expectedReady:    true,
+			expectedFailures: map[string]error{},
+		},
+		{
+			name: "Success Case - All checks pass",
+			checks: map[string]Check{
+				"postgres": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return nil },
+			},
+			expectedReady:    true,
+			expectedFailures: map[string]error{},
+		},
+		{
+			name: "Failure Case - One check fails",
+			checks: map[string]Check{
+				"postgres": func(ctx context.Context) error { return nil },
+				"redis":    func(ctx context.Context) error { return errors.New("connection refused") },
+			},
+			expectedReady: false,
+			expectedFailures: map[string]error{
+				"redis": errors.New("connection refused"),
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			registry := NewRegistry(Config{})
+			for name, check := range testCase.checks {
+				registry.Register(name, check)
+			}
+
+			ready, failures := registry.Ready(context.Background())
+
+			assert.Equal(t, testCase.expectedReady, ready)
+			assert.Equal(t, testCase.expectedFailures, failures)
+		})
+	}
+}
+
+func TestRegistry_Report(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		register       func(*Registry)
+		expectedStatus Status
+		expectedChecks map[string]Status
+	}{
+		{
+			name: "Success Case - All checks pass",
+			register: func(r *Registry) {
+				r.Register("postgres", func(ctx context.Context) error { return nil })
+				r.Register("redis", func(ctx context.Context) error { return nil }, NonCritical())
+			},
+			expectedStatus: StatusUp,
+			expectedChecks: map[string]Status{"postgres": StatusUp, "redis": StatusUp},
+		},
+		{
+			name: "Success Case - Non-critical check fails",
+			register: func(r *Registry) {
+				r.Register("postgres", func(ctx context.Context) error { return nil })
+				r.Register("redis", func(ctx context.Context) error { return errors.New("connection refused") }, NonCritical())
+			},
+			expectedStatus: StatusDegraded,
+			expectedChecks: map[string]Status{"postgres": StatusUp, "redis": StatusDown},
+		},
+		{
+			name: "Failure Case - Critical check fails",
+			register: func(r *Registry) {
+				r.Register("postgres", func(ctx context.Context) error { return errors.New("connection refused") })
+				r.Register("redis", func(ctx context.Context) error { return errors.New("connection refused") }, NonCritical())
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]Status{"postgres": StatusDown, "redis": StatusDown},
+		},
+		{
+			name: "Failure Case - Check timeout",
+			register: func(r *Registry) {
+				r.Register("nats", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}, WithTimeout(10*time.Millisecond))
+			},
+			expectedStatus: StatusDown,
+			expectedChecks: map[string]Status{"nats": StatusDown},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			registry := NewRegistry(Config{})
+			testCase.register(registry)
+
+			report := registry.Report(context.Background())
+
+			assert.Equal(t, testCase.expectedStatus, report.Status)
+			checks := make(map[string]Status, len(report.Checks))
+			for name, result := range report.Checks {
+				checks[name] = result.Status
+			}
+			assert.Equal(t, testCase.expectedChecks, checks)
+		})
+	}
+}
+
+func TestRegistry_Cache(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	registry := NewRegistry(Config{CacheTTL: time.Second})
+	registry.now = func() time.Time { return now }
+
+	var calls atomic.Int32
+	registry.Register("postgres", func(ctx context.Context) error {
+		calls.Add(1)
+		return nil
+	})
+
+	registry.Report(context.Background())
+	registry.Report(context.Background())
+	assert.Equal(t, int32(1), calls.Load())
+
+	now = now.Add(time.Second)
+	registry.Report(context.Background())
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestRegistry_Started(t *testing.T) {
+	t.Parallel()
+	registry := NewRegistry(Config{CacheTTL: -1})
+
+	var healthy atomic.Bool
+	registry.Register("postgres", func(ctx context.Context) error {
+		if !healthy.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+	registry.Register("s3", func(ctx context.Context) error { return errors.New("access denied") }, NonCritical())
+
+	assert.False(t, registry.Started(context.Background()))
+
+	healthy.Store(true)
+	assert.True(t, registry.Started(context.Background()))
+
+	healthy.Store(false)
+	assert.True(t, registry.Started(context.Background()))
+}
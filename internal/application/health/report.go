@@ -0,0 +1,49 @@
+package health
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Status is the health of a single check or of the whole service.
+type Status string
+
+const (
+	StatusUp Status = "UP"
+	// StatusDegraded means that only non-critical checks are failing: the service keeps receiving traffic.
+	StatusDegraded Status = "DEGRADED"
+	StatusDown     Status = "DOWN"
+)
+
+// CheckResult is the outcome of the last execution of a check.
+type CheckResult struct {
+	Status    Status
+	Critical  bool
+	Error     string
+	Duration  time.Duration
+	CheckedAt time.Time
+}
+
+// MarshalJSON renders the duration in milliseconds, which is easier to read than nanoseconds in probe reports.
+func (r CheckResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Status     Status    `json:"status"`
+		Critical   bool      `json:"critical"`
+		Error      string    `json:"error,omitempty"`
+		DurationMs float64   `json:"duration_ms"`
+		CheckedAt  time.Time `json:"checked_at"`
+	}{
+		Status:     r.Status,
+		Critical:   r.Critical,
+		Error:      r.Error,
+		DurationMs: float64(r.Duration.Microseconds()) / 1000,
+		CheckedAt:  r.CheckedAt.UTC(),
+	})
+}
+
+// Report aggregates the results of every registered check.
+// The service is DOWN as soon as a critical check fails and DEGRADED when only non-critical checks fail.
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// writeConditional renders obj as JSON along with its validators: a strong ETag computed from the rendered body and,
+// when known, the Last-Modified date. When the request preconditions show that the client copy is still current, it
+// answers 304 Not Modified without a body instead.
+func writeConditional(ctx *gin.Context, span trace.Span, obj any, lastModified time.Time) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to marshal response")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to marshal response"})
+		return
+	}
+
+	etag := httpcache.ETag(body)
+	ctx.Header("ETag", etag)
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	notModified := httpcache.NotModified(ctx.Request.Header, etag, lastModified)
+	httpcache.Observe(ctx.Request.Method+" "+ctx.FullPath(), notModified)
+	span.SetAttributes(attribute.Bool("http.not_modified", notModified))
+	if notModified {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// lastModified returns the date of the last change of a Foo.
+func lastModified(foo *model.Foo) time.Time {
+	if foo.UpdatedAt != nil {
+		return *foo.UpdatedAt
+	}
+	return foo.CreatedAt
+}
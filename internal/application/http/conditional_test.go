@@ -0,0 +1,115 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWriteConditional(t *testing.T) {
+	t.Parallel()
+	obj := gin.H{"id": "20000000-0000-0000-0000-000000000001", "label": "foo1"}
+	body, _ := json.Marshal(obj)
+	etag := httpcache.ETag(body)
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 600, time.UTC)
+
+	testCases := []struct {
+		name                 string
+		header               http.Header
+		lastModified         time.Time
+		expectedStatus       int
+		expectedLastModified string
+	}{
+		{
+			name:                 "Success Case - No Precondition",
+			header:               http.Header{},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:                 "Success Case - If-None-Match Matches",
+			header:               http.Header{"If-None-Match": {`"other", ` + etag}},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusNotModified,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:                 "Success Case - If-None-Match Matches Weak ETag",
+			header:               http.Header{"If-None-Match": {"W/" + etag}},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusNotModified,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:                 "Success Case - If-None-Match Does Not Match",
+			header:               http.Header{"If-None-Match": {`"other"`}},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name: "Success Case - If-None-Match Takes Precedence Over If-Modified-Since",
+			header: http.Header{
+				"If-None-Match":     {`"other"`},
+				"If-Modified-Since": {"Thu, 02 Jan 2025 03:04:05 GMT"},
+			},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:                 "Success Case - Not Modified Since",
+			header:               http.Header{"If-Modified-Since": {"Thu, 02 Jan 2025 03:04:05 GMT"}},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusNotModified,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:                 "Success Case - Modified Since",
+			header:               http.Header{"If-Modified-Since": {"Thu, 02 Jan 2025 03:04:04 GMT"}},
+			lastModified:         lastModified,
+			expectedStatus:       http.StatusOK,
+			expectedLastModified: "Thu, 02 Jan 2025 03:04:05 GMT",
+		},
+		{
+			name:           "Success Case - Unknown Last Modified",
+			header:         http.Header{"If-Modified-Since": {"Thu, 02 Jan 2025 03:04:05 GMT"}},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/foos/:id", func(c *gin.Context) {
+				writeConditional(c, trace.SpanFromContext(context.Background()), obj, testCase.lastModified)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/foos/20000000-0000-0000-0000-000000000001", nil)
+			req.Header = testCase.header
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatus, rec.Code)
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			assert.Equal(t, testCase.expectedLastModified, rec.Header().Get("Last-Modified"))
+			if testCase.expectedStatus == http.StatusNotModified {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+			assert.JSONEq(t, string(body), rec.Body.String())
+		})
+	}
+}
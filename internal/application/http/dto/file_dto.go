@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type FileCreateBody struct {
+	Name     string `json:"name" binding:"required,max=255"`
+	Size     int64  `json:"size" binding:"required,gt=0"`
+	MimeType string `json:"mime_type" binding:"required,max=255"`
+}
+
+// PresignedRequestResponse is a request the client sends as is to the object storage: its headers must be sent along.
+type PresignedRequestResponse struct {
+	Method  string              `json:"method" binding:"required"`
+	Url     string              `json:"url" binding:"required"`
+	Headers map[string][]string `json:"headers,omitempty"`
+}
+
+// NewPresignedRequestResponse returns the representation of a presigned request, or nil when there is none.
+func NewPresignedRequestResponse(request *model.PresignedRequest) *PresignedRequestResponse {
+	if request == nil {
+		return nil
+	}
+	return &PresignedRequestResponse{
+		Method:  request.Method,
+		Url:     request.Url,
+		Headers: request.Header,
+	}
+}
+
+type FileReadRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type FileReadResponse struct {
+	Id         uuid.UUID                 `json:"id" binding:"required"`
+	Name       string                    `json:"name" binding:"required"`
+	Size       int64                     `json:"size" binding:"required"`
+	Extension  string                    `json:"extension"`
+	MimeType   string                    `json:"mime_type" binding:"required"`
+	Status     string                    `json:"status" binding:"required"`
+	CreatedAt  time.Time                 `json:"created_at" binding:"required"`
+	UploadedAt *time.Time                `json:"uploaded_at,omitempty"`
+	Download   *PresignedRequestResponse `json:"download,omitempty"`
+}
+
+// NewFileReadResponse returns the representation of a file along with the request downloading it, if any. The path of
+// the file in the object storage is not disclosed.
+func NewFileReadResponse(file *model.File, download *model.PresignedRequest) *FileReadResponse {
+	response := &FileReadResponse{
+		Id:        file.Id,
+		Name:      file.Name,
+		Size:      file.Size,
+		Extension: file.Extension,
+		MimeType:  file.MimeType,
+		Status:    string(file.Status),
+		CreatedAt: file.CreatedAt,
+		Download:  NewPresignedRequestResponse(download),
+	}
+	if !file.UploadedAt.IsZero() {
+		response.UploadedAt = &file.UploadedAt
+	}
+	return response
+}
+
+// FileCreateResponse is the only representation of a file carrying the request uploading it.
+type FileCreateResponse struct {
+	Id     uuid.UUID                 `json:"id" binding:"required"`
+	Status string                    `json:"status" binding:"required"`
+	Upload *PresignedRequestResponse `json:"upload" binding:"required"`
+}
+
+type FileCompleteRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type FileDeleteRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
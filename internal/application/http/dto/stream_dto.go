@@ -0,0 +1,15 @@
+package dto
+
+import "encoding/json"
+
+type FooStreamRequest struct {
+	Types       []string `form:"type" binding:"omitempty,dive,oneof=foo.created foo.updated foo.deleted"`
+	Ids         []string `form:"id" binding:"omitempty,dive,uuid"`
+	LastEventID uint64   `form:"last_event_id" binding:"omitempty"`
+}
+
+type FooStreamMessage struct {
+	Id   uint64          `json:"id"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
@@ -0,0 +1,102 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+type WebhookReadRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type WebhookReadResponse struct {
+	Id                  uuid.UUID  `json:"id" binding:"required"`
+	Url                 string     `json:"url" binding:"required"`
+	EventTypes          []string   `json:"event_types" binding:"required"`
+	Enabled             bool       `json:"enabled" binding:"required"`
+	ConsecutiveFailures int        `json:"consecutive_failures" binding:"required"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" binding:"required"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
+
+// NewWebhookReadResponse returns the representation of a subscription, which never discloses its secret.
+func NewWebhookReadResponse(subscription *model.WebhookSubscription) *WebhookReadResponse {
+	return &WebhookReadResponse{
+		Id:                  subscription.Id,
+		Url:                 subscription.Url,
+		EventTypes:          subscription.EventTypes,
+		Enabled:             subscription.Enabled,
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+		UpdatedAt:           subscription.UpdatedAt,
+	}
+}
+
+type WebhookCreateBody struct {
+	Url        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	Secret     string   `json:"secret" binding:"omitempty,min=16"`
+}
+
+// WebhookCreateResponse is the only representation of a subscription carrying its secret.
+type WebhookCreateResponse struct {
+	Id     uuid.UUID `json:"id" binding:"required"`
+	Secret string    `json:"secret" binding:"required"`
+}
+
+type WebhookUpdateRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+type WebhookUpdateBody struct {
+	Url        string   `json:"url" binding:"required,url"`
+	EventTypes []string `json:"event_types" binding:"required,min=1"`
+	Secret     *string  `json:"secret" binding:"omitempty,min=16"`
+	Enabled    *bool    `json:"enabled" binding:"required"`
+}
+
+type WebhookDeleteRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type WebhookDeliveryListRequest struct {
+	Id string `uri:"id" binding:"required,uuid"`
+}
+
+type WebhookDeliveryResponse struct {
+	Id            uuid.UUID  `json:"id" binding:"required"`
+	EventId       string     `json:"event_id" binding:"required"`
+	EventType     string     `json:"event_type" binding:"required"`
+	Status        string     `json:"status" binding:"required"`
+	Attempts      int        `json:"attempts" binding:"required"`
+	StatusCode    int        `json:"status_code,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	DurationMs    int64      `json:"duration_ms" binding:"required"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" binding:"required"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// NewWebhookDeliveryResponse returns the representation of a delivery. The next attempt is only set while it is pending.
+func NewWebhookDeliveryResponse(delivery *model.WebhookDelivery) *WebhookDeliveryResponse {
+	response := &WebhookDeliveryResponse{
+		Id:         delivery.Id,
+		EventId:    delivery.EventId,
+		EventType:  delivery.EventType,
+		Status:     string(delivery.Status),
+		Attempts:   delivery.Attempts,
+		StatusCode: delivery.StatusCode,
+		Error:      delivery.Error,
+		DurationMs: delivery.Duration.Milliseconds(),
+		CreatedAt:  delivery.CreatedAt,
+		UpdatedAt:  delivery.UpdatedAt,
+	}
+	if delivery.Status == model.WebhookDeliveryPending {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return response
+}
@@ -0,0 +1,238 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/http/dto"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var _ IFileController = (*FileController)(nil)
+
+// IFileController defines an interface for managing the files uploaded straight to the object storage through HTTP handlers.
+// Create registers a pending file and returns the request uploading it.
+// Complete verifies the upload of a file.
+// GetByID retrieves a file, along with the request downloading it once uploaded.
+// DeleteByID deletes a file along with its content.
+type IFileController interface {
+	Create(ctx *gin.Context)
+	Complete(ctx *gin.Context)
+	GetByID(ctx *gin.Context)
+	DeleteByID(ctx *gin.Context)
+}
+
+// FileController manages the HTTP request handling for operations related to files.
+type FileController struct {
+	svc service.IFileService
+}
+
+// Create @Summary Create a new file
+// @Description Declare a file and get the presigned request uploading its content to the object storage. The file is pending until its upload is completed.
+// @Tags File
+// @Accept json
+// @Produce json
+// @Param file body dto.FileCreateBody true "File"
+// @Success 201 {object} dto.FileCreateResponse
+// @Router /files [post]
+func (c *FileController) Create(ctx *gin.Context) {
+	tracer := otel.Tracer("FileController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "FileController.Create")
+	defer span.End()
+
+	var input dto.FileCreateBody
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate request body")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate request body"})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("file.mime_type", input.MimeType),
+		attribute.Int64("file.size", input.Size),
+	)
+
+	file, upload, err := c.svc.Create(spanCtx, data.FileCreateInput{
+		Name:     input.Name,
+		Size:     input.Size,
+		MimeType: input.MimeType,
+	})
+	if err != nil {
+		span.RecordError(err)
+		var validationErrors validator.ValidationErrors
+		switch {
+		case errors.As(err, &port.ErrorTooLarge):
+			span.SetStatus(codes.Error, "file too large")
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		case errors.As(err, &validationErrors):
+			span.SetStatus(codes.Error, "invalid file")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid file"})
+		default:
+			span.SetStatus(codes.Error, "failed to create file")
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create file"})
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.String("file.id", file.Id.String()))
+	ctx.JSON(http.StatusCreated, &dto.FileCreateResponse{
+		Id:     file.Id,
+		Status: string(file.Status),
+		Upload: dto.NewPresignedRequestResponse(upload),
+	})
+}
+
+// Complete @Summary Complete the upload of a file
+// @Description Verify that the content of a file was uploaded to the object storage and mark the file complete. A content that does not match the file fails it.
+// @Tags File
+// @Accept json
+// @Produce json
+// @Param id path uuid true "File id"
+// @Success 200 {object} dto.FileReadResponse
+// @Failure 409 "Upload incomplete"
+// @Router /files/{id}/complete [post]
+func (c *FileController) Complete(ctx *gin.Context) {
+	tracer := otel.Tracer("FileController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "FileController.Complete")
+	defer span.End()
+
+	var pathParams dto.FileCompleteRequest
+	id, ok := bindFileID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	file, err := c.svc.Complete(spanCtx, id)
+	if err != nil {
+		span.RecordError(err)
+		var incomplete *port.ErrUploadIncomplete
+		switch {
+		case errors.As(err, &port.ErrorNotFound):
+			span.SetStatus(codes.Error, "file not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+		case errors.As(err, &incomplete):
+			span.SetStatus(codes.Error, "upload incomplete")
+			ctx.JSON(http.StatusConflict, gin.H{"error": "upload incomplete", "reason": incomplete.Reason})
+		default:
+			span.SetStatus(codes.Error, "failed to complete file upload")
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to complete file upload"})
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.JSON(http.StatusOK, dto.NewFileReadResponse(file, nil))
+}
+
+// GetByID @Summary Get file by id
+// @Description Get a file by id, along with the presigned request downloading its content once its upload is completed
+// @Tags File
+// @Accept json
+// @Produce json
+// @Param id path uuid true "File id"
+// @Success 200 {object} dto.FileReadResponse
+// @Router /files/{id} [get]
+func (c *FileController) GetByID(ctx *gin.Context) {
+	tracer := otel.Tracer("FileController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "FileController.GetByID")
+	defer span.End()
+
+	var pathParams dto.FileReadRequest
+	id, ok := bindFileID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	file, download, err := c.svc.GetByID(spanCtx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "file not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to get file by id")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get file by id"})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.JSON(http.StatusOK, dto.NewFileReadResponse(file, download))
+}
+
+// DeleteByID @Summary Delete a file
+// @Description Delete a file along with its content in the object storage
+// @Tags File
+// @Accept json
+// @Produce json
+// @Param id path uuid true "File id"
+// @Success 204
+// @Router /files/{id} [delete]
+func (c *FileController) DeleteByID(ctx *gin.Context) {
+	tracer := otel.Tracer("FileController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "FileController.DeleteByID")
+	defer span.End()
+
+	var pathParams dto.FileDeleteRequest
+	id, ok := bindFileID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	if err := c.svc.DeleteByID(spanCtx, id); err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "file not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "file not found"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to delete file")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete file"})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.Status(http.StatusNoContent)
+}
+
+// bindFileID binds the path params into params and parses the id they hold. On failure, it answers the request and
+// returns false.
+func bindFileID(ctx *gin.Context, span trace.Span, params any, rawID *string) (uuid.UUID, bool) {
+	if err := ctx.ShouldBindUri(params); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate path params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate path params"})
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(*rawID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to parse id to uuid")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse id to uuid"})
+		return uuid.Nil, false
+	}
+	span.SetAttributes(attribute.String("file.id", id.String()))
+	return id, true
+}
+
+// NewFileController initializes a new FileController with the provided IFileService dependency.
+func NewFileController(svc service.IFileService) *FileController {
+	c := &FileController{
+		svc: svc,
+	}
+
+	return c
+}
@@ -0,0 +1,216 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	data2 "github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFileController(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("30000000-0000-0000-0000-000000000001")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	pending := &model.File{
+		Id:        id,
+		Name:      "report.pdf",
+		Size:      512,
+		Extension: ".pdf",
+		MimeType:  "application/pdf",
+		Path:      "files/30000000-0000-0000-0000-000000000001.pdf",
+		Status:    model.UploadStatusPending,
+		CreatedAt: createdAt,
+	}
+	complete := *pending
+	complete.Status = model.UploadStatusComplete
+	complete.UploadedAt = createdAt.Add(time.Minute)
+
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		statusCode   int
+		bodyResponse string
+
+		setupMockHandler func(*service.MockFileService)
+	}{
+		{
+			name:       "Success Case - Create",
+			method:     http.MethodPost,
+			url:        "/files",
+			body:       `{"name":"report.pdf","size":512,"mime_type":"application/pdf"}`,
+			statusCode: http.StatusCreated,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","status":"pending","upload":{"method":"PUT",
+				"url":"https://storage/upload","headers":{"Content-Type":["application/pdf"]}}}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Create", mock.Anything, data2.FileCreateInput{
+					Name:     "report.pdf",
+					Size:     512,
+					MimeType: "application/pdf",
+				}).Return(pending, &model.PresignedRequest{
+					Method: http.MethodPut,
+					Url:    "https://storage/upload",
+					Header: http.Header{"Content-Type": {"application/pdf"}},
+				}, nil)
+			},
+		},
+		{
+			name:             "Failure Case - Create Invalid Body",
+			method:           http.MethodPost,
+			url:              "/files",
+			body:             `{"name":"report.pdf","size":0,"mime_type":"application/pdf"}`,
+			statusCode:       http.StatusBadRequest,
+			bodyResponse:     `{"error":"failed to validate request body"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {},
+		},
+		{
+			name:         "Failure Case - Create Invalid File",
+			method:       http.MethodPost,
+			url:          "/files",
+			body:         `{"name":"report.pdf","size":512,"mime_type":"application/pdf"}`,
+			statusCode:   http.StatusBadRequest,
+			bodyResponse: `{"error":"invalid file"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Create", mock.Anything, mock.Anything).
+					Return((*model.File)(nil), (*model.PresignedRequest)(nil), validator.ValidationErrors{})
+			},
+		},
+		{
+			name:         "Failure Case - Create Too Large",
+			method:       http.MethodPost,
+			url:          "/files",
+			body:         `{"name":"report.pdf","size":512,"mime_type":"application/pdf"}`,
+			statusCode:   http.StatusRequestEntityTooLarge,
+			bodyResponse: `{"error":"file too large"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Create", mock.Anything, mock.Anything).
+					Return((*model.File)(nil), (*model.PresignedRequest)(nil), port.NewErrTooLarge("file", 512, 256))
+			},
+		},
+		{
+			name:         "Success Case - Complete",
+			method:       http.MethodPost,
+			url:          "/files/30000000-0000-0000-0000-000000000001/complete",
+			statusCode:   http.StatusOK,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","name":"report.pdf","size":512,"extension":".pdf","mime_type":"application/pdf","status":"complete","created_at":"2025-01-02T03:04:05Z","uploaded_at":"2025-01-02T03:05:05Z"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Complete", mock.Anything, id).Return(&complete, nil)
+			},
+		},
+		{
+			name:         "Failure Case - Complete Upload Incomplete",
+			method:       http.MethodPost,
+			url:          "/files/30000000-0000-0000-0000-000000000001/complete",
+			statusCode:   http.StatusConflict,
+			bodyResponse: `{"error":"upload incomplete","reason":"object not found"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Complete", mock.Anything, id).Return((*model.File)(nil), port.NewErrUploadIncomplete(id.String(), "object not found"))
+			},
+		},
+		{
+			name:         "Failure Case - Complete Not Found",
+			method:       http.MethodPost,
+			url:          "/files/30000000-0000-0000-0000-000000000001/complete",
+			statusCode:   http.StatusNotFound,
+			bodyResponse: `{"error":"file not found"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("Complete", mock.Anything, id).Return((*model.File)(nil), port.NewErrNotFound("file", "id", id.String()))
+			},
+		},
+		{
+			name:       "Success Case - Get By ID",
+			method:     http.MethodGet,
+			url:        "/files/30000000-0000-0000-0000-000000000001",
+			statusCode: http.StatusOK,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","name":"report.pdf","size":512,"extension":".pdf","mime_type":"application/pdf","status":"complete","created_at":"2025-01-02T03:04:05Z","uploaded_at":"2025-01-02T03:05:05Z",
+				"download":{"method":"GET","url":"https://storage/download"}}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(&complete, &model.PresignedRequest{
+					Method: http.MethodGet,
+					Url:    "https://storage/download",
+					Header: http.Header{},
+				}, nil)
+			},
+		},
+		{
+			name:         "Success Case - Get By ID Pending",
+			method:       http.MethodGet,
+			url:          "/files/30000000-0000-0000-0000-000000000001",
+			statusCode:   http.StatusOK,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","name":"report.pdf","size":512,"extension":".pdf","mime_type":"application/pdf","status":"pending","created_at":"2025-01-02T03:04:05Z"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(pending, (*model.PresignedRequest)(nil), nil)
+			},
+		},
+		{
+			name:             "Failure Case - Get By ID Invalid UUID",
+			method:           http.MethodGet,
+			url:              "/files/invalid",
+			statusCode:       http.StatusBadRequest,
+			bodyResponse:     `{"error":"failed to validate path params"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {},
+		},
+		{
+			name:         "Success Case - Delete",
+			method:       http.MethodDelete,
+			url:          "/files/30000000-0000-0000-0000-000000000001",
+			statusCode:   http.StatusNoContent,
+			bodyResponse: ``,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("DeleteByID", mock.Anything, id).Return(nil)
+			},
+		},
+		{
+			name:         "Failure Case - Delete Service Error",
+			method:       http.MethodDelete,
+			url:          "/files/30000000-0000-0000-0000-000000000001",
+			statusCode:   http.StatusInternalServerError,
+			bodyResponse: `{"error":"failed to delete file"}`,
+			setupMockHandler: func(mockHandler *service.MockFileService) {
+				mockHandler.On("DeleteByID", mock.Anything, id).Return(errors.New("storage error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockHandler := new(service.MockFileService)
+			controller := NewFileController(mockHandler)
+
+			testCase.setupMockHandler(mockHandler)
+
+			req, err := http.NewRequest(testCase.method, testCase.url, strings.NewReader(testCase.body))
+			assert.NoError(t, err)
+			w := httptest.NewRecorder()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.POST("/files", controller.Create)
+			router.POST("/files/:id/complete", controller.Complete)
+			router.GET("/files/:id", controller.GetByID)
+			router.DELETE("/files/:id", controller.DeleteByID)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.statusCode, w.Code)
+			if testCase.bodyResponse != "" {
+				assert.JSONEq(t, testCase.bodyResponse, w.Body.String())
+			}
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/application/http/dto"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port"
@@ -45,7 +46,9 @@ type FooController struct {
 // @Produce json
 // @Param offset query int false "Offset"
 // @Param limit query int false "Limit"
+// @Param If-None-Match header string false "ETag of the cached copy"
 // @Success 200 {array} dto.FooReadResponse
+// @Success 304 "Cached copy is still current"
 // @Router /foos [get]
 func (c *FooController) GetAll(ctx *gin.Context) {
 	tracer := otel.Tracer("FooController")
@@ -79,7 +82,8 @@ func (c *FooController) GetAll(ctx *gin.Context) {
 
 	span.SetStatus(codes.Ok, "")
 	span.SetAttributes(attribute.Int("response.count", len(results)))
-	ctx.JSON(http.StatusOK, results)
+	// No Last-Modified: the dates of the listed Foos do not reflect deletions, only the ETag does.
+	writeConditional(ctx, span, results, time.Time{})
 }
 
 // GetByID @Summary Get foo by id
@@ -88,7 +92,10 @@ func (c *FooController) GetAll(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path uuid true "Foo id"
+// @Param If-None-Match header string false "ETag of the cached copy"
+// @Param If-Modified-Since header string false "Last-Modified date of the cached copy"
 // @Success 200 {object} dto.FooReadResponse
+// @Success 304 "Cached copy is still current"
 // @Router /foos/{id} [get]
 func (c *FooController) GetByID(ctx *gin.Context) {
 	tracer := otel.Tracer("FooController")
@@ -136,7 +143,7 @@ func (c *FooController) GetByID(ctx *gin.Context) {
 		attribute.Float64("foo.weight", float64(foo.Weight)),
 	)
 
-	ctx.JSON(http.StatusOK, result)
+	writeConditional(ctx, span, result, lastModified(foo))
 }
 
 // Create @Summary Create a new foo
@@ -232,7 +239,7 @@ func (c *FooController) Update(ctx *gin.Context) {
 		attribute.Float64("foo.weight", float64(body.Weight)),
 	)
 
-	if err := c.svc.Update(spanCtx, &data.FooUpdateInput{
+	if _, err := c.svc.Update(spanCtx, &data.FooUpdateInput{
 		Id:     id,
 		Label:  body.Label,
 		Secret: body.Secret,
@@ -323,7 +330,7 @@ func (c *FooController) Patch(ctx *gin.Context) {
 		input.Weight.Value = *body.Weight
 	}
 
-	if err := c.svc.Update(spanCtx, &input); err != nil {
+	if _, err := c.svc.Update(spanCtx, &input); err != nil {
 		span.RecordError(err)
 		if errors.As(err, &port.ErrorNotFound) {
 			span.SetStatus(codes.Error, "foo not found")
@@ -8,6 +8,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/TancelinMazzotti/astigo/internal/application/http/middleware"
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port"
 	data2 "github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
@@ -381,7 +383,7 @@ func TestFooController_Update(t *testing.T) {
 						Secret: "secret_update",
 						Value:  1,
 						Weight: 1.5,
-					}).Return(nil)
+					}).Return(&model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001")}, nil)
 			},
 		},
 		{
@@ -409,7 +411,7 @@ func TestFooController_Update(t *testing.T) {
 						Secret: "secret_update",
 						Value:  1,
 						Weight: 1.5,
-					}).Return(port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"))
+					}).Return((*model.Foo)(nil), port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"))
 			},
 		},
 		{
@@ -429,7 +431,7 @@ func TestFooController_Update(t *testing.T) {
 						Secret: "secret_update",
 						Value:  1,
 						Weight: 1.5,
-					}).Return(errors.New("repository error"))
+					}).Return((*model.Foo)(nil), errors.New("repository error"))
 			},
 		},
 	}
@@ -501,7 +503,7 @@ func TestFooController_Patch(t *testing.T) {
 							Value: 1.5,
 							Set:   true,
 						},
-					}).Return(nil)
+					}).Return(&model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001")}, nil)
 			},
 		},
 		{
@@ -520,7 +522,7 @@ func TestFooController_Patch(t *testing.T) {
 							Value: "foo_patch",
 							Set:   true,
 						},
-					}).Return(nil)
+					}).Return(&model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001")}, nil)
 			},
 		},
 		{
@@ -540,7 +542,7 @@ func TestFooController_Patch(t *testing.T) {
 							Value: "foo_patch",
 							Set:   true,
 						},
-					}).Return(port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"))
+					}).Return((*model.Foo)(nil), port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"))
 			},
 		},
 		{
@@ -560,7 +562,7 @@ func TestFooController_Patch(t *testing.T) {
 							Value: "foo_patch",
 							Set:   true,
 						},
-					}).Return(errors.New("repository error"))
+					}).Return((*model.Foo)(nil), errors.New("repository error"))
 			},
 		},
 	}
@@ -678,3 +680,117 @@ func TestFooController_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestFooController_GetByID_Conditional(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	updatedAt := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	foo := &model.Foo{
+		Id:        id,
+		Label:     "foo1",
+		Secret:    "secret1",
+		Value:     1,
+		Weight:    1.5,
+		CreatedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: &updatedAt,
+	}
+	etag := httpcache.ETag([]byte(`{"id":"20000000-0000-0000-0000-000000000001","label":"foo1","value":1,"weight":1.5}`))
+	policy := httpcache.NewPolicy(httpcache.Config{
+		Enabled: true,
+		Routes:  []httpcache.Route{{Route: "GET /foos/:id", MaxAge: 30 * time.Second}},
+	})
+
+	testCases := []struct {
+		name                 string
+		url                  string
+		header               http.Header
+		statusCode           int
+		expectedCacheControl string
+
+		setupMockHandler func(*service.MockFooService)
+	}{
+		{
+			name:                 "Success Case - Full Response",
+			url:                  "/foos/20000000-0000-0000-0000-000000000001",
+			header:               http.Header{},
+			statusCode:           http.StatusOK,
+			expectedCacheControl: "public, max-age=30",
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(foo, nil)
+			},
+		},
+		{
+			name:                 "Success Case - Matching ETag",
+			url:                  "/foos/20000000-0000-0000-0000-000000000001",
+			header:               http.Header{"If-None-Match": {etag}},
+			statusCode:           http.StatusNotModified,
+			expectedCacheControl: "public, max-age=30",
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(foo, nil)
+			},
+		},
+		{
+			name:                 "Success Case - Not Modified Since",
+			url:                  "/foos/20000000-0000-0000-0000-000000000001",
+			header:               http.Header{"If-Modified-Since": {updatedAt.Format(http.TimeFormat)}},
+			statusCode:           http.StatusNotModified,
+			expectedCacheControl: "public, max-age=30",
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(foo, nil)
+			},
+		},
+		{
+			name: "Success Case - Authenticated Request",
+			url:  "/foos/20000000-0000-0000-0000-000000000001",
+			header: http.Header{
+				"Authorization": {"Bearer token"},
+				"If-None-Match": {`"stale"`},
+			},
+			statusCode:           http.StatusOK,
+			expectedCacheControl: "private, max-age=30",
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(foo, nil)
+			},
+		},
+		{
+			name:       "Failure Case - Not Found Is Not Cacheable",
+			url:        "/foos/40400000-0000-0000-0000-000000000000",
+			header:     http.Header{},
+			statusCode: http.StatusNotFound,
+			setupMockHandler: func(mockHandler *service.MockFooService) {
+				mockHandler.On("GetByID", mock.Anything, uuid.MustParse("40400000-0000-0000-0000-000000000000")).
+					Return((*model.Foo)(nil), port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockHandler := new(service.MockFooService)
+			controller := NewFooController(mockHandler)
+
+			testCase.setupMockHandler(mockHandler)
+
+			router := gin.New()
+			router.Use(middleware.CacheControlMiddleware(policy))
+			router.GET("/foos/:id", controller.GetByID)
+
+			req, _ := http.NewRequest(http.MethodGet, testCase.url, nil)
+			req.Header = testCase.header
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.statusCode, w.Code)
+			assert.Equal(t, testCase.expectedCacheControl, w.Header().Get("Cache-Control"))
+			if testCase.statusCode == http.StatusOK || testCase.statusCode == http.StatusNotModified {
+				assert.Equal(t, etag, w.Header().Get("ETag"))
+				assert.Equal(t, updatedAt.Format(http.TimeFormat), w.Header().Get("Last-Modified"))
+			}
+			if testCase.statusCode == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			}
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
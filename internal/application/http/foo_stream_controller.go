@@ -0,0 +1,293 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/http/dto"
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultHeartbeatInterval = 15 * time.Second
+	websocketWriteTimeout    = 10 * time.Second
+)
+
+var _ IFooStreamController = (*FooStreamController)(nil)
+
+// IFooStreamController defines an interface for pushing Foo change events to browser clients.
+// Events streams Foo events using Server-Sent Events.
+// WebSocket streams Foo events over a WebSocket connection.
+type IFooStreamController interface {
+	Events(ctx *gin.Context)
+	WebSocket(ctx *gin.Context)
+}
+
+// FooStreamController fans out Foo events received from the stream hub to SSE and WebSocket clients.
+type FooStreamController struct {
+	hub       *stream.Hub
+	heartbeat time.Duration
+	origins   map[string]struct{}
+	upgrader  websocket.Upgrader
+}
+
+// Events @Summary Stream foo events
+// @Description Stream foo created, updated and deleted events using Server-Sent Events
+// @Tags Foo
+// @Produce text/event-stream
+// @Security BearerAuth
+// @Param type query []string false "Event types to receive (foo.created, foo.updated, foo.deleted)"
+// @Param id query []string false "Foo ids to receive"
+// @Param last_event_id query int false "Resume after this event id (alternative to the Last-Event-ID header)"
+// @Param access_token query string false "Bearer token, for the clients which cannot set the Authorization header"
+// @Success 200
+// @Router /foos/events [get]
+func (c *FooStreamController) Events(ctx *gin.Context) {
+	tracer := otel.Tracer("FooStreamController")
+	_, span := tracer.Start(ctx.Request.Context(), "FooStreamController.Events")
+	defer span.End()
+
+	filter, lastEventID, err := c.bind(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate query params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate query params"})
+		return
+	}
+	span.SetAttributes(attribute.Int64("stream.last_event_id", int64(lastEventID)))
+
+	sub, replay := c.hub.Subscribe(filter, lastEventID)
+	defer sub.Close()
+
+	stream.ConnectedClients.WithLabelValues("sse").Inc()
+	defer stream.ConnectedClients.WithLabelValues("sse").Dec()
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	fmt.Fprintf(ctx.Writer, "retry: %d\n\n", c.heartbeat.Milliseconds())
+	for _, event := range replay {
+		writeServerSentEvent(ctx.Writer, event)
+	}
+	ctx.Writer.Flush()
+	span.SetAttributes(attribute.Int("stream.replayed", len(replay)))
+
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			span.SetStatus(codes.Ok, "")
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				span.SetStatus(codes.Ok, "stream closed")
+				return
+			}
+			writeServerSentEvent(ctx.Writer, event)
+			ctx.Writer.Flush()
+		case <-ticker.C:
+			fmt.Fprint(ctx.Writer, ": heartbeat\n\n")
+			ctx.Writer.Flush()
+		}
+	}
+}
+
+// WebSocket @Summary Stream foo events over WebSocket
+// @Description Stream foo created, updated and deleted events over a WebSocket connection
+// @Tags Foo
+// @Security BearerAuth
+// @Param type query []string false "Event types to receive (foo.created, foo.updated, foo.deleted)"
+// @Param id query []string false "Foo ids to receive"
+// @Param last_event_id query int false "Resume after this event id"
+// @Param access_token query string false "Bearer token, for the clients which cannot set the Authorization header"
+// @Success 101
+// @Router /foos/ws [get]
+func (c *FooStreamController) WebSocket(ctx *gin.Context) {
+	tracer := otel.Tracer("FooStreamController")
+	_, span := tracer.Start(ctx.Request.Context(), "FooStreamController.WebSocket")
+	defer span.End()
+
+	filter, lastEventID, err := c.bind(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate query params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate query params"})
+		return
+	}
+	span.SetAttributes(attribute.Int64("stream.last_event_id", int64(lastEventID)))
+
+	conn, err := c.upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		// The upgrader already replied to the client.
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to upgrade connection")
+		return
+	}
+	defer conn.Close()
+
+	sub, replay := c.hub.Subscribe(filter, lastEventID)
+	defer sub.Close()
+
+	stream.ConnectedClients.WithLabelValues("websocket").Inc()
+	defer stream.ConnectedClients.WithLabelValues("websocket").Dec()
+
+	// Clients do not send anything: the read loop only processes control frames and detects disconnections.
+	done := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(2 * c.heartbeat))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * c.heartbeat))
+	})
+	go func() {
+		defer close(done)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range replay {
+		if err := writeWebSocketEvent(conn, event); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to write replayed event")
+			return
+		}
+	}
+	span.SetAttributes(attribute.Int("stream.replayed", len(replay)))
+
+	ticker := time.NewTicker(c.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			span.SetStatus(codes.Ok, "")
+			return
+		case event, ok := <-sub.C:
+			if !ok {
+				_ = conn.WriteControl(
+					websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream closed"),
+					time.Now().Add(websocketWriteTimeout),
+				)
+				span.SetStatus(codes.Ok, "stream closed")
+				return
+			}
+			if err := writeWebSocketEvent(conn, event); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to write event")
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(websocketWriteTimeout)); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to write heartbeat")
+				return
+			}
+		}
+	}
+}
+
+// bind parses the query filters and the Last-Event-ID header shared by both transports.
+func (c *FooStreamController) bind(ctx *gin.Context) (stream.Filter, uint64, error) {
+	var queryParams dto.FooStreamRequest
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		return stream.Filter{}, 0, err
+	}
+
+	ids := make([]uuid.UUID, len(queryParams.Ids))
+	for i, id := range queryParams.Ids {
+		parsed, err := uuid.Parse(id)
+		if err != nil {
+			return stream.Filter{}, 0, err
+		}
+		ids[i] = parsed
+	}
+
+	lastEventID := queryParams.LastEventID
+	if header := ctx.GetHeader("Last-Event-ID"); header != "" {
+		parsed, err := strconv.ParseUint(header, 10, 64)
+		if err != nil {
+			return stream.Filter{}, 0, err
+		}
+		lastEventID = parsed
+	}
+
+	return stream.NewFilter(queryParams.Types, ids), lastEventID, nil
+}
+
+func writeServerSentEvent(w gin.ResponseWriter, event stream.Event) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, event.Data)
+	stream.EventsDelivered.WithLabelValues("sse", event.Type).Inc()
+}
+
+func writeWebSocketEvent(conn *websocket.Conn, event stream.Event) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(websocketWriteTimeout)); err != nil {
+		return err
+	}
+	if err := conn.WriteJSON(dto.FooStreamMessage{
+		Id:   event.ID,
+		Type: event.Type,
+		Data: event.Data,
+	}); err != nil {
+		return err
+	}
+	stream.EventsDelivered.WithLabelValues("websocket", event.Type).Inc()
+
+	return nil
+}
+
+// NewFooStreamController initializes a new FooStreamController reading events from the provided hub.
+func NewFooStreamController(config stream.Config, hub *stream.Hub) *FooStreamController {
+	heartbeat := config.HeartbeatInterval
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeatInterval
+	}
+
+	c := &FooStreamController{
+		hub:       hub,
+		heartbeat: heartbeat,
+		origins:   make(map[string]struct{}, len(config.AllowedOrigins)),
+	}
+	for _, origin := range config.AllowedOrigins {
+		c.origins[strings.TrimSuffix(origin, "/")] = struct{}{}
+	}
+	c.upgrader = websocket.Upgrader{CheckOrigin: c.checkOrigin}
+
+	return c
+}
+
+// checkOrigin accepts the WebSocket handshakes without Origin, which do not come from a browser, and those of the
+// pages of the API's own origin or of an allowed origin, so that another site cannot open a stream with the
+// credentials of its visitors.
+func (c *FooStreamController) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if _, ok := c.origins["*"]; ok {
+		return true
+	}
+	if _, ok := c.origins[origin]; ok {
+		return true
+	}
+
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
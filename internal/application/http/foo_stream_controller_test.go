@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFooStreamController_CheckOrigin(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expected       bool
+	}{
+		{
+			name:     "Success Case - No Origin",
+			expected: true,
+		},
+		{
+			name:     "Success Case - Same Origin",
+			origin:   "https://api.example.com",
+			expected: true,
+		},
+		{
+			name:           "Success Case - Allowed Origin",
+			allowedOrigins: []string{"https://app.example.com/"},
+			origin:         "https://app.example.com",
+			expected:       true,
+		},
+		{
+			name:           "Success Case - Any Origin",
+			allowedOrigins: []string{"*"},
+			origin:         "https://other.example.com",
+			expected:       true,
+		},
+		{
+			name:           "Failure Case - Other Origin",
+			allowedOrigins: []string{"https://app.example.com"},
+			origin:         "https://evil.example.com",
+		},
+		{
+			name:   "Failure Case - Other Port",
+			origin: "https://api.example.com:8443",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			controller := NewFooStreamController(stream.Config{AllowedOrigins: testCase.allowedOrigins}, nil)
+			req := httptest.NewRequest(http.MethodGet, "https://api.example.com/foos/ws", nil)
+			if testCase.origin != "" {
+				req.Header.Set("Origin", testCase.origin)
+			}
+
+			assert.Equal(t, testCase.expected, controller.checkOrigin(req))
+		})
+	}
+}
@@ -9,9 +9,15 @@ import (
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
 	"github.com/TancelinMazzotti/astigo/internal/application/http/middleware"
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	ratelimit2 "github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
 	"github.com/TancelinMazzotti/astigo/internal/domain/service"
+	"github.com/TancelinMazzotti/astigo/pkg/proto/protoconnect"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -21,31 +27,53 @@ import (
 
 var StartAt time.Time
 
+// Config holds the settings of the HTTP server. TrustedProxies lists the addresses or CIDRs of the reverse proxies
+// whose forwarding headers, such as X-Forwarded-For, give the client IP. Without any, the client IP is the remote
+// address of the connection.
 type Config struct {
-	Port     string `mapstructure:"port"`
-	Mode     string `mapstructure:"mode"`
-	Issuer   string `mapstructure:"issuer"`
-	ClientID string `mapstructure:"client_id"`
+	Port           string   `mapstructure:"port"`
+	Mode           string   `mapstructure:"mode"`
+	Issuer         string   `mapstructure:"issuer"`
+	ClientID       string   `mapstructure:"client_id"`
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 func NewGin(
 	config Config,
 	logger *zap.Logger,
 	authHandler service.IAuthService,
+	limiter ratelimit2.IRateLimiter,
+	rateLimitPolicy *ratelimit.Policy,
+	cachePolicy *httpcache.Policy,
 	healthController *HealthController,
 	fooController *FooController,
-) *gin.Engine {
+	fooStreamController *FooStreamController,
+	fooConnectService protoconnect.FooServiceHandler,
+	webhookController *WebhookController,
+	fileController *FileController,
+) (*gin.Engine, error) {
 
 	middleware.RegisterMetrics()
+	stream.RegisterMetrics()
+	health.RegisterMetrics()
+	ratelimit.RegisterMetrics()
+	httpcache.RegisterMetrics()
 	gin.SetMode(config.Mode)
 	authMiddleware := middleware.NewAuthMiddleware(authHandler)
+	// Rate limiting runs after authentication so that authenticated callers are limited by subject.
+	rateLimit := middleware.RateLimitMiddleware(limiter, rateLimitPolicy)
 
 	e := gin.New()
+	if err := e.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 	e.Use(otelgin.Middleware("astigo"))
+	e.Use(middleware.RequestIDMiddleware(logger))
 	e.Use(middleware.ZapLoggerMiddleware(logger))
 	e.Use(middleware.ZapRecoveryMiddleware(logger))
 	e.Use(middleware.MetricsMiddleware())
 	e.Use(middleware.CorsMiddleware())
+	e.Use(middleware.CacheControlMiddleware(cachePolicy))
 
 	e.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
@@ -65,15 +93,42 @@ func NewGin(
 
 	e.GET("/health/liveness", healthController.GetLiveness)
 	e.GET("/health/readiness", healthController.GetReadiness)
-
-	e.GET("/foos", fooController.GetAll)
-	e.GET("foos/:id", fooController.GetByID)
-	e.POST("/foos", fooController.Create)
-	e.PUT("/foos/:id", fooController.Update)
-	e.PATCH("/foos/:id", fooController.Patch)
-	e.DELETE("/foos/:id", fooController.DeleteByID)
-
-	e.GET("/private", authMiddleware.Middleware, func(c *gin.Context) {
+	e.GET("/health/startup", healthController.GetStartup)
+
+	e.GET("/foos", rateLimit, fooController.GetAll)
+	e.GET("/foos/events", authMiddleware.StreamMiddleware, rateLimit, fooStreamController.Events)
+	e.GET("/foos/ws", authMiddleware.StreamMiddleware, rateLimit, fooStreamController.WebSocket)
+	e.GET("foos/:id", rateLimit, fooController.GetByID)
+	e.POST("/foos", rateLimit, fooController.Create)
+	e.PUT("/foos/:id", rateLimit, fooController.Update)
+	e.PATCH("/foos/:id", rateLimit, fooController.Patch)
+	e.DELETE("/foos/:id", rateLimit, fooController.DeleteByID)
+
+	e.GET("/webhooks", authMiddleware.Middleware, rateLimit, webhookController.GetAll)
+	e.GET("/webhooks/:id", authMiddleware.Middleware, rateLimit, webhookController.GetByID)
+	e.GET("/webhooks/:id/deliveries", authMiddleware.Middleware, rateLimit, webhookController.GetDeliveries)
+	e.POST("/webhooks", authMiddleware.Middleware, rateLimit, webhookController.Create)
+	e.PUT("/webhooks/:id", authMiddleware.Middleware, rateLimit, webhookController.Update)
+	e.DELETE("/webhooks/:id", authMiddleware.Middleware, rateLimit, webhookController.DeleteByID)
+
+	e.POST("/files", authMiddleware.Middleware, rateLimit, fileController.Create)
+	e.POST("/files/:id/complete", authMiddleware.Middleware, rateLimit, fileController.Complete)
+	e.GET("/files/:id", authMiddleware.Middleware, rateLimit, fileController.GetByID)
+	e.DELETE("/files/:id", authMiddleware.Middleware, rateLimit, fileController.DeleteByID)
+
+	// FooService over Connect, gRPC-Web and gRPC (h2c) for browser and edge clients. Like the REST routes and the native
+	// gRPC server, it is not authenticated, and its procedures share the rate limits of the gRPC methods.
+	fooConnectPath, fooConnectHandler := protoconnect.NewFooServiceHandler(fooConnectService)
+	e.POST(fooConnectPath+"*procedure", middleware.ProcedureRateLimitMiddleware(limiter, rateLimitPolicy,
+		protoconnect.FooServiceCreateProcedure,
+		protoconnect.FooServiceGetProcedure,
+		protoconnect.FooServiceUpdateProcedure,
+		protoconnect.FooServicePatchProcedure,
+		protoconnect.FooServiceDeleteProcedure,
+		protoconnect.FooServiceListProcedure,
+	), gin.WrapH(fooConnectHandler))
+
+	e.GET("/private", authMiddleware.Middleware, rateLimit, func(c *gin.Context) {
 		claimsCtx, _ := c.Get("claims")
 		claims, ok := claimsCtx.(*model.Claims)
 		if !ok {
@@ -85,5 +140,5 @@ func NewGin(
 		})
 	})
 
-	return e
+	return e, nil
 }
@@ -4,10 +4,14 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
+
 	"github.com/gin-gonic/gin"
 )
 
-type HealthController struct{}
+type HealthController struct {
+	checker health.IHealthChecker
+}
 
 func (c *HealthController) GetLiveness(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{
@@ -18,17 +22,53 @@ func (c *HealthController) GetLiveness(ctx *gin.Context) {
 	})
 }
 
+// GetReadiness reports the result of every dependency check. Only critical failures make the service unready.
 func (c *HealthController) GetReadiness(ctx *gin.Context) {
+	report := c.checker.Report(ctx.Request.Context())
+
+	statusCode := http.StatusOK
+	message := "Service is healthy"
+	switch report.Status {
+	case health.StatusDegraded:
+		message = "Service is degraded"
+	case health.StatusDown:
+		statusCode = http.StatusServiceUnavailable
+		message = "Service is not ready"
+	}
+
+	ctx.JSON(statusCode, gin.H{
+		"status":     report.Status,
+		"message":    message,
+		"checks":     report.Checks,
+		"timestamp":  time.Now().UTC().Format(time.RFC3339),
+		"start_time": StartAt.Format(time.RFC3339),
+	})
+}
+
+// GetStartup succeeds once every critical dependency has been reachable at least once.
+func (c *HealthController) GetStartup(ctx *gin.Context) {
+	if !c.checker.Started(ctx.Request.Context()) {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":     health.StatusDown,
+			"message":    "Service is starting",
+			"timestamp":  time.Now().UTC().Format(time.RFC3339),
+			"start_time": StartAt.Format(time.RFC3339),
+		})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{
-		"status":     "UP",
-		"message":    "Service is healthy",
+		"status":     health.StatusUp,
+		"message":    "Service is started",
 		"timestamp":  time.Now().UTC().Format(time.RFC3339),
 		"start_time": StartAt.Format(time.RFC3339),
 	})
 }
 
-func NewHealthController() *HealthController {
-	c := &HealthController{}
+func NewHealthController(checker health.IHealthChecker) *HealthController {
+	c := &HealthController{
+		checker: checker,
+	}
 
 	return c
 }
@@ -6,10 +6,14 @@ import (
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/service"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
 
 	"github.com/gin-gonic/gin"
 )
 
+// accessTokenParam is the query parameter carrying the bearer token of the stream requests.
+const accessTokenParam = "access_token"
+
 // AuthMiddleware handles authentication by validating JWT tokens in incoming requests.
 // It uses a provided implementation of IAuthService for token verification and claims extraction.
 type AuthMiddleware struct {
@@ -30,9 +34,33 @@ func (m *AuthMiddleware) Middleware(c *gin.Context) {
 		return
 	}
 
-	token := parts[1]
+	m.authenticate(c, parts[1])
+}
+
+// StreamMiddleware authenticates the streams opened by the browsers, whose EventSource and WebSocket APIs cannot set
+// the Authorization header: without the header, the bearer token is read from the access_token query parameter, as
+// RFC 6750 allows. The parameter is removed from the request once read.
+func (m *AuthMiddleware) StreamMiddleware(c *gin.Context) {
+	if c.GetHeader("Authorization") != "" {
+		m.Middleware(c)
+		return
+	}
+
+	query := c.Request.URL.Query()
+	token := query.Get(accessTokenParam)
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header or access_token query parameter required"})
+		return
+	}
+	query.Del(accessTokenParam)
+	c.Request.URL.RawQuery = query.Encode()
+
+	m.authenticate(c, token)
+}
 
-	idToken, err := m.handler.VerifyToken(c, token)
+// authenticate verifies the bearer token and stores its claims in the context.
+func (m *AuthMiddleware) authenticate(c *gin.Context, token string) {
+	idToken, err := m.handler.VerifyToken(c.Request.Context(), token)
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 		return
@@ -46,6 +74,7 @@ func (m *AuthMiddleware) Middleware(c *gin.Context) {
 	}
 
 	c.Set("claims", claims)
+	c.Request = c.Request.WithContext(correlation.WithSubject(c.Request.Context(), claims.Subject))
 
 	c.Next()
 
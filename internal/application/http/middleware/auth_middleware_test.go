@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAuthMiddleware_StreamMiddleware(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		url           string
+		authorization string
+		statusCode    int
+		query         string
+	}{
+		{
+			name:       "Success Case - Query Token",
+			url:        "/foos/events?type=foo.created&access_token=token",
+			statusCode: http.StatusOK,
+			query:      "type=foo.created",
+		},
+		{
+			name:          "Success Case - Authorization Header",
+			url:           "/foos/events",
+			authorization: "Bearer token",
+			statusCode:    http.StatusOK,
+		},
+		{
+			name:       "Failure Case - Missing Token",
+			url:        "/foos/events",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:       "Failure Case - Invalid Query Token",
+			url:        "/foos/events?access_token=other",
+			statusCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "Failure Case - Invalid Header Ignores Query Token",
+			url:           "/foos/events?access_token=token",
+			authorization: "Bearer other",
+			statusCode:    http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			auth := NewAuthMiddleware(service.NewStaticAuthService(zap.NewNop(), "token"))
+			var query string
+			router := gin.New()
+			router.GET("/foos/events", auth.StreamMiddleware, func(c *gin.Context) {
+				query = c.Request.URL.RawQuery
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, testCase.url, nil)
+			if testCase.authorization != "" {
+				req.Header.Set("Authorization", testCase.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.statusCode, rec.Code)
+			assert.Equal(t, testCase.query, query)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControlMiddleware sets the Cache-Control header of the route policy on successful and 304 Not Modified
+// responses to GET and HEAD requests. Errors are never marked cacheable. Requests carrying credentials get private
+// responses even on public routes.
+func CacheControlMiddleware(policy *httpcache.Policy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+
+		_, authenticated := c.Get("claims")
+		authenticated = authenticated || c.GetHeader("Authorization") != ""
+		value, ok := policy.CacheControl(c.Request.Method+" "+c.FullPath(), authenticated)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter decides on the Cache-Control header when the status is set, since headers cannot be changed
+// once the response is written. The status may be set several times before then: the last one wins.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if !w.Written() {
+		if code == http.StatusOK || code == http.StatusNotModified {
+			w.Header().Set("Cache-Control", w.value)
+		} else if w.Header().Get("Cache-Control") == w.value {
+			w.Header().Del("Cache-Control")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControlMiddleware(t *testing.T) {
+	t.Parallel()
+	policy := httpcache.NewPolicy(httpcache.Config{
+		Enabled: true,
+		Routes: []httpcache.Route{
+			{Route: "GET /foos/:id", MaxAge: time.Minute},
+			{Route: "POST /foos/:id", MaxAge: time.Minute},
+		},
+	})
+
+	testCases := []struct {
+		name                 string
+		method               string
+		url                  string
+		authorization        string
+		statusCode           int
+		expectedCacheControl string
+	}{
+		{
+			name:                 "Success Case - OK",
+			method:               http.MethodGet,
+			url:                  "/foos/1",
+			statusCode:           http.StatusOK,
+			expectedCacheControl: "public, max-age=60",
+		},
+		{
+			name:                 "Success Case - Not Modified",
+			method:               http.MethodGet,
+			url:                  "/foos/1",
+			statusCode:           http.StatusNotModified,
+			expectedCacheControl: "public, max-age=60",
+		},
+		{
+			name:                 "Success Case - Authenticated Request Is Private",
+			method:               http.MethodGet,
+			url:                  "/foos/1",
+			authorization:        "Bearer token",
+			statusCode:           http.StatusOK,
+			expectedCacheControl: "private, max-age=60",
+		},
+		{
+			name:       "Success Case - Route Without Policy",
+			method:     http.MethodGet,
+			url:        "/foos",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Success Case - Method Not Cacheable",
+			method:     http.MethodPost,
+			url:        "/foos/1",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "Failure Case - Not Found",
+			method:     http.MethodGet,
+			url:        "/foos/1",
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "Failure Case - Internal Server Error",
+			method:     http.MethodGet,
+			url:        "/foos/1",
+			statusCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(CacheControlMiddleware(policy))
+			handler := func(c *gin.Context) { c.Status(testCase.statusCode) }
+			router.GET("/foos", handler)
+			router.GET("/foos/:id", handler)
+			router.POST("/foos/:id", handler)
+
+			req := httptest.NewRequest(testCase.method, testCase.url, nil)
+			if testCase.authorization != "" {
+				req.Header.Set("Authorization", testCase.authorization)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.statusCode, rec.Code)
+			assert.Equal(t, testCase.expectedCacheControl, rec.Header().Get("Cache-Control"))
+		})
+	}
+}
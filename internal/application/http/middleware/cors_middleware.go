@@ -9,9 +9,17 @@ import (
 
 func CorsMiddleware() gin.HandlerFunc {
 	return cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+		AllowOrigins: []string{"*"},
+		AllowMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders: []string{
+			"Origin", "Content-Length", "Content-Type", "Authorization", "If-None-Match", "If-Modified-Since",
+			// Connect and gRPC-Web request headers
+			"Connect-Protocol-Version", "Connect-Timeout-Ms", "Grpc-Timeout", "X-Grpc-Web", "X-User-Agent",
+		},
+		ExposeHeaders: []string{
+			"X-Request-ID", "ETag", "Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "RateLimit-Policy", "Retry-After",
+		},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	})
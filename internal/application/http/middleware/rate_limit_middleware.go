@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	ratelimit2 "github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitMiddleware rejects requests exceeding the limit of their route with 429 Too Many Requests.
+// Callers are identified by the subject claim when the request has already been authenticated, and by client IP
+// otherwise: an unverified credential would let a caller pick a fresh bucket on every request. The client IP is only
+// read from the forwarding headers of the trusted proxies. When the limiter fails, requests are let through.
+func RateLimitMiddleware(limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy) gin.HandlerFunc {
+	return rateLimitMiddleware(limiter, policy, func(c *gin.Context) string {
+		return c.Request.Method + " " + c.FullPath()
+	})
+}
+
+// ProcedureRateLimitMiddleware is the RateLimitMiddleware of a Connect handler: requests are limited by their procedure,
+// named like the gRPC method (e.g. "/proto.FooService/Create"), so that a procedure has the same limit and the same
+// buckets whether it is called over Connect, gRPC-Web or gRPC. Requests to other paths are limited by their route.
+func ProcedureRateLimitMiddleware(limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy, procedures ...string) gin.HandlerFunc {
+	return rateLimitMiddleware(limiter, policy, func(c *gin.Context) string {
+		if slices.Contains(procedures, c.Request.URL.Path) {
+			return c.Request.URL.Path
+		}
+		return c.Request.Method + " " + c.FullPath()
+	})
+}
+
+func rateLimitMiddleware(limiter ratelimit2.IRateLimiter, policy *ratelimit.Policy, routeOf func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := routeOf(c)
+		limit, ok := policy.Limit(route)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		result, err := limiter.Allow(c.Request.Context(), ratelimit.Key(route, identity(c)), limit)
+		if err != nil {
+			c.Next()
+			return
+		}
+		ratelimit.Observe(route, result.Allowed)
+
+		for key, value := range ratelimit.Headers(limit, result) {
+			c.Header(key, value)
+		}
+
+		if !result.Allowed {
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "too many requests"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func identity(c *gin.Context) string {
+	if claimsCtx, ok := c.Get("claims"); ok {
+		if claims, ok := claimsCtx.(*model.Claims); ok && claims.Subject != "" {
+			return ratelimit.SubjectIdentity(claims.Subject)
+		}
+	}
+
+	return ratelimit.IPIdentity(c.ClientIP())
+}
@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubLimiter records the keys it is asked for and returns result.
+type stubLimiter struct {
+	mu     sync.Mutex
+	keys   []string
+	result model.RateLimitResult
+	err    error
+}
+
+func (l *stubLimiter) Allow(_ context.Context, key string, _ model.RateLimit) (*model.RateLimitResult, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.keys = append(l.keys, key)
+	if l.err != nil {
+		return nil, l.err
+	}
+	result := l.result
+	return &result, nil
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+	policy := ratelimit.NewPolicy(ratelimit.Config{
+		Enabled: true,
+		Limit:   10,
+		Period:  time.Minute,
+		Routes:  []ratelimit.Route{{Route: "GET /health", Limit: 0}},
+	})
+
+	testCases := []struct {
+		name            string
+		url             string
+		claims          *model.Claims
+		limiter         *stubLimiter
+		expectedStatus  int
+		expectedKeys    []string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "Success Case - Allowed",
+			url:            "/foos/1",
+			limiter:        &stubLimiter{result: model.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9, ResetAfter: 6 * time.Second}},
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"GET /foos/:id|ip:192.0.2.1"},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "9",
+				"RateLimit-Reset":     "6",
+				"RateLimit-Policy":    "10;w=60",
+				"Retry-After":         "",
+			},
+		},
+		{
+			name:           "Success Case - Authenticated Caller Limited By Subject",
+			url:            "/foos/1",
+			claims:         &model.Claims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}},
+			limiter:        &stubLimiter{result: model.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9}},
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"GET /foos/:id|sub:user-1"},
+		},
+		{
+			name:           "Success Case - Route Not Limited",
+			url:            "/health",
+			limiter:        &stubLimiter{},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+		{
+			name:           "Success Case - Limiter Unavailable",
+			url:            "/foos/1",
+			limiter:        &stubLimiter{err: errors.New("connection refused")},
+			expectedStatus: http.StatusOK,
+			expectedKeys:   []string{"GET /foos/:id|ip:192.0.2.1"},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit": "",
+			},
+		},
+		{
+			name: "Failure Case - Too Many Requests",
+			url:  "/foos/1",
+			limiter: &stubLimiter{result: model.RateLimitResult{
+				Allowed: false, Limit: 10, Remaining: 0, RetryAfter: 5500 * time.Millisecond, ResetAfter: time.Minute,
+			}},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedKeys:   []string{"GET /foos/:id|ip:192.0.2.1"},
+			expectedHeaders: map[string]string{
+				"RateLimit-Limit":     "10",
+				"RateLimit-Remaining": "0",
+				"RateLimit-Reset":     "60",
+				"RateLimit-Policy":    "10;w=60",
+				"Retry-After":         "6",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if testCase.claims != nil {
+					c.Set("claims", testCase.claims)
+				}
+			})
+			router.Use(RateLimitMiddleware(testCase.limiter, policy))
+			handler := func(c *gin.Context) { c.Status(http.StatusOK) }
+			router.GET("/health", handler)
+			router.GET("/foos/:id", handler)
+
+			req := httptest.NewRequest(http.MethodGet, testCase.url, nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, testCase.expectedStatus, rec.Code)
+			assert.Equal(t, testCase.expectedKeys, testCase.limiter.keys)
+			for key, value := range testCase.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(key), key)
+			}
+		})
+	}
+}
+
+func TestProcedureRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+	policy := ratelimit.NewPolicy(ratelimit.Config{Enabled: true, Limit: 10, Period: time.Minute})
+
+	testCases := []struct {
+		name        string
+		path        string
+		expectedKey string
+	}{
+		{
+			name:        "Success Case - Known Procedure",
+			path:        "/proto.FooService/Create",
+			expectedKey: "/proto.FooService/Create|ip:192.0.2.1",
+		},
+		{
+			name:        "Success Case - Unknown Procedure Limited By Route",
+			path:        "/proto.FooService/Unknown",
+			expectedKey: "POST /proto.FooService/*procedure|ip:192.0.2.1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			limiter := &stubLimiter{result: model.RateLimitResult{Allowed: true, Limit: 10, Remaining: 9}}
+			router := gin.New()
+			router.POST("/proto.FooService/*procedure",
+				ProcedureRateLimitMiddleware(limiter, policy, "/proto.FooService/Create", "/proto.FooService/Get"),
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			)
+
+			req := httptest.NewRequest(http.MethodPost, testCase.path, nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, []string{testCase.expectedKey}, limiter.keys)
+		})
+	}
+}
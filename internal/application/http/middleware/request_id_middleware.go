@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// RequestIDMiddleware accepts the X-Request-ID header sent by the client or generates a new one, echoes it in the response
+// and stores it in the request context along with a logger enriched with the request id and trace id.
+func RequestIDMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(correlation.HeaderRequestID)
+		if !correlation.ValidRequestID(id) {
+			id = correlation.NewRequestID()
+		}
+
+		c.Header(correlation.HeaderRequestID, id)
+		c.Request = c.Request.WithContext(correlation.Start(c.Request.Context(), logger, id))
+
+		c.Next()
+	}
+}
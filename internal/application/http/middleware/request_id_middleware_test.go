@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name       string
+		requestID  string
+		propagated bool
+	}{
+		{
+			name:       "Success Case - Propagated",
+			requestID:  "req-42",
+			propagated: true,
+		},
+		{
+			name:      "Success Case - Generated When Missing",
+			requestID: "",
+		},
+		{
+			name:      "Success Case - Generated When Invalid Characters",
+			requestID: "req 42",
+		},
+		{
+			name:      "Success Case - Generated When Too Long",
+			requestID: strings.Repeat("a", 1024),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			gin.SetMode(gin.TestMode)
+			var contextID string
+			router := gin.New()
+			router.Use(RequestIDMiddleware(zap.NewNop()))
+			router.GET("/foos", func(c *gin.Context) {
+				contextID = correlation.RequestID(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/foos", nil)
+			if testCase.requestID != "" {
+				req.Header.Set(correlation.HeaderRequestID, testCase.requestID)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(correlation.HeaderRequestID)
+			assert.True(t, correlation.ValidRequestID(id))
+			assert.Equal(t, id, contextID)
+			if testCase.propagated {
+				assert.Equal(t, testCase.requestID, id)
+			} else {
+				assert.NotEqual(t, testCase.requestID, id)
+			}
+		})
+	}
+}
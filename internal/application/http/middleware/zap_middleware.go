@@ -3,6 +3,8 @@ package middleware
 import (
 	"time"
 
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -13,7 +15,7 @@ func ZapLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
 		c.Next()
-		logger.Info("HTTP request",
+		correlation.Logger(c.Request.Context(), logger).Info("HTTP request",
 			zap.Int("status", c.Writer.Status()),
 			zap.String("method", c.Request.Method),
 			zap.String("path", c.Request.URL.Path),
@@ -26,7 +28,7 @@ func ZapLoggerMiddleware(logger *zap.Logger) gin.HandlerFunc {
 // ZapRecoveryMiddleware provides a middleware for recovering from panics, logs the error, and returns a 500 status.
 func ZapRecoveryMiddleware(logger *zap.Logger) gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, err any) {
-		logger.Error("panic recovered",
+		correlation.Logger(c.Request.Context(), logger).Error("panic recovered",
 			zap.Any("error", err),
 			zap.String("path", c.Request.URL.Path),
 		)
@@ -0,0 +1,358 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/http/dto"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+var _ IWebhookController = (*WebhookController)(nil)
+
+// IWebhookController defines an interface for managing webhook subscriptions through HTTP handlers.
+// GetAll retrieves all subscriptions.
+// GetByID retrieves a subscription by its unique identifier.
+// Create registers a new subscription.
+// Update modifies an existing subscription.
+// DeleteByID deletes a subscription by its unique identifier.
+// GetDeliveries retrieves the delivery log of a subscription.
+type IWebhookController interface {
+	GetAll(ctx *gin.Context)
+	GetByID(ctx *gin.Context)
+	Create(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	DeleteByID(ctx *gin.Context)
+	GetDeliveries(ctx *gin.Context)
+}
+
+// WebhookController manages the HTTP request handling for operations related to webhook subscriptions.
+type WebhookController struct {
+	svc service.IWebhookService
+}
+
+// GetAll @Summary Get all webhooks
+// @Description Get all webhook subscriptions, without their secret
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param offset query int false "Offset"
+// @Param limit query int false "Limit"
+// @Success 200 {array} dto.WebhookReadResponse
+// @Router /webhooks [get]
+func (c *WebhookController) GetAll(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.GetAll")
+	defer span.End()
+
+	var queryParams dto.ListRequest
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate query params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate query params"})
+		return
+	}
+
+	subscriptions, err := c.svc.GetAll(spanCtx, data.PaginationOffset{
+		Offset: queryParams.Offset,
+		Limit:  queryParams.Limit,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get all webhooks")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get all webhooks"})
+		return
+	}
+
+	results := make([]*dto.WebhookReadResponse, len(subscriptions))
+	for i, subscription := range subscriptions {
+		results[i] = dto.NewWebhookReadResponse(subscription)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("response.count", len(results)))
+	ctx.JSON(http.StatusOK, results)
+}
+
+// GetByID @Summary Get webhook by id
+// @Description Get a webhook subscription by id, without its secret
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path uuid true "Webhook id"
+// @Success 200 {object} dto.WebhookReadResponse
+// @Router /webhooks/{id} [get]
+func (c *WebhookController) GetByID(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.GetByID")
+	defer span.End()
+
+	var pathParams dto.WebhookReadRequest
+	id, ok := bindWebhookID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	subscription, err := c.svc.GetByID(spanCtx, id)
+	if err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "webhook not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to get webhook by id")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get webhook by id"})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.JSON(http.StatusOK, dto.NewWebhookReadResponse(subscription))
+}
+
+// Create @Summary Create a new webhook
+// @Description Subscribe an endpoint to event types. The secret signing the deliveries is generated when omitted and only returned by this call.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param webhook body dto.WebhookCreateBody true "Webhook"
+// @Success 201 {object} dto.WebhookCreateResponse
+// @Router /webhooks [post]
+func (c *WebhookController) Create(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.Create")
+	defer span.End()
+
+	var input dto.WebhookCreateBody
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate request body")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate request body"})
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String("webhook.url", input.Url),
+		attribute.StringSlice("webhook.event_types", input.EventTypes),
+	)
+
+	subscription, err := c.svc.Create(spanCtx, data.WebhookCreateInput{
+		Url:        input.Url,
+		EventTypes: input.EventTypes,
+		Secret:     input.Secret,
+	})
+	if err != nil {
+		span.RecordError(err)
+		if isInvalidWebhook(err) {
+			span.SetStatus(codes.Error, "invalid webhook")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to create webhook")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create webhook"})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.String("webhook.id", subscription.Id.String()))
+	ctx.JSON(http.StatusCreated, &dto.WebhookCreateResponse{
+		Id:     subscription.Id,
+		Secret: subscription.Secret,
+	})
+}
+
+// Update @Summary Update a webhook
+// @Description Update a webhook subscription. Enabling a disabled subscription resets its consecutive failures; the secret is kept when omitted.
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path uuid true "Webhook id"
+// @Param webhook body dto.WebhookUpdateBody true "Webhook"
+// @Success 204
+// @Router /webhooks/{id} [put]
+func (c *WebhookController) Update(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.Update")
+	defer span.End()
+
+	var pathParams dto.WebhookUpdateRequest
+	id, ok := bindWebhookID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	var body dto.WebhookUpdateBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate request body")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate request body"})
+		return
+	}
+
+	input := data.WebhookUpdateInput{
+		Id:         id,
+		Url:        body.Url,
+		EventTypes: body.EventTypes,
+		Enabled:    *body.Enabled,
+	}
+	if body.Secret != nil {
+		input.Secret.Set = true
+		input.Secret.Value = *body.Secret
+	}
+
+	if _, err := c.svc.Update(spanCtx, input); err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.As(err, &port.ErrorNotFound):
+			span.SetStatus(codes.Error, "webhook not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+		case isInvalidWebhook(err):
+			span.SetStatus(codes.Error, "invalid webhook")
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid webhook"})
+		default:
+			span.SetStatus(codes.Error, "failed to update webhook")
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update webhook"})
+		}
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteByID @Summary Delete a webhook
+// @Description Delete a webhook subscription along with its deliveries
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path uuid true "Webhook id"
+// @Success 204
+// @Router /webhooks/{id} [delete]
+func (c *WebhookController) DeleteByID(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.DeleteByID")
+	defer span.End()
+
+	var pathParams dto.WebhookDeleteRequest
+	id, ok := bindWebhookID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	if err := c.svc.DeleteByID(spanCtx, id); err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "webhook not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to delete webhook")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete webhook"})
+		return
+	}
+
+	span.SetStatus(codes.Ok, "")
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetDeliveries @Summary Get the deliveries of a webhook
+// @Description Get the delivery log of a webhook subscription, most recent first
+// @Tags Webhook
+// @Accept json
+// @Produce json
+// @Param id path uuid true "Webhook id"
+// @Param offset query int false "Offset"
+// @Param limit query int false "Limit"
+// @Success 200 {array} dto.WebhookDeliveryResponse
+// @Router /webhooks/{id}/deliveries [get]
+func (c *WebhookController) GetDeliveries(ctx *gin.Context) {
+	tracer := otel.Tracer("WebhookController")
+	spanCtx, span := tracer.Start(ctx.Request.Context(), "WebhookController.GetDeliveries")
+	defer span.End()
+
+	var pathParams dto.WebhookDeliveryListRequest
+	id, ok := bindWebhookID(ctx, span, &pathParams, &pathParams.Id)
+	if !ok {
+		return
+	}
+
+	var queryParams dto.ListRequest
+	if err := ctx.ShouldBindQuery(&queryParams); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate query params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate query params"})
+		return
+	}
+
+	deliveries, err := c.svc.GetDeliveries(spanCtx, data.WebhookDeliveryListInput{
+		SubscriptionId: id,
+		Offset:         queryParams.Offset,
+		Limit:          queryParams.Limit,
+	})
+	if err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "webhook not found")
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "webhook not found"})
+			return
+		}
+		span.SetStatus(codes.Error, "failed to get webhook deliveries")
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get webhook deliveries"})
+		return
+	}
+
+	results := make([]*dto.WebhookDeliveryResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		results[i] = dto.NewWebhookDeliveryResponse(delivery)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("response.count", len(results)))
+	ctx.JSON(http.StatusOK, results)
+}
+
+// bindWebhookID binds the path params into params and parses the id they hold. On failure, it answers the request
+// and returns false.
+func bindWebhookID(ctx *gin.Context, span trace.Span, params any, rawID *string) (uuid.UUID, bool) {
+	if err := ctx.ShouldBindUri(params); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to validate path params")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to validate path params"})
+		return uuid.Nil, false
+	}
+
+	id, err := uuid.Parse(*rawID)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to parse id to uuid")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse id to uuid"})
+		return uuid.Nil, false
+	}
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+	return id, true
+}
+
+// isInvalidWebhook reports whether the service rejected the subscription, such as for an unknown event type.
+func isInvalidWebhook(err error) bool {
+	var validationErrors validator.ValidationErrors
+	return errors.As(err, &validationErrors) || errors.As(err, &port.ErrorInvalidReference)
+}
+
+// NewWebhookController initializes a new WebhookController with the provided IWebhookService dependency.
+func NewWebhookController(svc service.IWebhookService) *WebhookController {
+	c := &WebhookController{
+		svc: svc,
+	}
+
+	return c
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	data2 "github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWebhookController(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("30000000-0000-0000-0000-000000000001")
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	subscription := &model.WebhookSubscription{
+		Id:         id,
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{"com.astigo.foo.created"},
+		Secret:     "whsec_0123456789abcdef",
+		Enabled:    true,
+		CreatedAt:  createdAt,
+	}
+
+	testCases := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		statusCode   int
+		bodyResponse string
+
+		setupMockHandler func(*service.MockWebhookService)
+	}{
+		{
+			name:         "Success Case - Create",
+			method:       http.MethodPost,
+			url:          "/webhooks",
+			body:         `{"url":"https://partner.example.com/hooks","event_types":["com.astigo.foo.created"]}`,
+			statusCode:   http.StatusCreated,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","secret":"whsec_0123456789abcdef"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("Create", mock.Anything, data2.WebhookCreateInput{
+					Url:        "https://partner.example.com/hooks",
+					EventTypes: []string{"com.astigo.foo.created"},
+				}).Return(subscription, nil)
+			},
+		},
+		{
+			name:             "Failure Case - Create Invalid Body",
+			method:           http.MethodPost,
+			url:              "/webhooks",
+			body:             `{"url":"partner","event_types":[]}`,
+			statusCode:       http.StatusBadRequest,
+			bodyResponse:     `{"error":"failed to validate request body"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {},
+		},
+		{
+			name:         "Failure Case - Create Unknown Event Type",
+			method:       http.MethodPost,
+			url:          "/webhooks",
+			body:         `{"url":"https://partner.example.com/hooks","event_types":["com.astigo.bar.created"]}`,
+			statusCode:   http.StatusBadRequest,
+			bodyResponse: `{"error":"invalid webhook"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("Create", mock.Anything, mock.Anything).Return(
+					(*model.WebhookSubscription)(nil),
+					port.NewErrInvalidReference("webhook", "event_type", "com.astigo.bar.created"),
+				)
+			},
+		},
+		{
+			name:       "Success Case - Get By Id Without Secret",
+			method:     http.MethodGet,
+			url:        "/webhooks/30000000-0000-0000-0000-000000000001",
+			statusCode: http.StatusOK,
+			bodyResponse: `{"id":"30000000-0000-0000-0000-000000000001","url":"https://partner.example.com/hooks",
+				"event_types":["com.astigo.foo.created"],"enabled":true,"consecutive_failures":0,"created_at":"2025-01-02T03:04:05Z"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return(subscription, nil)
+			},
+		},
+		{
+			name:         "Failure Case - Get By Id Not Found",
+			method:       http.MethodGet,
+			url:          "/webhooks/30000000-0000-0000-0000-000000000001",
+			statusCode:   http.StatusNotFound,
+			bodyResponse: `{"error":"webhook not found"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("GetByID", mock.Anything, id).Return((*model.WebhookSubscription)(nil), port.NewErrNotFound("webhook", "id", id.String()))
+			},
+		},
+		{
+			name:         "Success Case - Update",
+			method:       http.MethodPut,
+			url:          "/webhooks/30000000-0000-0000-0000-000000000001",
+			body:         `{"url":"https://partner.example.com/hooks","event_types":["com.astigo.foo.created"],"enabled":true,"secret":"fedcba9876543210"}`,
+			statusCode:   http.StatusNoContent,
+			bodyResponse: ``,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("Update", mock.Anything, data2.WebhookUpdateInput{
+					Id:         id,
+					Url:        "https://partner.example.com/hooks",
+					EventTypes: []string{"com.astigo.foo.created"},
+					Secret:     data2.Optional[string]{Value: "fedcba9876543210", Set: true},
+					Enabled:    true,
+				}).Return(subscription, nil)
+			},
+		},
+		{
+			name:             "Failure Case - Update Missing Enabled",
+			method:           http.MethodPut,
+			url:              "/webhooks/30000000-0000-0000-0000-000000000001",
+			body:             `{"url":"https://partner.example.com/hooks","event_types":["com.astigo.foo.created"]}`,
+			statusCode:       http.StatusBadRequest,
+			bodyResponse:     `{"error":"failed to validate request body"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {},
+		},
+		{
+			name:         "Failure Case - Delete Not Found",
+			method:       http.MethodDelete,
+			url:          "/webhooks/30000000-0000-0000-0000-000000000001",
+			statusCode:   http.StatusNotFound,
+			bodyResponse: `{"error":"webhook not found"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("DeleteByID", mock.Anything, id).Return(port.NewErrNotFound("webhook", "id", id.String()))
+			},
+		},
+		{
+			name:       "Success Case - Get Deliveries",
+			method:     http.MethodGet,
+			url:        "/webhooks/30000000-0000-0000-0000-000000000001/deliveries?limit=5",
+			statusCode: http.StatusOK,
+			bodyResponse: `[{"id":"40000000-0000-0000-0000-000000000001","event_id":"foo.created:1","event_type":"com.astigo.foo.created",
+				"status":"pending","attempts":1,"status_code":500,"error":"unexpected status code 500","duration_ms":150,
+				"next_attempt_at":"2025-01-02T03:05:05Z","created_at":"2025-01-02T03:04:05Z"}]`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("GetDeliveries", mock.Anything, data2.WebhookDeliveryListInput{SubscriptionId: id, Offset: 0, Limit: 5}).Return([]*model.WebhookDelivery{
+					{
+						Id:            uuid.MustParse("40000000-0000-0000-0000-000000000001"),
+						EventId:       "foo.created:1",
+						EventType:     "com.astigo.foo.created",
+						Status:        model.WebhookDeliveryPending,
+						Attempts:      1,
+						StatusCode:    500,
+						Error:         "unexpected status code 500",
+						Duration:      150 * time.Millisecond,
+						NextAttemptAt: createdAt.Add(time.Minute),
+						CreatedAt:     createdAt,
+					},
+				}, nil)
+			},
+		},
+		{
+			name:         "Failure Case - Get Deliveries Service Error",
+			method:       http.MethodGet,
+			url:          "/webhooks/30000000-0000-0000-0000-000000000001/deliveries",
+			statusCode:   http.StatusInternalServerError,
+			bodyResponse: `{"error":"failed to get webhook deliveries"}`,
+			setupMockHandler: func(mockHandler *service.MockWebhookService) {
+				mockHandler.On("GetDeliveries", mock.Anything, mock.Anything).Return(([]*model.WebhookDelivery)(nil), errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockHandler := new(service.MockWebhookService)
+			controller := NewWebhookController(mockHandler)
+
+			testCase.setupMockHandler(mockHandler)
+
+			req, err := http.NewRequest(testCase.method, testCase.url, strings.NewReader(testCase.body))
+			assert.NoError(t, err)
+			w := httptest.NewRecorder()
+
+			gin.SetMode(gin.TestMode)
+			router := gin.Default()
+			router.GET("/webhooks/:id", controller.GetByID)
+			router.POST("/webhooks", controller.Create)
+			router.PUT("/webhooks/:id", controller.Update)
+			router.DELETE("/webhooks/:id", controller.DeleteByID)
+			router.GET("/webhooks/:id/deliveries", controller.GetDeliveries)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, testCase.statusCode, w.Code)
+			if testCase.bodyResponse != "" {
+				assert.JSONEq(t, testCase.bodyResponse, w.Body.String())
+			}
+			mockHandler.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package httpcache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	ConditionalRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_conditional_responses_total",
+			Help: "Total number of cacheable responses, by whether the client copy was still valid",
+		},
+		[]string{"route", "result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(ConditionalRequests)
+}
+
+// Observe records whether a cacheable response was sent in full or answered with 304 Not Modified.
+func Observe(route string, notModified bool) {
+	result := "full"
+	if notModified {
+		result = "not_modified"
+	}
+	ConditionalRequests.WithLabelValues(route, result).Inc()
+}
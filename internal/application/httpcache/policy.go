@@ -0,0 +1,125 @@
+package httpcache
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds the Cache-Control policy of each cacheable route, identified by "<METHOD> <path>" using the Gin route
+// pattern (e.g. "GET /foos/:id"). Routes without a policy send no Cache-Control header.
+type Config struct {
+	Enabled bool    `mapstructure:"enabled"`
+	Routes  []Route `mapstructure:"routes"`
+}
+
+// Route defines how long the successful responses of a route may be reused. Private responses are only stored by the
+// client; public ones may also be stored by shared caches such as CDNs, for SharedMaxAge when it is set.
+// A zero MaxAge lets caches store the response but requires them to revalidate it before each reuse.
+type Route struct {
+	Route                string        `mapstructure:"route"`
+	MaxAge               time.Duration `mapstructure:"max_age"`
+	SharedMaxAge         time.Duration `mapstructure:"shared_max_age"`
+	StaleWhileRevalidate time.Duration `mapstructure:"stale_while_revalidate"`
+	Private              bool          `mapstructure:"private"`
+	NoStore              bool          `mapstructure:"no_store"`
+}
+
+// Policy resolves the Cache-Control header of a route.
+type Policy struct {
+	enabled bool
+	routes  map[string]Route
+}
+
+// CacheControl returns the Cache-Control header of the route. Responses to authenticated requests are always private,
+// so that a shared cache never serves them to another caller. The boolean is false when the route has no policy.
+func (p *Policy) CacheControl(route string, authenticated bool) (string, bool) {
+	if !p.enabled {
+		return "", false
+	}
+
+	policy, ok := p.routes[route]
+	if !ok {
+		return "", false
+	}
+	if policy.NoStore {
+		return "no-store", true
+	}
+
+	private := policy.Private || authenticated
+	directives := []string{"public"}
+	if private {
+		directives[0] = "private"
+	}
+	if policy.MaxAge > 0 {
+		directives = append(directives, "max-age="+seconds(policy.MaxAge))
+	} else {
+		directives = append(directives, "no-cache")
+	}
+	if !private && policy.SharedMaxAge > 0 {
+		directives = append(directives, "s-maxage="+seconds(policy.SharedMaxAge))
+	}
+	if policy.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+seconds(policy.StaleWhileRevalidate))
+	}
+
+	return strings.Join(directives, ", "), true
+}
+
+func NewPolicy(config Config) *Policy {
+	policy := &Policy{
+		enabled: config.Enabled,
+		routes:  make(map[string]Route, len(config.Routes)),
+	}
+	for _, route := range config.Routes {
+		policy.routes[route.Route] = route
+	}
+
+	return policy
+}
+
+// ETag returns a strong entity tag of a response body: two responses share it only if their bodies are identical.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified evaluates the If-None-Match and If-Modified-Since preconditions of a GET request against the current
+// validators of the resource, following RFC 9110: If-Modified-Since is ignored when If-None-Match is present.
+// A zero lastModified never satisfies If-Modified-Since.
+func NotModified(header http.Header, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := header.Get("If-None-Match"); ifNoneMatch != "" {
+		return matchETag(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// HTTP dates have a one second precision.
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// matchETag reports whether one of the entity tags listed in an If-None-Match header matches etag, using the weak
+// comparison required for this header.
+func matchETag(list, etag string) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d.Seconds()), 10)
+}
@@ -0,0 +1,169 @@
+package httpcache
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_CacheControl(t *testing.T) {
+	t.Parallel()
+	config := Config{
+		Enabled: true,
+		Routes: []Route{
+			{Route: "GET /foos/:id", MaxAge: 30 * time.Second, SharedMaxAge: time.Minute, StaleWhileRevalidate: 30 * time.Second},
+			{Route: "GET /foos", MaxAge: 0},
+			{Route: "GET /private", MaxAge: time.Minute, Private: true},
+			{Route: "GET /secret", NoStore: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		config        Config
+		route         string
+		authenticated bool
+		expected      string
+		expectedOk    bool
+	}{
+		{
+			name:       "Success Case - Public",
+			config:     config,
+			route:      "GET /foos/:id",
+			expected:   "public, max-age=30, s-maxage=60, stale-while-revalidate=30",
+			expectedOk: true,
+		},
+		{
+			name:          "Success Case - Authenticated Request",
+			config:        config,
+			route:         "GET /foos/:id",
+			authenticated: true,
+			expected:      "private, max-age=30, stale-while-revalidate=30",
+			expectedOk:    true,
+		},
+		{
+			name:       "Success Case - Revalidate",
+			config:     config,
+			route:      "GET /foos",
+			expected:   "public, no-cache",
+			expectedOk: true,
+		},
+		{
+			name:       "Success Case - Private Route",
+			config:     config,
+			route:      "GET /private",
+			expected:   "private, max-age=60",
+			expectedOk: true,
+		},
+		{
+			name:       "Success Case - No Store",
+			config:     config,
+			route:      "GET /secret",
+			expected:   "no-store",
+			expectedOk: true,
+		},
+		{
+			name:   "Success Case - Route Without Policy",
+			config: config,
+			route:  "GET /health/liveness",
+		},
+		{
+			name:   "Success Case - Disabled",
+			config: Config{Enabled: false, Routes: config.Routes},
+			route:  "GET /foos/:id",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			policy := NewPolicy(testCase.config)
+
+			result, ok := policy.CacheControl(testCase.route, testCase.authenticated)
+
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.Equal(t, testCase.expected, result)
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	t.Parallel()
+	etag := ETag([]byte(`{"id":"20000000-0000-0000-0000-000000000001"}`))
+	lastModified := time.Date(2025, 1, 1, 12, 0, 0, 500, time.UTC)
+
+	testCases := []struct {
+		name         string
+		header       http.Header
+		lastModified time.Time
+		expected     bool
+	}{
+		{
+			name:         "Success Case - No Precondition",
+			header:       http.Header{},
+			lastModified: lastModified,
+		},
+		{
+			name:         "Success Case - Matching ETag",
+			header:       http.Header{"If-None-Match": {`"other", ` + etag}},
+			lastModified: lastModified,
+			expected:     true,
+		},
+		{
+			name:         "Success Case - Weak Matching ETag",
+			header:       http.Header{"If-None-Match": {"W/" + etag}},
+			lastModified: lastModified,
+			expected:     true,
+		},
+		{
+			name:         "Success Case - Wildcard",
+			header:       http.Header{"If-None-Match": {"*"}},
+			lastModified: lastModified,
+			expected:     true,
+		},
+		{
+			name: "Success Case - ETag Takes Precedence",
+			header: http.Header{
+				"If-None-Match":     {`"other"`},
+				"If-Modified-Since": {lastModified.Format(http.TimeFormat)},
+			},
+			lastModified: lastModified,
+		},
+		{
+			name:         "Success Case - Not Modified Since",
+			header:       http.Header{"If-Modified-Since": {lastModified.Format(http.TimeFormat)}},
+			lastModified: lastModified,
+			expected:     true,
+		},
+		{
+			name:         "Success Case - Modified Since",
+			header:       http.Header{"If-Modified-Since": {lastModified.Add(-time.Second).Format(http.TimeFormat)}},
+			lastModified: lastModified,
+		},
+		{
+			name:   "Success Case - Unknown Last Modified",
+			header: http.Header{"If-Modified-Since": {lastModified.Format(http.TimeFormat)}},
+		},
+		{
+			name:         "Failure Case - Invalid Date",
+			header:       http.Header{"If-Modified-Since": {"yesterday"}},
+			lastModified: lastModified,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, NotModified(testCase.header, etag, testCase.lastModified))
+		})
+	}
+}
+
+func TestETag(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, ETag([]byte("foo")), ETag([]byte("foo")))
+	assert.NotEqual(t, ETag([]byte("foo")), ETag([]byte("bar")))
+	assert.Regexp(t, `^"[A-Za-z0-9_-]+"$`, ETag([]byte("foo")))
+}
@@ -0,0 +1,26 @@
+package ratelimit
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	Requests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ratelimit_requests_total",
+			Help: "Total number of requests checked against a rate limit",
+		},
+		[]string{"route", "result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(Requests)
+}
+
+// Observe records the decision taken for a request on a route.
+func Observe(route string, allowed bool) {
+	result := "allowed"
+	if !allowed {
+		result = "limited"
+	}
+	Requests.WithLabelValues(route, result).Inc()
+}
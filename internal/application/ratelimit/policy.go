@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+)
+
+const defaultPeriod = time.Minute
+
+// Config holds the default limit applied to every route and the per-route overrides.
+// HTTP routes are identified by "<METHOD> <path>" using the Gin route pattern (e.g. "POST /foos")
+// and gRPC methods, also served over Connect, by their full name (e.g. "/proto.FooService/Create").
+type Config struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Limit   int           `mapstructure:"limit"`
+	Period  time.Duration `mapstructure:"period"`
+	Burst   int           `mapstructure:"burst"`
+	Routes  []Route       `mapstructure:"routes"`
+}
+
+// Route overrides the default limit for a single route. A limit lower than or equal to zero disables rate limiting for the route.
+type Route struct {
+	Route  string        `mapstructure:"route"`
+	Limit  int           `mapstructure:"limit"`
+	Period time.Duration `mapstructure:"period"`
+	Burst  int           `mapstructure:"burst"`
+}
+
+// Policy resolves the limit that applies to a route.
+type Policy struct {
+	enabled bool
+	def     model.RateLimit
+	routes  map[string]model.RateLimit
+}
+
+// Limit returns the limit of the route. The boolean is false when the route is not rate limited.
+func (p *Policy) Limit(route string) (model.RateLimit, bool) {
+	if !p.enabled {
+		return model.RateLimit{}, false
+	}
+
+	limit, ok := p.routes[route]
+	if !ok {
+		limit = p.def
+	}
+
+	return limit, limit.Limit > 0
+}
+
+func NewPolicy(config Config) *Policy {
+	policy := &Policy{
+		enabled: config.Enabled,
+		def:     newRateLimit(config.Limit, config.Period, config.Burst),
+		routes:  make(map[string]model.RateLimit, len(config.Routes)),
+	}
+	for _, route := range config.Routes {
+		policy.routes[route.Route] = newRateLimit(route.Limit, route.Period, route.Burst)
+	}
+
+	return policy
+}
+
+func newRateLimit(limit int, period time.Duration, burst int) model.RateLimit {
+	if period <= 0 {
+		period = defaultPeriod
+	}
+
+	return model.RateLimit{
+		Limit:  limit,
+		Period: period,
+		Burst:  burst,
+	}
+}
+
+// Key builds the limiter key of an identity on a route: each route has its own bucket.
+func Key(route, identity string) string {
+	return route + "|" + identity
+}
+
+// SubjectIdentity identifies an authenticated caller by the subject claim of its token.
+func SubjectIdentity(subject string) string {
+	return "sub:" + subject
+}
+
+// IPIdentity identifies an anonymous caller by its IP address.
+func IPIdentity(ip string) string {
+	return "ip:" + ip
+}
+
+// Headers returns the RateLimit-* headers describing the result, plus Retry-After when the request is rejected.
+// Durations are rounded up to the second as expected by clients.
+func Headers(limit model.RateLimit, result *model.RateLimitResult) map[string]string {
+	headers := map[string]string{
+		"RateLimit-Limit":     strconv.Itoa(result.Limit),
+		"RateLimit-Remaining": strconv.Itoa(result.Remaining),
+		"RateLimit-Reset":     seconds(result.ResetAfter),
+		"RateLimit-Policy":    fmt.Sprintf("%d;w=%s", limit.Limit, seconds(limit.Period)),
+	}
+	if !result.Allowed {
+		headers["Retry-After"] = seconds(result.RetryAfter)
+	}
+
+	return headers
+}
+
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
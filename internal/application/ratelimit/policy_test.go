@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_Limit(t *testing.T) {
+	t.Parallel()
+	config := Config{
+		Enabled: true,
+		Limit:   100,
+		Period:  time.Minute,
+		Routes: []Route{
+			{Route: "POST /foos", Limit: 10, Period: time.Second, Burst: 2},
+			{Route: "GET /foos", Limit: 0},
+			{Route: "/proto.FooService/Create", Limit: 5},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		config        Config
+		route         string
+		expectedLimit model.RateLimit
+		expectedOk    bool
+	}{
+		{
+			name:          "Success Case - Default",
+			config:        config,
+			route:         "DELETE /foos/:id",
+			expectedLimit: model.RateLimit{Limit: 100, Period: time.Minute},
+			expectedOk:    true,
+		},
+		{
+			name:          "Success Case - Route override",
+			config:        config,
+			route:         "POST /foos",
+			expectedLimit: model.RateLimit{Limit: 10, Period: time.Second, Burst: 2},
+			expectedOk:    true,
+		},
+		{
+			name:          "Success Case - Default period",
+			config:        config,
+			route:         "/proto.FooService/Create",
+			expectedLimit: model.RateLimit{Limit: 5, Period: time.Minute},
+			expectedOk:    true,
+		},
+		{
+			name:          "Success Case - Route disabled",
+			config:        config,
+			route:         "GET /foos",
+			expectedLimit: model.RateLimit{Period: time.Minute},
+			expectedOk:    false,
+		},
+		{
+			name:          "Success Case - Rate limiting disabled",
+			config:        Config{Enabled: false, Limit: 100},
+			route:         "POST /foos",
+			expectedLimit: model.RateLimit{},
+			expectedOk:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			limit, ok := NewPolicy(testCase.config).Limit(testCase.route)
+
+			assert.Equal(t, testCase.expectedOk, ok)
+			assert.Equal(t, testCase.expectedLimit, limit)
+		})
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	t.Parallel()
+	limit := model.RateLimit{Limit: 100, Period: time.Minute}
+
+	headers := Headers(limit, &model.RateLimitResult{
+		Allowed:    false,
+		Limit:      100,
+		Remaining:  0,
+		RetryAfter: 300 * time.Millisecond,
+		ResetAfter: 59*time.Second + 400*time.Millisecond,
+	})
+
+	assert.Equal(t, map[string]string{
+		"RateLimit-Limit":     "100",
+		"RateLimit-Remaining": "0",
+		"RateLimit-Reset":     "60",
+		"RateLimit-Policy":    "100;w=60",
+		"Retry-After":         "1",
+	}, headers)
+}
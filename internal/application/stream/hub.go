@@ -0,0 +1,214 @@
+package stream
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Config holds the settings of the in-process event hub used to push Foo changes to browser clients.
+// AllowedOrigins lists the origins, besides the API's own, whose pages may open a WebSocket; "*" allows any origin.
+type Config struct {
+	HistorySize       int           `mapstructure:"history_size"`
+	ClientBufferSize  int           `mapstructure:"client_buffer_size"`
+	HeartbeatInterval time.Duration `mapstructure:"heartbeat_interval"`
+	AllowedOrigins    []string      `mapstructure:"allowed_origins"`
+}
+
+// Event represents a single change notification delivered to stream clients.
+// ID is assigned by the hub and increases monotonically, which allows clients to resume with Last-Event-ID.
+type Event struct {
+	ID    uint64
+	Type  string
+	FooID uuid.UUID
+	Data  []byte
+}
+
+// Filter restricts the events delivered to a subscription. Empty sets match everything.
+type Filter struct {
+	Types map[string]struct{}
+	IDs   map[uuid.UUID]struct{}
+}
+
+// NewFilter builds a Filter from the event types and Foo ids requested by a client.
+func NewFilter(types []string, ids []uuid.UUID) Filter {
+	filter := Filter{
+		Types: make(map[string]struct{}, len(types)),
+		IDs:   make(map[uuid.UUID]struct{}, len(ids)),
+	}
+	for _, t := range types {
+		filter.Types[t] = struct{}{}
+	}
+	for _, id := range ids {
+		filter.IDs[id] = struct{}{}
+	}
+
+	return filter
+}
+
+// Match reports whether the event satisfies the filter.
+func (f Filter) Match(event Event) bool {
+	if len(f.Types) > 0 {
+		if _, ok := f.Types[event.Type]; !ok {
+			return false
+		}
+	}
+	if len(f.IDs) > 0 {
+		if _, ok := f.IDs[event.FooID]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Subscription is a client registration on the hub. Events are received on C, which is closed
+// when the subscription is closed, when the hub shuts down or when the client is too slow to keep up.
+type Subscription struct {
+	C <-chan Event
+
+	ch     chan Event
+	filter Filter
+	hub    *Hub
+	once   sync.Once
+}
+
+// Close detaches the subscription from the hub and closes its channel.
+func (s *Subscription) Close() {
+	s.hub.remove(s)
+}
+
+func (s *Subscription) close() {
+	s.once.Do(func() {
+		close(s.ch)
+	})
+}
+
+// Hub fans out events to every connected subscription and keeps a bounded history for resumption.
+// Event ids are local to the process: a client resuming against another replica only receives new events.
+type Hub struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []Event
+	historySize int
+	bufferSize  int
+	subscribers map[*Subscription]struct{}
+	closed      bool
+}
+
+// Publish assigns an id to a new event of the given type about the given Foo, stores it in the history and delivers it
+// to matching subscribers. The data is sent as is to the clients, so it must be the Foo payload rather than an envelope.
+func (h *Hub) Publish(eventType string, fooID uuid.UUID, data []byte) Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastID++
+	event := Event{
+		ID:    h.lastID,
+		Type:  eventType,
+		FooID: fooID,
+		Data:  data,
+	}
+
+	if h.closed {
+		return event
+	}
+
+	if h.historySize > 0 {
+		if len(h.history) >= h.historySize {
+			h.history = h.history[1:]
+		}
+		h.history = append(h.history, event)
+	}
+
+	for sub := range h.subscribers {
+		if !sub.filter.Match(event) {
+			continue
+		}
+
+		select {
+		case sub.ch <- event:
+		default:
+			// The client does not drain its buffer: drop it so it reconnects with Last-Event-ID.
+			delete(h.subscribers, sub)
+			sub.close()
+		}
+	}
+
+	return event
+}
+
+// Subscribe registers a new subscription with the given filter. When lastEventID is not zero,
+// the retained events published after it and matching the filter are returned for replay.
+func (h *Hub) Subscribe(filter Filter, lastEventID uint64) (*Subscription, []Event) {
+	ch := make(chan Event, h.bufferSize)
+	sub := &Subscription{
+		C:      ch,
+		ch:     ch,
+		filter: filter,
+		hub:    h,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		sub.close()
+		return sub, nil
+	}
+
+	var replay []Event
+	if lastEventID != 0 && lastEventID <= h.lastID {
+		for _, event := range h.history {
+			if event.ID > lastEventID && filter.Match(event) {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	h.subscribers[sub] = struct{}{}
+
+	return sub, replay
+}
+
+// Len returns the number of active subscriptions.
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscribers)
+}
+
+// Close disconnects every subscription and rejects new ones.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subscribers {
+		delete(h.subscribers, sub)
+		sub.close()
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers, sub)
+	sub.close()
+}
+
+// NewHub creates a Hub using the provided configuration, falling back to sane defaults for unset values.
+func NewHub(config Config) *Hub {
+	if config.ClientBufferSize <= 0 {
+		config.ClientBufferSize = 64
+	}
+
+	return &Hub{
+		historySize: config.HistorySize,
+		bufferSize:  config.ClientBufferSize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
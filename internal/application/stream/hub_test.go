@@ -0,0 +1,199 @@
+package stream
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Match(t *testing.T) {
+	t.Parallel()
+	id1 := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	id2 := uuid.MustParse("20000000-0000-0000-0000-000000000002")
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		event    Event
+		expected bool
+	}{
+		{
+			name:     "Success Case - Empty filter",
+			filter:   NewFilter(nil, nil),
+			event:    Event{Type: "foo.created", FooID: id1},
+			expected: true,
+		},
+		{
+			name:     "Success Case - Type match",
+			filter:   NewFilter([]string{"foo.created", "foo.deleted"}, nil),
+			event:    Event{Type: "foo.deleted", FooID: id1},
+			expected: true,
+		},
+		{
+			name:     "Success Case - Type mismatch",
+			filter:   NewFilter([]string{"foo.created"}, nil),
+			event:    Event{Type: "foo.updated", FooID: id1},
+			expected: false,
+		},
+		{
+			name:     "Success Case - Id mismatch",
+			filter:   NewFilter(nil, []uuid.UUID{id2}),
+			event:    Event{Type: "foo.updated", FooID: id1},
+			expected: false,
+		},
+		{
+			name:     "Success Case - Type and id match",
+			filter:   NewFilter([]string{"foo.updated"}, []uuid.UUID{id1, id2}),
+			event:    Event{Type: "foo.updated", FooID: id2},
+			expected: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, testCase.filter.Match(testCase.event))
+		})
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+	hub := NewHub(Config{HistorySize: 10, ClientBufferSize: 4})
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+
+	all, _ := hub.Subscribe(NewFilter(nil, nil), 0)
+	defer all.Close()
+	deleted, _ := hub.Subscribe(NewFilter([]string{"foo.deleted"}, nil), 0)
+	defer deleted.Close()
+	other, _ := hub.Subscribe(NewFilter(nil, []uuid.UUID{uuid.MustParse("20000000-0000-0000-0000-000000000002")}), 0)
+	defer other.Close()
+
+	hub.Publish("foo.created", id, []byte(`{"id":"20000000-0000-0000-0000-000000000001"}`))
+	hub.Publish("foo.deleted", id, []byte(`{"id":"20000000-0000-0000-0000-000000000001"}`))
+
+	event := <-all.C
+	assert.Equal(t, uint64(1), event.ID)
+	assert.Equal(t, "foo.created", event.Type)
+	assert.Equal(t, id, event.FooID)
+
+	event = <-all.C
+	assert.Equal(t, uint64(2), event.ID)
+
+	event = <-deleted.C
+	assert.Equal(t, uint64(2), event.ID)
+	assert.Equal(t, "foo.deleted", event.Type)
+	assert.Len(t, deleted.C, 0)
+	assert.Len(t, other.C, 0)
+}
+
+func TestHub_Subscribe(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		historySize    int
+		published      int
+		filter         Filter
+		lastEventID    uint64
+		expectedReplay []uint64
+	}{
+		{
+			name:           "Success Case - No resume",
+			historySize:    10,
+			published:      3,
+			filter:         NewFilter(nil, nil),
+			lastEventID:    0,
+			expectedReplay: nil,
+		},
+		{
+			name:           "Success Case - Resume",
+			historySize:    10,
+			published:      5,
+			filter:         NewFilter(nil, nil),
+			lastEventID:    3,
+			expectedReplay: []uint64{4, 5},
+		},
+		{
+			name:           "Success Case - Resume with filter",
+			historySize:    10,
+			published:      5,
+			filter:         NewFilter([]string{"foo.updated"}, nil),
+			lastEventID:    1,
+			expectedReplay: []uint64{2, 4},
+		},
+		{
+			name:           "Success Case - Resume beyond history",
+			historySize:    2,
+			published:      5,
+			filter:         NewFilter(nil, nil),
+			lastEventID:    1,
+			expectedReplay: []uint64{4, 5},
+		},
+		{
+			name:           "Success Case - Unknown event id",
+			historySize:    10,
+			published:      2,
+			filter:         NewFilter(nil, nil),
+			lastEventID:    42,
+			expectedReplay: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			hub := NewHub(Config{HistorySize: testCase.historySize})
+			for i := 1; i <= testCase.published; i++ {
+				eventType := "foo.created"
+				if i%2 == 0 {
+					eventType = "foo.updated"
+				}
+				hub.Publish(eventType, uuid.New(), []byte(`{}`))
+			}
+
+			sub, replay := hub.Subscribe(testCase.filter, testCase.lastEventID)
+			defer sub.Close()
+
+			var ids []uint64
+			for _, event := range replay {
+				ids = append(ids, event.ID)
+			}
+			assert.Equal(t, testCase.expectedReplay, ids)
+			assert.Equal(t, 1, hub.Len())
+		})
+	}
+}
+
+func TestHub_SlowSubscriber(t *testing.T) {
+	t.Parallel()
+	hub := NewHub(Config{ClientBufferSize: 1})
+
+	sub, _ := hub.Subscribe(NewFilter(nil, nil), 0)
+	hub.Publish("foo.created", uuid.New(), []byte(`{}`))
+	hub.Publish("foo.created", uuid.New(), []byte(`{}`))
+
+	_, ok := <-sub.C
+	assert.True(t, ok)
+	_, ok = <-sub.C
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Len())
+
+	sub.Close()
+}
+
+func TestHub_Close(t *testing.T) {
+	t.Parallel()
+	hub := NewHub(Config{})
+
+	sub, _ := hub.Subscribe(NewFilter(nil, nil), 0)
+	hub.Close()
+
+	_, ok := <-sub.C
+	assert.False(t, ok)
+
+	late, _ := hub.Subscribe(NewFilter(nil, nil), 0)
+	_, ok = <-late.C
+	assert.False(t, ok)
+	assert.Equal(t, 0, hub.Len())
+}
@@ -0,0 +1,26 @@
+package stream
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	ConnectedClients = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "stream_connected_clients",
+			Help: "Number of clients currently connected to the event stream",
+		},
+		[]string{"transport"},
+	)
+
+	EventsDelivered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stream_events_delivered_total",
+			Help: "Total number of events delivered to stream clients",
+		},
+		[]string{"transport", "type"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(ConnectedClients)
+	prometheus.MustRegister(EventsDelivered)
+}
@@ -0,0 +1,90 @@
+// Package webhook runs the delivery of the webhooks in the background.
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+
+	"go.uber.org/zap"
+)
+
+const defaultPollInterval = time.Second
+
+// Config holds the settings of the dispatcher.
+// PollInterval is the time waited for new due deliveries once none is left.
+type Config struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+// Dispatcher attempts the due webhook deliveries until it is closed. Every replica runs one: the deliveries are leased
+// to the replica attempting them.
+type Dispatcher struct {
+	logger *zap.Logger
+	svc    service.IWebhookService
+	config Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Start runs the dispatcher in the background until Close is called.
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+// run attempts the due deliveries batch after batch, and waits for the poll interval once none is left or on failure.
+func (d *Dispatcher) run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		for ctx.Err() == nil {
+			// A batch being attempted is completed on close, so that the outcome of its deliveries is stored.
+			count, err := d.svc.DeliverPending(context.WithoutCancel(ctx))
+			if err != nil {
+				d.logger.Warn("fail to deliver pending webhooks", zap.Error(err))
+				break
+			}
+			if count == 0 {
+				break
+			}
+		}
+		timer.Reset(d.config.PollInterval)
+	}
+}
+
+// Close stops the dispatcher and waits for the deliveries being attempted.
+func (d *Dispatcher) Close() {
+	if d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.wg.Wait()
+}
+
+// NewDispatcher initializes a new Dispatcher of the webhook deliveries with the provided logger, configuration and service.
+func NewDispatcher(logger *zap.Logger, config Config, svc service.IWebhookService) *Dispatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaultPollInterval
+	}
+
+	return &Dispatcher{
+		logger: logger,
+		svc:    svc,
+		config: config,
+	}
+}
@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+func TestDispatcher(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name  string
+		setup func(*service.MockWebhookService, chan struct{})
+	}{
+		{
+			name: "Success Case - Drain Batches Then Poll",
+			setup: func(mockService *service.MockWebhookService, polled chan struct{}) {
+				mockService.On("DeliverPending", mock.Anything).Return(10, nil).Twice()
+				mockService.On("DeliverPending", mock.Anything).Return(0, nil).Once()
+				mockService.On("DeliverPending", mock.Anything).Return(0, nil).Run(func(mock.Arguments) {
+					select {
+					case polled <- struct{}{}:
+					default:
+					}
+				})
+			},
+		},
+		{
+			name: "Failure Case - Poll Again After Error",
+			setup: func(mockService *service.MockWebhookService, polled chan struct{}) {
+				mockService.On("DeliverPending", mock.Anything).Return(0, errors.New("repository error")).Once()
+				mockService.On("DeliverPending", mock.Anything).Return(0, nil).Run(func(mock.Arguments) {
+					select {
+					case polled <- struct{}{}:
+					default:
+					}
+				})
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockService := new(service.MockWebhookService)
+			polled := make(chan struct{})
+			testCase.setup(mockService, polled)
+			dispatcher := NewDispatcher(zap.NewNop(), Config{PollInterval: 10 * time.Millisecond}, mockService)
+
+			dispatcher.Start(context.Background())
+			select {
+			case <-polled:
+			case <-time.After(time.Second):
+				t.Fatal("dispatcher did not poll again")
+			}
+			dispatcher.Close()
+
+			mockService.AssertExpectations(t)
+		})
+	}
+}
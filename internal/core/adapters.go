@@ -0,0 +1,188 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+	"github.com/TancelinMazzotti/astigo/internal/domain/service"
+	cache2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/cache"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/codec"
+	memory2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/memory"
+	redis2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis"
+	nats2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats"
+	ratelimit2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/ratelimit/memory"
+	redis3 "github.com/TancelinMazzotti/astigo/internal/infrastructure/ratelimit/redis"
+	memory4 "github.com/TancelinMazzotti/astigo/internal/infrastructure/repository/memory"
+	postgres2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/repository/postgres"
+	memory5 "github.com/TancelinMazzotti/astigo/internal/infrastructure/storage/memory"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/storage/s3storage"
+
+	"github.com/coreos/go-oidc"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	// ProfileDefault connects the server to Postgres, Redis, NATS, S3 and the OIDC provider.
+	ProfileDefault = "default"
+	// ProfileMemory runs the server in a single process, without any infrastructure: every outbound port is kept in
+	// memory, which loses all data on shutdown, and requests authenticate with the static token of the auth config.
+	ProfileMemory = "memory"
+
+	// memoryStoragePath is where the memory profile serves the URLs presigned by its object storage.
+	memoryStoragePath = "/storage"
+)
+
+// adapters holds the implementations of the outbound ports selected by the profile. The list cache and the filter
+// of existing ids are optional and may be nil.
+type adapters struct {
+	auth              service.IAuthService
+	rateLimiter       ratelimit.IRateLimiter
+	fooRepository     repository.IFooRepository
+	fileRepository    repository.IFileRepository
+	webhookRepository repository.IWebhookRepository
+	fooCache          cache.IFooCache
+	fooListCache      cache.IFooListCache
+	fooFilter         cache.IFooFilter
+	fileStorage       storage.IFileStorage
+}
+
+// newAdapters connects to the infrastructure, or creates the in-memory adapters with the memory profile. The memory
+// profile is refused in release mode: its static token and object storage only suit development.
+func (server *Server) newAdapters(ctx context.Context) (*adapters, error) {
+	switch server.Config.Profile {
+	case "", ProfileDefault:
+		return server.newInfrastructureAdapters(ctx)
+	case ProfileMemory:
+		if server.releaseMode() {
+			return nil, fmt.Errorf("profile %q is not allowed in %s mode", ProfileMemory, gin.ReleaseMode)
+		}
+		return server.newMemoryAdapters(), nil
+	default:
+		return nil, fmt.Errorf("unknown profile %q", server.Config.Profile)
+	}
+}
+
+// releaseMode reports whether the server runs in release mode, set either by http.mode or by the GIN_MODE
+// environment variable, which Gin reads on start-up before the configuration is applied.
+func (server *Server) releaseMode() bool {
+	return server.Config.Gin.Mode == gin.ReleaseMode || gin.Mode() == gin.ReleaseMode
+}
+
+// newInfrastructureAdapters connects to Postgres, Redis, S3, NATS and the OIDC provider and registers their health checks.
+func (server *Server) newInfrastructureAdapters(ctx context.Context) (*adapters, error) {
+	var err error
+
+	server.Logger.Info("create new postgres connector")
+	if server.Postgres, err = postgres2.NewPostgres(ctx, server.Config.Postgres); err != nil {
+		server.Logger.Error("fail to create postgres connector", zap.Error(err))
+		return nil, fmt.Errorf("fail to create postgres connector %w", err)
+	}
+
+	server.Logger.Info("create new redis connector")
+	if server.Redis, err = redis2.NewRedis(ctx, server.Config.Redis); err != nil {
+		server.Logger.Error("fail to create redis connector", zap.Error(err))
+		return nil, fmt.Errorf("fail to create nats connector %w", err)
+	}
+
+	server.Logger.Info("create new s3 connector")
+	if server.S3, err = s3storage.NewS3(ctx, server.Config.S3); err != nil {
+		server.Logger.Error("fail to create s3 connector", zap.Error(err))
+		return nil, fmt.Errorf("fail to create s3 connector %w", err)
+	}
+
+	server.Logger.Info("create new nats connector")
+	if server.Nats, err = nats2.NewNats(server.Config.Nats); err != nil {
+		server.Logger.Error("fail to create nats connector", zap.Error(err))
+		return nil, fmt.Errorf("fail to create nats connector %w", err)
+	}
+
+	if server.Config.Nats.JetStream.Enabled {
+		server.Logger.Info("provision jetstream streams")
+		if server.JetStream, err = nats2.NewJetStream(ctx, server.Nats, server.Config.Nats.JetStream); err != nil {
+			server.Logger.Error("fail to provision jetstream streams", zap.Error(err))
+			return nil, fmt.Errorf("fail to provision jetstream streams %w", err)
+		}
+	}
+
+	server.Logger.Info("create new oidc provider")
+	server.Provider, err = oidc.NewProvider(ctx, server.Config.Auth.Issuer)
+	if err != nil {
+		server.Logger.Error("fail to create oidc provider", zap.Error(err))
+		return nil, fmt.Errorf("failed to create oidc provider: %w", err)
+	}
+
+	// The cache and the object storage are not on the critical path of the Foo API: their failure only degrades the service.
+	server.Health.Register("postgres", health.PingCheck(server.Postgres))
+	server.Health.Register("nats", health.NatsCheck(server.Nats))
+	server.Health.Register("redis", health.RedisCheck(server.Redis), health.NonCritical())
+	server.Health.Register("s3", server.S3.Ping, health.NonCritical())
+
+	server.Logger.Debug("create new foo caches")
+	serializer, err := codec.NewSerializer(server.Config.Cache.Codec)
+	if err != nil {
+		server.Logger.Error("fail to create cache serializer", zap.Error(err))
+		return nil, fmt.Errorf("fail to create cache serializer %w", err)
+	}
+	var fooCache cache.IFooCache = redis2.NewFooRedis(server.Redis, serializer)
+	if server.Config.Cache.Local.Enabled {
+		cache2.RegisterMetrics()
+		server.Invalidation = nats2.NewFooInvalidationNats(server.Logger, server.Nats)
+		tiered := cache2.NewFooCacheTiered(
+			server.Logger,
+			memory2.NewFooMemory(server.Config.Cache.Local.Size, server.Config.Cache.Local.TTL),
+			fooCache,
+			server.Invalidation,
+		)
+		if err := server.Invalidation.Subscribe(tiered.Evict); err != nil {
+			server.Logger.Error("fail to subscribe to foo cache invalidations", zap.Error(err))
+			return nil, fmt.Errorf("fail to subscribe to foo cache invalidations %w", err)
+		}
+		fooCache = tiered
+	}
+
+	var fooFilter cache.IFooFilter
+	if server.Config.Cache.Bloom.Enabled {
+		fooFilter = redis2.NewFooBloomRedis(server.Redis, server.Config.Cache.Bloom)
+	}
+
+	return &adapters{
+		auth: service.NewAuthService(server.Logger, server.Provider, server.Config.Auth.ClientID),
+		// Limits are shared through Redis and enforced per instance while Redis is unavailable.
+		rateLimiter: ratelimit2.NewRateLimiterFallback(
+			server.Logger,
+			redis3.NewRateLimiterRedis(server.Redis),
+			memory.NewRateLimiterMemory(),
+		),
+		fooRepository:     postgres2.NewFooPostgres(server.Postgres),
+		fileRepository:    postgres2.NewFilePostgres(server.Postgres),
+		webhookRepository: postgres2.NewWebhookPostgres(server.Postgres),
+		fooCache:          fooCache,
+		fooListCache:      redis2.NewFooListRedis(server.Redis, serializer),
+		fooFilter:         fooFilter,
+		fileStorage:       s3storage.NewFileS3(server.S3),
+	}, nil
+}
+
+// newMemoryAdapters creates the in-memory adapters of the memory profile.
+func (server *Server) newMemoryAdapters() *adapters {
+	server.Logger.Warn("memory profile: data is kept in process and lost on shutdown, requests authenticate with the static token")
+
+	server.Storage = memory5.NewFileMemory(fmt.Sprintf("http://localhost:%s%s", server.Config.Gin.Port, memoryStoragePath))
+
+	return &adapters{
+		auth:              service.NewStaticAuthService(server.Logger, server.Config.Auth.StaticToken),
+		rateLimiter:       memory.NewRateLimiterMemory(),
+		fooRepository:     memory4.NewFooMemory(),
+		fileRepository:    memory4.NewFileMemory(),
+		webhookRepository: memory4.NewWebhookMemory(),
+		fooCache:          memory2.NewFooMemory(server.Config.Cache.Local.Size, 0),
+		fileStorage:       server.Storage,
+	}
+}
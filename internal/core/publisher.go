@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/logging"
+	memory3 "github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/memory"
+	nats2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/publisher"
+	webhook3 "github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/webhook"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// newFooPublisher fans out the Foo events to the configured sinks. Without any sink configured, the events are
+// published to the broker, through JetStream when it is enabled, and fail the API calls when they cannot be. Without
+// a broker, with the memory profile, they are kept in memory and enqueued for the webhooks instead.
+func (server *Server) newFooPublisher(encoder *cloudevents.Encoder, webhookService service.IWebhookService) (*publisher.FooPublisher, error) {
+	sinks := server.Config.Publisher.Sinks
+	if len(sinks) == 0 {
+		switch {
+		case server.Nats == nil:
+			sinks = []publisher.SinkConfig{{Type: publisher.SinkMemory, Required: true}}
+			if server.Config.Webhook.Enabled {
+				sinks = append(sinks, publisher.SinkConfig{Type: publisher.SinkWebhook, Required: true})
+			}
+		case server.JetStream != nil:
+			sinks = []publisher.SinkConfig{{Type: publisher.SinkJetStream, Required: true}}
+		default:
+			sinks = []publisher.SinkConfig{{Type: publisher.SinkNats, Required: true}}
+		}
+	}
+
+	fooPublisher := publisher.NewFooPublisher(server.Logger)
+	for _, sink := range sinks {
+		var subscriber messaging.IFooMessaging
+		switch sink.Type {
+		case publisher.SinkNats:
+			if server.Nats == nil {
+				return nil, fmt.Errorf("publisher sink %q requires nats", sink.Type)
+			}
+			subscriber = nats2.NewFooNats(server.Nats, nil, encoder)
+		case publisher.SinkJetStream:
+			if server.JetStream == nil {
+				return nil, fmt.Errorf("publisher sink %q requires jetstream to be enabled", sink.Type)
+			}
+			subscriber = nats2.NewFooNats(server.Nats, server.JetStream, encoder)
+		case publisher.SinkLogging:
+			subscriber = logging.NewFooLogging(server.Logger)
+		case publisher.SinkWebhook:
+			if !server.Config.Webhook.Enabled {
+				return nil, fmt.Errorf("publisher sink %q requires webhooks to be enabled", sink.Type)
+			}
+			subscriber = webhook3.NewFooWebhook(webhookService, encoder)
+		case publisher.SinkMemory:
+			// The events kept in memory are only read by the stream clients, which read them from the broker when
+			// there is one.
+			if server.Nats != nil {
+				return nil, fmt.Errorf("publisher sink %q requires the %s profile", sink.Type, ProfileMemory)
+			}
+			fooMemory := memory3.NewFooMemory(memory3.DefaultSize)
+			fooMemory.Listen(server.streamFooEvent())
+			subscriber = fooMemory
+		default:
+			return nil, fmt.Errorf("unknown publisher sink %q", sink.Type)
+		}
+		fooPublisher.Subscribe(subscriber, sink.Options()...)
+	}
+
+	return fooPublisher, nil
+}
+
+// newFileMessaging publishes the File events to core NATS, the Foo stream only persisting the Foo subjects. Without a
+// broker, with the memory profile, they are kept in memory.
+func (server *Server) newFileMessaging(encoder *cloudevents.Encoder) messaging.IFileMessaging {
+	if server.Nats == nil {
+		return memory3.NewFileMemory(memory3.DefaultSize)
+	}
+	return nats2.NewFileNats(server.Nats, encoder)
+}
+
+// streamFooEvent forwards the Foo events to the stream hub as the NATS consumer does: the hub receives the Foo payload
+// of the event, along with the Foo id carried by its subject.
+func (server *Server) streamFooEvent() memory3.Listener {
+	return func(subject string, event cloudevents.Event) {
+		fooID, err := uuid.Parse(event.Subject)
+		if err != nil {
+			server.Logger.Warn("invalid foo id in foo event for the stream hub", zap.String("subject", subject), zap.Error(err))
+			return
+		}
+		server.StreamHub.Publish(subject, fooID, event.Data)
+	}
+}
@@ -10,18 +10,31 @@ import (
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/application/event"
+	"github.com/TancelinMazzotti/astigo/internal/application/file"
 	grpc2 "github.com/TancelinMazzotti/astigo/internal/application/grpc"
+	"github.com/TancelinMazzotti/astigo/internal/application/health"
 	http2 "github.com/TancelinMazzotti/astigo/internal/application/http"
+	"github.com/TancelinMazzotti/astigo/internal/application/httpcache"
+	"github.com/TancelinMazzotti/astigo/internal/application/ratelimit"
+	"github.com/TancelinMazzotti/astigo/internal/application/stream"
+	"github.com/TancelinMazzotti/astigo/internal/application/webhook"
 	"github.com/TancelinMazzotti/astigo/internal/domain/service"
+	cache2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/cache"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/codec"
 	redis2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis"
 	nats2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/publisher"
 	postgres2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/repository/postgres"
+	memory5 "github.com/TancelinMazzotti/astigo/internal/infrastructure/storage/memory"
 	"github.com/TancelinMazzotti/astigo/internal/infrastructure/storage/s3storage"
 	"github.com/TancelinMazzotti/astigo/internal/infrastructure/telemetry"
+	webhook2 "github.com/TancelinMazzotti/astigo/internal/infrastructure/webhook"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
 
 	"github.com/coreos/go-oidc"
 	"github.com/gin-gonic/gin"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -29,14 +42,26 @@ import (
 
 // Config represents the main configuration structure for the application, encompassing all required service settings.
 type Config struct {
-	Log LoggerConfig `mapstructure:"log"`
-
-	Gin       http2.Config     `mapstructure:"http"`
-	Grpc      grpc2.Config     `mapstructure:"grpc"`
-	Telemetry telemetry.Config `mapstructure:"telemetry"`
+	Profile string       `mapstructure:"profile"`
+	Log     LoggerConfig `mapstructure:"log"`
+
+	Gin       http2.Config       `mapstructure:"http"`
+	Grpc      grpc2.Config       `mapstructure:"grpc"`
+	Telemetry telemetry.Config   `mapstructure:"telemetry"`
+	Stream    stream.Config      `mapstructure:"stream"`
+	Health    health.Config      `mapstructure:"health"`
+	RateLimit ratelimit.Config   `mapstructure:"rate_limit"`
+	HTTPCache httpcache.Config   `mapstructure:"http_cache"`
+	Worker    event.WorkerConfig `mapstructure:"worker"`
+	Cache     CacheConfig        `mapstructure:"cache"`
+	Webhook   WebhookConfig      `mapstructure:"webhook"`
+	File      FileConfig         `mapstructure:"file"`
+	Publisher publisher.Config   `mapstructure:"publisher"`
 	Auth      struct {
 		ClientID string `mapstructure:"client_id"`
 		Issuer   string `mapstructure:"issuer"`
+		// StaticToken is the bearer token accepted with the memory profile, which does not use the OIDC provider.
+		StaticToken string `mapstructure:"static_token"`
 	} `mapstructure:"auth"`
 
 	Postgres postgres2.Config `mapstructure:"postgres"`
@@ -45,6 +70,29 @@ type Config struct {
 	S3       s3storage.Config `mapstructure:"s3"`
 }
 
+// CacheConfig holds the Foo caching policy along with the optional in-process tier and Bloom filter of existing Foo ids.
+type CacheConfig struct {
+	service.FooCacheConfig `mapstructure:",squash"`
+	Local                  cache2.LocalConfig `mapstructure:"local"`
+	Bloom                  redis2.BloomConfig `mapstructure:"bloom"`
+	Codec                  codec.Config       `mapstructure:"codec"`
+}
+
+// WebhookConfig holds the delivery policy of the webhooks along with the settings of their dispatcher and HTTP client.
+// When disabled, the subscriptions can still be managed but no event is delivered.
+type WebhookConfig struct {
+	Enabled               bool `mapstructure:"enabled"`
+	service.WebhookConfig `mapstructure:",squash"`
+	Dispatcher            webhook.Config  `mapstructure:",squash"`
+	Client                webhook2.Config `mapstructure:",squash"`
+}
+
+// FileConfig holds the upload policy of the files along with the settings of the sweeper of their expired uploads.
+type FileConfig struct {
+	service.FileConfig `mapstructure:",squash"`
+	Sweeper            file.Config `mapstructure:",squash"`
+}
+
 // Server represents the main service structure that holds all essential configurations and dependencies.
 type Server struct {
 	Config Config
@@ -53,20 +101,36 @@ type Server struct {
 	HttpServer   *http.Server
 	GrpcServer   *grpc.Server
 	ConsumerNats *event.ConsumerNats
+	Invalidation *nats2.FooInvalidationNats
+	Webhook      *webhook.Dispatcher
+	FileSweeper  *file.Sweeper
 	GinEngine    *gin.Engine
+	StreamHub    *stream.Hub
+	Health       *health.Registry
 
 	Provider  *oidc.Provider
 	Telemetry *telemetry.Telemetry
 	Postgres  *sql.DB
 	Nats      *nats.Conn
-	Redis     *redis.Client
+	JetStream jetstream.JetStream
+	Redis     redis.UniversalClient
 	S3        *s3storage.Client
+	Storage   *memory5.FileMemory
 }
 
 // Start initializes and runs the HTTP and gRPC servers concurrently and listens for errors and shutdown signals.
+// The webhook dispatcher and the file sweeper start along with them, so that a server failing to be created leaves no
+// goroutine behind.
 func (server *Server) Start(ctx context.Context) error {
 	errCh := make(chan error, 2)
 
+	if server.Webhook != nil {
+		server.Webhook.Start(ctx)
+	}
+	if server.FileSweeper != nil {
+		server.FileSweeper.Start(ctx)
+	}
+
 	go server.startHTTPServer(errCh)
 	go server.startGrpcServer(errCh)
 	go server.handleShutdown(ctx, errCh)
@@ -80,9 +144,16 @@ func (server *Server) Start(ctx context.Context) error {
 // startHTTPServer starts an HTTP server using the configuration provided in the Server instance and listens for errors.
 func (server *Server) startHTTPServer(errCh chan<- error) {
 	http2.StartAt = time.Now()
+
+	// Unencrypted HTTP/2 lets native gRPC clients reach the Connect handler on the HTTP port.
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
 	server.HttpServer = &http.Server{
-		Addr:    fmt.Sprintf(":%s", server.Config.Gin.Port),
-		Handler: server.GinEngine,
+		Addr:      fmt.Sprintf(":%s", server.Config.Gin.Port),
+		Handler:   server.GinEngine,
+		Protocols: &protocols,
 	}
 
 	server.Logger.Info("HTTP server starting")
@@ -114,6 +185,11 @@ func (server *Server) handleShutdown(ctx context.Context, errCh chan<- error) {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
+	// Close stream hub first so that long-lived SSE and WebSocket connections do not block the HTTP shutdown
+	server.Logger.Info("Stream hub shutdown...")
+	server.StreamHub.Close()
+	server.Logger.Info("Stream hub shutdown")
+
 	// Shutdown HTTP
 	server.Logger.Info("HTTP server shutdown...")
 	if err := server.HttpServer.Shutdown(shutdownCtx); err != nil {
@@ -128,33 +204,66 @@ func (server *Server) handleShutdown(ctx context.Context, errCh chan<- error) {
 	server.Logger.Info("gRPC server shutdown")
 
 	// Close NatsConsumer
-	server.Logger.Info("Nats consumer shutdown...")
-	if err := server.ConsumerNats.Close(); err != nil {
-		server.Logger.Error("Nats consumer shutdown error", zap.Error(err))
-	} else {
-		server.Logger.Info("Nats consumer shutdown")
+	if server.ConsumerNats != nil {
+		server.Logger.Info("Nats consumer shutdown...")
+		if err := server.ConsumerNats.Close(); err != nil {
+			server.Logger.Error("Nats consumer shutdown error", zap.Error(err))
+		} else {
+			server.Logger.Info("Nats consumer shutdown")
+		}
 	}
 
-	// Shutdown Postgres
-	server.Logger.Info("Postgres shutdown...")
-	if err := server.Postgres.Close(); err != nil {
-		server.Logger.Error("Postgres shutdown error", zap.Error(err))
-	} else {
-		server.Logger.Info("Postgres shutdown")
+	// Close webhook dispatcher before Postgres, which stores the outcome of the deliveries being attempted
+	if server.Webhook != nil {
+		server.Logger.Info("Webhook dispatcher shutdown...")
+		server.Webhook.Close()
+		server.Logger.Info("Webhook dispatcher shutdown")
 	}
 
-	// Shutdown redis
-	server.Logger.Info("Redis shutdown...")
-	if err := server.Redis.Close(); err != nil {
-		server.Logger.Error("Redis shutdown error", zap.Error(err))
-	} else {
-		server.Logger.Info("Redis shutdown")
+	// Close file sweeper before Postgres, which stores the files being expired
+	if server.FileSweeper != nil {
+		server.Logger.Info("File sweeper shutdown...")
+		server.FileSweeper.Close()
+		server.Logger.Info("File sweeper shutdown")
+	}
+
+	// Close cache invalidations
+	if server.Invalidation != nil {
+		server.Logger.Info("Cache invalidation shutdown...")
+		if err := server.Invalidation.Close(); err != nil {
+			server.Logger.Error("Cache invalidation shutdown error", zap.Error(err))
+		} else {
+			server.Logger.Info("Cache invalidation shutdown")
+		}
+	}
+
+	// The connections are not opened with the memory profile
+	if server.Postgres != nil {
+		// Shutdown Postgres
+		server.Logger.Info("Postgres shutdown...")
+		if err := server.Postgres.Close(); err != nil {
+			server.Logger.Error("Postgres shutdown error", zap.Error(err))
+		} else {
+			server.Logger.Info("Postgres shutdown")
+		}
 	}
 
-	// Close Nats
-	server.Logger.Info("Nats shutdown...")
-	server.Nats.Close()
-	server.Logger.Info("Nats shutdown")
+	if server.Redis != nil {
+		// Shutdown redis
+		server.Logger.Info("Redis shutdown...")
+		if err := server.Redis.Close(); err != nil {
+			server.Logger.Error("Redis shutdown error", zap.Error(err))
+		} else {
+			server.Logger.Info("Redis shutdown")
+		}
+	}
+
+	if server.Nats != nil {
+		// Close Nats
+		server.Logger.Info("Nats shutdown...")
+		server.Nats.Close()
+		server.Logger.Info("Nats shutdown")
+	}
 
 	// Shutdown Telemetry
 	server.Logger.Info("Telemetry shutdown...")
@@ -169,7 +278,8 @@ func (server *Server) handleShutdown(ctx context.Context, errCh chan<- error) {
 }
 
 // NewServer initializes a new Server instance with configured dependencies including logging, tracing, and connectors.
-// It sets up components such as Telemetry tracer, PostgreSQL, Redis, NATS, OIDC provider, and associated services.
+// It sets up components such as Telemetry tracer, PostgreSQL, Redis, NATS, OIDC provider, and associated services,
+// or in-memory adapters in place of the connectors with the memory profile.
 // Returns a fully initialized Server instance or an error if any dependency setup fails.
 func NewServer(ctx context.Context, config Config) (*Server, error) {
 	var err error
@@ -189,72 +299,141 @@ func NewServer(ctx context.Context, config Config) (*Server, error) {
 		return nil, fmt.Errorf("fail to create telemetry provider %w", err)
 	}
 
-	server.Logger.Info("create new postgres connector")
-	if server.Postgres, err = postgres2.NewPostgres(ctx, server.Config.Postgres); err != nil {
-		server.Logger.Error("fail to create postgres connector", zap.Error(err))
-		return nil, fmt.Errorf("fail to create postgres connector %w", err)
-	}
+	server.Logger.Debug("create new health registry")
+	server.Health = health.NewRegistry(server.Config.Health)
 
-	server.Logger.Info("create new redis connector")
-	if server.Redis, err = redis2.NewRedis(ctx, server.Config.Redis); err != nil {
-		server.Logger.Error("fail to create redis connector", zap.Error(err))
-		return nil, fmt.Errorf("fail to create nats connector %w", err)
+	adapters, err := server.newAdapters(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	server.Logger.Info("create new s3 connector")
-	if server.S3, err = s3storage.NewS3(ctx, server.Config.S3); err != nil {
-		server.Logger.Error("fail to create s3 connector", zap.Error(err))
-		return nil, fmt.Errorf("fail to create s3 connector %w", err)
+	server.Logger.Debug("create new stream hub")
+	server.StreamHub = stream.NewHub(server.Config.Stream)
+
+	encoder, err := cloudevents.NewEncoder(server.Config.Nats.CloudEvents)
+	if err != nil {
+		server.Logger.Error("fail to create cloudevents encoder", zap.Error(err))
+		return nil, fmt.Errorf("fail to create cloudevents encoder %w", err)
 	}
 
-	server.Logger.Info("create new nats connector")
-	if server.Nats, err = nats2.NewNats(server.Config.Nats); err != nil {
-		server.Logger.Error("fail to create nats connector", zap.Error(err))
-		return nil, fmt.Errorf("fail to create nats connector %w", err)
+	server.Logger.Debug("create new webhook services")
+	service.RegisterMetrics()
+	webhookService := service.NewWebhookService(
+		server.Logger,
+		server.Config.Webhook.WebhookConfig,
+		adapters.webhookRepository,
+		webhook2.NewWebhookHTTP(server.Config.Webhook.Client),
+	)
+	if server.Config.Webhook.Enabled {
+		server.Webhook = webhook.NewDispatcher(server.Logger, server.Config.Webhook.Dispatcher, webhookService)
 	}
 
-	server.Logger.Info("create new oidc provider")
-	server.Provider, err = oidc.NewProvider(ctx, server.Config.Auth.Issuer)
-	if err != nil {
-		server.Logger.Error("fail to create oidc provider", zap.Error(err))
-		return nil, fmt.Errorf("failed to create oidc provider: %w", err)
+	if server.Nats != nil {
+		server.Logger.Info("create new nats consumer")
+		event.RegisterMetrics()
+		registry := event.NewRegistry()
+		registry.Use(
+			event.Tracing(),
+			event.Logging(server.Logger),
+			event.Metrics(),
+			event.Recovery(server.Logger),
+		)
+		if server.JetStream == nil {
+			// Core NATS does not deliver a failed event again: retry it in process instead.
+			registry.Use(event.Retry(server.Config.Worker.MaxDeliver, server.Config.Worker.BackOff))
+		}
+		event.RegisterFooHandlers(registry, server.Logger, event.WithConcurrency(server.Config.Worker.Concurrency))
+		// The webhook sink enqueues the deliveries as the events are published: they are not consumed from NATS then.
+		if server.Config.Webhook.Enabled && !server.Config.Publisher.Has(publisher.SinkWebhook) {
+			event.RegisterWebhookHandlers(registry, webhookService, event.WithConcurrency(server.Config.Worker.Concurrency))
+		}
+		server.ConsumerNats, err = event.NewConsumerNats(
+			ctx,
+			server.Logger,
+			server.Nats,
+			server.JetStream,
+			server.Config.Nats.JetStream.Stream,
+			server.Config.Worker,
+			registry,
+			server.StreamHub,
+		)
+		if err != nil {
+			server.Logger.Error("fail to create nats consumer", zap.Error(err))
+			return nil, fmt.Errorf("fail to create nats consumer %w", err)
+		}
 	}
 
-	server.Logger.Info("create new nats consumer")
-	server.ConsumerNats, err = event.NewConsumerNats(server.Logger, server.Nats)
+	server.Logger.Debug("create new foo services")
+	publisher.RegisterMetrics()
+	fooPublisher, err := server.newFooPublisher(encoder, webhookService)
 	if err != nil {
-		server.Logger.Error("fail to create nats consumer", zap.Error(err))
-		return nil, fmt.Errorf("fail to create nats consumer %w", err)
+		server.Logger.Error("fail to create foo publisher", zap.Error(err))
+		return nil, fmt.Errorf("fail to create foo publisher %w", err)
 	}
 
-	server.Logger.Debug("create new auth services")
-	authService := service.NewAuthService(
+	fooService := service.NewFooService(
 		server.Logger,
-		server.Provider,
-		server.Config.Auth.ClientID,
+		server.Config.Cache.FooCacheConfig,
+		adapters.fooRepository,
+		adapters.fooCache,
+		adapters.fooListCache,
+		adapters.fooFilter,
+		fooPublisher,
 	)
+	if adapters.fooFilter != nil {
+		// Every replica populates the shared filter at startup: adding ids is idempotent and the filter only
+		// rejects ids once it has been populated at least once.
+		go func() {
+			if err := fooService.PopulateFilter(context.WithoutCancel(ctx)); err != nil {
+				server.Logger.Warn("fail to populate foo filter", zap.Error(err))
+			}
+		}()
+	}
 
-	server.Logger.Debug("create new foo services")
-	fooService := service.NewFooService(
+	server.Logger.Debug("create new file services")
+	fileService := service.NewFileService(
 		server.Logger,
-		postgres2.NewFooPostgres(server.Postgres),
-		redis2.NewFooRedis(server.Redis),
-		nats2.NewFooNats(server.Nats),
+		server.Config.File.FileConfig,
+		adapters.fileRepository,
+		adapters.fileStorage,
+		server.newFileMessaging(encoder),
 	)
+	server.FileSweeper = file.NewSweeper(server.Logger, server.Config.File.Sweeper, fileService)
+
+	rateLimitPolicy := ratelimit.NewPolicy(server.Config.RateLimit)
+
+	grpcFooService := grpc2.NewFooService(fooService)
 
 	server.Logger.Debug("create new gin engine")
-	server.GinEngine = http2.NewGin(
+	server.GinEngine, err = http2.NewGin(
 		server.Config.Gin,
 		server.Logger,
-		authService,
-		http2.NewHealthController(),
+		adapters.auth,
+		adapters.rateLimiter,
+		rateLimitPolicy,
+		httpcache.NewPolicy(server.Config.HTTPCache),
+		http2.NewHealthController(server.Health),
 		http2.NewFooController(fooService),
+		http2.NewFooStreamController(server.Config.Stream, server.StreamHub),
+		grpc2.NewFooConnectService(grpcFooService),
+		http2.NewWebhookController(webhookService),
+		http2.NewFileController(fileService),
 	)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create gin engine: %w", err)
+	}
+	if server.Storage != nil {
+		server.GinEngine.Any(memoryStoragePath+"/*key", gin.WrapH(http.StripPrefix(memoryStoragePath, server.Storage)))
+	}
 
 	server.Logger.Debug("create new grpc server")
 	server.GrpcServer = grpc2.NewGrpcServer(
+		server.Config.Grpc,
 		server.Logger,
-		grpc2.NewFooService(fooService),
+		adapters.rateLimiter,
+		rateLimitPolicy,
+		grpc2.NewHealthService(server.Health, server.Config.Grpc.HealthWatchInterval),
+		grpcFooService,
 	)
 
 	return server, nil
@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// CacheTTL holds the lifetimes of a cache entry. The entry is fresh during Fresh, then it can still be served
+// for Stale while it is refreshed in the background. A zero Stale disables stale-while-revalidate.
+type CacheTTL struct {
+	Fresh time.Duration
+	Stale time.Duration
+}
+
+// Expiration returns the time after which the entry is evicted from the cache.
+func (t CacheTTL) Expiration() time.Duration {
+	return t.Fresh + t.Stale
+}
+
+// FooCacheEntry is a Foo read from the cache along with the time until which it is fresh.
+// A Missing entry is a tombstone recording that no Foo exists with the requested id; its Foo is nil.
+type FooCacheEntry struct {
+	Foo        *Foo
+	Missing    bool
+	FreshUntil time.Time
+}
+
+// Fresh reports whether the entry can be served without being refreshed.
+func (e *FooCacheEntry) Fresh(now time.Time) bool {
+	return now.Before(e.FreshUntil)
+}
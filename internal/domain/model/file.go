@@ -1,6 +1,7 @@
 package model
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,15 +15,31 @@ const (
 	UploadStatusFailed   UploadStatus = "failed"
 )
 
+// File is a file uploaded by a client straight to the object storage, at Path. It is pending until its upload is
+// completed, when the object found at Path matches the declared Size; it is failed when it does not.
 type File struct {
-	Id         uuid.UUID
-	Name       string
-	Size       int64
-	Extension  string
-	MimeType   string
-	Path       string
-	Status     UploadStatus
+	Id         uuid.UUID    `validate:"required"`
+	Name       string       `validate:"required,max=255"`
+	Size       int64        `validate:"gt=0"`
+	Extension  string       `validate:"max=32"`
+	MimeType   string       `validate:"required,max=255"`
+	Path       string       `validate:"required,max=1024"`
+	Status     UploadStatus `validate:"required,oneof=pending complete failed"`
 	CreatedAt  time.Time
 	UploadedAt time.Time
 	UpdatedAt  time.Time
 }
+
+// FileObject describes an object of the storage, as reported by the storage.
+type FileObject struct {
+	Size        int64
+	ContentType string
+	ModifiedAt  time.Time
+}
+
+// PresignedRequest is a request a client sends as is to the object storage, authorized until it expires.
+type PresignedRequest struct {
+	Method string
+	Url    string
+	Header http.Header
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+// RateLimit allows Limit requests per Period, with bursts of up to Burst requests.
+// A zero Burst means that the whole Limit can be consumed at once.
+type RateLimit struct {
+	Limit  int
+	Period time.Duration
+	Burst  int
+}
+
+// Capacity returns the number of requests that can be served back to back.
+func (r RateLimit) Capacity() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return r.Limit
+}
+
+// EmissionInterval returns the time needed to regain one request. It is at least one nanosecond, so that a limit
+// higher than the nanoseconds of its period does not yield a zero interval.
+func (r RateLimit) EmissionInterval() time.Duration {
+	return max(r.Period/time.Duration(r.Limit), time.Nanosecond)
+}
+
+// RateLimitResult is the decision taken for a single request.
+// RetryAfter is only set when the request is rejected; ResetAfter is the time until the limit is fully replenished.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
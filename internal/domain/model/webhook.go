@@ -0,0 +1,56 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookSubscription is an endpoint of a partner receiving the events of the listed types.
+// It is disabled once ConsecutiveFailures reaches the failure threshold, until it is enabled again.
+type WebhookSubscription struct {
+	Id         uuid.UUID `validate:"required"`
+	Url        string    `validate:"required,http_url,max=2048"`
+	EventTypes []string  `validate:"required,min=1,dive,required,max=255"`
+	Secret     string    `validate:"required,min=16,max=256"`
+	Enabled    bool
+
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+
+	CreatedAt time.Time  `validate:"omitempty"`
+	UpdatedAt *time.Time `validate:"omitempty"`
+}
+
+// Matches reports whether the subscription receives the events of the given type.
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	return slices.Contains(s.EventTypes, eventType)
+}
+
+// WebhookDelivery is the delivery of an event to a subscription, along with the outcome of its last attempt.
+type WebhookDelivery struct {
+	Id             uuid.UUID
+	SubscriptionId uuid.UUID
+	EventId        string
+	EventType      string
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+
+	Attempts      int
+	StatusCode    int
+	Error         string
+	Duration      time.Duration
+	NextAttemptAt time.Time
+
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+}
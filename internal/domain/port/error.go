@@ -7,6 +7,8 @@ var (
 	ErrorAlreadyExists    *ErrAlreadyExists
 	ErrorNoAffectedData   *ErrNoAffectedData
 	ErrorInvalidReference *ErrInvalidReference
+	ErrorUploadIncomplete *ErrUploadIncomplete
+	ErrorTooLarge         *ErrTooLarge
 )
 
 type ErrNotFound struct {
@@ -63,3 +65,33 @@ func (e *ErrInvalidReference) Error() string {
 func NewErrInvalidReference(resource, field, value string) error {
 	return &ErrInvalidReference{Resource: resource, Field: field, Value: value}
 }
+
+// ErrUploadIncomplete is returned when completing the upload of a file whose object is missing or does not match
+// the file.
+type ErrUploadIncomplete struct {
+	Id     string
+	Reason string
+}
+
+func (e *ErrUploadIncomplete) Error() string {
+	return fmt.Sprintf("upload of file with id '%s' is incomplete: %s", e.Id, e.Reason)
+}
+
+func NewErrUploadIncomplete(id, reason string) error {
+	return &ErrUploadIncomplete{Id: id, Reason: reason}
+}
+
+// ErrTooLarge is returned when the size of a resource exceeds the maximum size allowed.
+type ErrTooLarge struct {
+	Resource string
+	Size     int64
+	MaxSize  int64
+}
+
+func (e *ErrTooLarge) Error() string {
+	return fmt.Sprintf("%s of %d bytes exceeds the maximum size of %d bytes", e.Resource, e.Size, e.MaxSize)
+}
+
+func NewErrTooLarge(resource string, size, maxSize int64) error {
+	return &ErrTooLarge{Resource: resource, Size: size, MaxSize: maxSize}
+}
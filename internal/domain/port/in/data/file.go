@@ -0,0 +1,8 @@
+package data
+
+// FileCreateInput declares a file before its upload: its content must be of Size bytes and of the MimeType content type.
+type FileCreateInput struct {
+	Name     string
+	Size     int64
+	MimeType string
+}
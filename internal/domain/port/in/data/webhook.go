@@ -0,0 +1,35 @@
+package data
+
+import (
+	"github.com/google/uuid"
+)
+
+type WebhookCreateInput struct {
+	Url        string
+	EventTypes []string
+	// Secret signs the deliveries; a random one is generated when empty.
+	Secret string
+}
+
+type WebhookUpdateInput struct {
+	Id         uuid.UUID
+	Url        string
+	EventTypes []string
+	// Secret replaces the signing secret when set.
+	Secret Optional[string]
+	// Enabled set to true enables a disabled subscription again and resets its failures.
+	Enabled bool
+}
+
+type WebhookDeliveryListInput struct {
+	SubscriptionId uuid.UUID
+	Offset         int
+	Limit          int
+}
+
+// WebhookEventInput is an event to deliver to the subscriptions of its type. Payload is the body sent as is.
+type WebhookEventInput struct {
+	Id      string
+	Type    string
+	Payload []byte
+}
@@ -1,3 +1,24 @@
 package service
 
-type IFileService interface{}
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+)
+
+// IFileService defines the interface for managing the files that the clients upload straight to the object storage.
+// Create registers a pending file and returns it along with the request uploading its content.
+// Complete verifies the uploaded content and marks the file complete, or failed when the content does not match it.
+// GetByID fetches a file along with the request downloading its content, which is nil until its upload is completed.
+// DeleteByID removes a file along with its content.
+// ExpireUploads removes a batch of the files whose upload is incomplete after the upload TTL, and returns their number.
+type IFileService interface {
+	Create(ctx context.Context, input data.FileCreateInput) (*model.File, *model.PresignedRequest, error)
+	Complete(ctx context.Context, id uuid.UUID) (*model.File, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*model.File, *model.PresignedRequest, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	ExpireUploads(ctx context.Context) (int, error)
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+)
+
+// IWebhookService defines the interface for managing the webhook subscriptions and delivering events to them.
+// GetAll retrieves a paginated list of subscriptions.
+// GetByID fetches a subscription by its unique identifier.
+// Create registers a new subscription and returns it along with its secret.
+// Update modifies an existing subscription and returns the persisted instance.
+// DeleteByID removes a subscription and its deliveries.
+// GetDeliveries retrieves the deliveries of a subscription, most recent first.
+// Enqueue schedules the delivery of an event to the enabled subscriptions of its type.
+// DeliverPending attempts the deliveries that are due and returns how many were attempted.
+type IWebhookService interface {
+	GetAll(ctx context.Context, input data.PaginationOffset) ([]*model.WebhookSubscription, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error)
+	Create(ctx context.Context, input data.WebhookCreateInput) (*model.WebhookSubscription, error)
+	Update(ctx context.Context, input data.WebhookUpdateInput) (*model.WebhookSubscription, error)
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	GetDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error)
+	Enqueue(ctx context.Context, input data.WebhookEventInput) error
+	DeliverPending(ctx context.Context) (int, error)
+}
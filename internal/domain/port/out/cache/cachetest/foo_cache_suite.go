@@ -0,0 +1,182 @@
+// Package cachetest provides conformance suites asserting that the implementations of the cache ports behave alike,
+// whatever their storage.
+package cachetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// FooCacheFactory returns an empty IFooCache. It is called once per test of the suite.
+type FooCacheFactory func(t *testing.T) cache.IFooCache
+
+var (
+	fooID        = uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	fooIDMissing = uuid.MustParse("40400000-0000-0000-0000-000000000000")
+)
+
+// RunFooCacheSuite runs the IFooCache conformance suite against the caches created by factory. The tests run one
+// after the other, so that the factory may reset a shared storage. The expiration tests wait for entries of a few
+// hundred milliseconds to expire.
+func RunFooCacheSuite(t *testing.T, factory FooCacheFactory) {
+	t.Run("GetByID", func(t *testing.T) {
+		testFooCacheGetByID(t, factory)
+	})
+	t.Run("Expiration", func(t *testing.T) {
+		testFooCacheExpiration(t, factory)
+	})
+	t.Run("SetMissing", func(t *testing.T) {
+		testFooCacheSetMissing(t, factory)
+	})
+	t.Run("DeleteByID", func(t *testing.T) {
+		testFooCacheDeleteByID(t, factory)
+	})
+}
+
+func testFooCacheGetByID(t *testing.T, factory FooCacheFactory) {
+	ctx := context.Background()
+	fooCache := factory(t)
+	foo := newFoo()
+	assert.NoError(t, fooCache.Set(ctx, foo, model.CacheTTL{Fresh: time.Minute, Stale: time.Minute}))
+
+	t.Run("Success Case", func(t *testing.T) {
+		entry, err := fooCache.GetByID(ctx, fooID)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.False(t, entry.Missing)
+			assert.True(t, entry.Fresh(time.Now()))
+			assert.WithinDuration(t, time.Now().Add(time.Minute), entry.FreshUntil, 5*time.Second)
+			if assert.NotNil(t, entry.Foo) {
+				assert.Equal(t, foo.Id, entry.Foo.Id)
+				assert.Equal(t, foo.Label, entry.Foo.Label)
+				assert.Equal(t, foo.Secret, entry.Foo.Secret)
+				assert.Equal(t, foo.Value, entry.Foo.Value)
+				assert.Equal(t, foo.Weight, entry.Foo.Weight)
+				assert.True(t, foo.CreatedAt.Equal(entry.Foo.CreatedAt))
+			}
+		}
+	})
+
+	t.Run("Success Case - Miss", func(t *testing.T) {
+		entry, err := fooCache.GetByID(ctx, fooIDMissing)
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func testFooCacheExpiration(t *testing.T, factory FooCacheFactory) {
+	ctx := context.Background()
+	fooCache := factory(t)
+	staleID := uuid.MustParse("20000000-0000-0000-0000-000000000002")
+	stale := newFoo()
+	stale.Id = staleID
+
+	assert.NoError(t, fooCache.Set(ctx, newFoo(), model.CacheTTL{Fresh: 100 * time.Millisecond, Stale: 100 * time.Millisecond}))
+	assert.NoError(t, fooCache.Set(ctx, stale, model.CacheTTL{Fresh: 100 * time.Millisecond, Stale: time.Minute}))
+	assert.NoError(t, fooCache.SetMissing(ctx, fooIDMissing, 100*time.Millisecond))
+	time.Sleep(300 * time.Millisecond)
+
+	t.Run("Success Case - Stale", func(t *testing.T) {
+		entry, err := fooCache.GetByID(ctx, staleID)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.False(t, entry.Fresh(time.Now()))
+		}
+	})
+
+	t.Run("Success Case - Expired", func(t *testing.T) {
+		entry, err := fooCache.GetByID(ctx, fooID)
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("Success Case - Expired tombstone", func(t *testing.T) {
+		entry, err := fooCache.GetByID(ctx, fooIDMissing)
+
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+}
+
+func testFooCacheSetMissing(t *testing.T, factory FooCacheFactory) {
+	ctx := context.Background()
+	fooCache := factory(t)
+	assert.NoError(t, fooCache.Set(ctx, newFoo(), model.CacheTTL{Fresh: time.Minute}))
+
+	t.Run("Success Case - Tombstone", func(t *testing.T) {
+		assert.NoError(t, fooCache.SetMissing(ctx, fooIDMissing, time.Minute))
+
+		entry, err := fooCache.GetByID(ctx, fooIDMissing)
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.True(t, entry.Missing)
+			assert.Nil(t, entry.Foo)
+		}
+	})
+
+	t.Run("Success Case - Existing Foo kept", func(t *testing.T) {
+		assert.NoError(t, fooCache.SetMissing(ctx, fooID, time.Minute))
+
+		entry, err := fooCache.GetByID(ctx, fooID)
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.False(t, entry.Missing)
+			assert.NotNil(t, entry.Foo)
+		}
+	})
+
+	t.Run("Success Case - Tombstone replaced by Set", func(t *testing.T) {
+		foo := newFoo()
+		foo.Id = fooIDMissing
+		assert.NoError(t, fooCache.Set(ctx, foo, model.CacheTTL{Fresh: time.Minute}))
+
+		entry, err := fooCache.GetByID(ctx, fooIDMissing)
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.False(t, entry.Missing)
+			if assert.NotNil(t, entry.Foo) {
+				assert.Equal(t, fooIDMissing, entry.Foo.Id)
+			}
+		}
+	})
+}
+
+func testFooCacheDeleteByID(t *testing.T, factory FooCacheFactory) {
+	ctx := context.Background()
+	fooCache := factory(t)
+	assert.NoError(t, fooCache.Set(ctx, newFoo(), model.CacheTTL{Fresh: time.Minute}))
+
+	t.Run("Success Case", func(t *testing.T) {
+		assert.NoError(t, fooCache.DeleteByID(ctx, fooID))
+
+		entry, err := fooCache.GetByID(ctx, fooID)
+		assert.NoError(t, err)
+		assert.Nil(t, entry)
+	})
+
+	t.Run("Success Case - Not exist", func(t *testing.T) {
+		assert.NoError(t, fooCache.DeleteByID(ctx, fooIDMissing))
+	})
+}
+
+func newFoo() *model.Foo {
+	return &model.Foo{
+		Id:        fooID,
+		Label:     "foo1",
+		Secret:    "secret1",
+		Value:     1,
+		Weight:    1.5,
+		CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+}
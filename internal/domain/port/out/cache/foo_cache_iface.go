@@ -2,20 +2,22 @@ package cache
 
 import (
 	"context"
+	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 
 	"github.com/google/uuid"
-
-	"time"
 )
 
 // IFooCache defines a port for caching operations related to Foo entities.
-// GetByID retrieves a Foo entity from the cache by its UUID. Returns an error if the operation fails.
-// Set stores a Foo entity in the cache with the specified expiration duration. Returns an error if the operation fails.
+// GetByID retrieves a Foo entry from the cache by its UUID, or nil when it is absent. Returns an error if the operation fails.
+// Set stores a Foo entity in the cache, fresh for ttl.Fresh and evicted after ttl.Expiration(). It replaces any tombstone
+// stored for the same id. Returns an error if the operation fails.
+// SetMissing stores a tombstone recording that no Foo exists with the given UUID, evicted after ttl. Returns an error if the operation fails.
 // DeleteByID removes a Foo entity from the cache using its UUID. Returns an error if the operation fails.
 type IFooCache interface {
-	GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error)
-	Set(ctx context.Context, foo *model.Foo, expiration time.Duration) error
+	GetByID(ctx context.Context, id uuid.UUID) (*model.FooCacheEntry, error)
+	Set(ctx context.Context, foo *model.Foo, ttl model.CacheTTL) error
+	SetMissing(ctx context.Context, id uuid.UUID, ttl time.Duration) error
 	DeleteByID(ctx context.Context, id uuid.UUID) error
 }
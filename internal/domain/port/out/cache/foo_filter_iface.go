@@ -0,0 +1,18 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// IFooFilter defines a port for a probabilistic set of existing Foo ids, such as a Bloom filter.
+// Add records ids of existing Foo entities. Returns an error if the operation fails.
+// MightContain reports false only when no Foo exists with the given UUID. It reports true as long as the filter has not
+// been populated with the ids already stored, since it cannot be trusted yet. Returns an error if the operation fails.
+// MarkPopulated records that every existing id has been added to the filter. Returns an error if the operation fails.
+type IFooFilter interface {
+	Add(ctx context.Context, ids ...uuid.UUID) error
+	MightContain(ctx context.Context, id uuid.UUID) (bool, error)
+	MarkPopulated(ctx context.Context) error
+}
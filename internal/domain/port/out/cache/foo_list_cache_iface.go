@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+)
+
+// IFooListCache defines a port for caching the results of Foo list queries, tagged with the ids they contain.
+// GetList retrieves the cached result of a query; found is false on a miss. Returns an error if the operation fails.
+// SetList stores the result of a query for ttl and tags it with the id of every Foo it contains. Returns an error if the operation fails.
+// InvalidateByIDs removes every cached result containing one of the given UUIDs. Returns an error if the operation fails.
+// InvalidateAll removes every cached result. Returns an error if the operation fails.
+type IFooListCache interface {
+	GetList(ctx context.Context, input data.FooReadListInput) (foos []*model.Foo, found bool, err error)
+	SetList(ctx context.Context, input data.FooReadListInput, foos []*model.Foo, ttl time.Duration) error
+	InvalidateByIDs(ctx context.Context, ids ...uuid.UUID) error
+	InvalidateAll(ctx context.Context) error
+}
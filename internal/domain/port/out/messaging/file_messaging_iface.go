@@ -1,3 +1,19 @@
 package messaging
 
-type IFileMessaging interface{}
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// IFileMessaging defines a port for publishing events related to files.
+// PublishFileCreated sends a message when a file is registered, before its upload.
+// PublishFileUploaded sends a message when the upload of a file is completed.
+// PublishFileDeleted sends a message when a file is deleted.
+type IFileMessaging interface {
+	PublishFileCreated(ctx context.Context, file *model.File) error
+	PublishFileUploaded(ctx context.Context, file *model.File) error
+	PublishFileDeleted(ctx context.Context, id uuid.UUID) error
+}
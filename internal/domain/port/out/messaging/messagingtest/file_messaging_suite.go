@@ -0,0 +1,106 @@
+package messagingtest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// FileMessagingFactory returns an IFileMessaging along with the channel receiving the events it publishes from now
+// on. It is called once per test of the suite.
+type FileMessagingFactory func(t *testing.T) (messaging.IFileMessaging, <-chan Received)
+
+var fileID = uuid.MustParse("30000000-0000-0000-0000-000000000001")
+
+// RunFileMessagingSuite runs the IFileMessaging conformance suite against the adapters created by factory. The tests
+// run one after the other, so that the events of a test are not received by another.
+func RunFileMessagingSuite(t *testing.T, factory FileMessagingFactory) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	uploadedAt := createdAt.Add(time.Minute)
+	file := &model.File{
+		Id:        fileID,
+		Name:      "report.pdf",
+		Size:      1024,
+		Extension: ".pdf",
+		MimeType:  "application/pdf",
+		Path:      "files/" + fileID.String() + ".pdf",
+		Status:    model.UploadStatusPending,
+		CreatedAt: createdAt,
+	}
+	uploaded := *file
+	uploaded.Status = model.UploadStatusComplete
+	uploaded.UploadedAt = uploadedAt
+
+	testCases := []struct {
+		name       string
+		publish    func(ctx context.Context, fileMessaging messaging.IFileMessaging) error
+		definition events.Definition
+		time       *time.Time
+	}{
+		{
+			name: "Success Case - Created",
+			publish: func(ctx context.Context, fileMessaging messaging.IFileMessaging) error {
+				return fileMessaging.PublishFileCreated(ctx, file)
+			},
+			definition: events.FileCreatedV1,
+			time:       &createdAt,
+		},
+		{
+			name: "Success Case - Uploaded",
+			publish: func(ctx context.Context, fileMessaging messaging.IFileMessaging) error {
+				return fileMessaging.PublishFileUploaded(ctx, &uploaded)
+			},
+			definition: events.FileUploadedV1,
+			time:       &uploadedAt,
+		},
+		{
+			name: "Success Case - Deleted",
+			publish: func(ctx context.Context, fileMessaging messaging.IFileMessaging) error {
+				return fileMessaging.PublishFileDeleted(ctx, fileID)
+			},
+			definition: events.FileDeletedV1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			fileMessaging, received := factory(t)
+
+			assert.NoError(t, testCase.publish(ctx, fileMessaging))
+			assert.NoError(t, testCase.publish(ctx, fileMessaging))
+			first, ok := receive(t, received)
+			if !ok {
+				return
+			}
+			second, ok := receive(t, received)
+			if !ok {
+				return
+			}
+			assert.Equal(t, first.Event.ID, second.Event.ID)
+
+			assert.Equal(t, testCase.definition.Subject, first.Subject)
+			assert.Equal(t, testCase.definition.Type, first.Event.Type)
+			assert.Equal(t, testCase.definition.DataSchema(), first.Event.DataSchema)
+			assert.Equal(t, fileID.String(), first.Event.Subject)
+			if testCase.time != nil && assert.NotNil(t, first.Event.Time) {
+				assert.True(t, testCase.time.Equal(*first.Event.Time))
+			}
+			assert.NoError(t, events.Default.Validate(testCase.definition.Type, testCase.definition.Version, first.Event.Data))
+
+			var data struct {
+				Id uuid.UUID `json:"id"`
+			}
+			assert.NoError(t, json.Unmarshal(first.Event.Data, &data))
+			assert.Equal(t, fileID, data.Id)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+// Package messagingtest provides conformance suites asserting that the implementations of the messaging ports
+// publish alike, whatever their transport.
+package messagingtest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// receiveTimeout bounds the wait for a published event.
+const receiveTimeout = 5 * time.Second
+
+// Received is an event published by the adapter under test, along with the subject it was published on.
+type Received struct {
+	Subject string
+	Event   cloudevents.Event
+}
+
+// FooMessagingFactory returns an IFooMessaging along with the channel receiving the events it publishes from now on.
+// It is called once per test of the suite.
+type FooMessagingFactory func(t *testing.T) (messaging.IFooMessaging, <-chan Received)
+
+var fooID = uuid.MustParse("20000000-0000-0000-0000-000000000001")
+
+// RunFooMessagingSuite runs the IFooMessaging conformance suite against the adapters created by factory. The tests
+// run one after the other, so that the events of a test are not received by another.
+func RunFooMessagingSuite(t *testing.T, factory FooMessagingFactory) {
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+	foo := &model.Foo{Id: fooID, Label: "foo1", Secret: "secret1", Value: 1, Weight: 1.5, CreatedAt: createdAt}
+	updated := *foo
+	updated.UpdatedAt = &updatedAt
+
+	testCases := []struct {
+		name       string
+		publish    func(ctx context.Context, fooMessaging messaging.IFooMessaging) error
+		definition events.Definition
+		time       *time.Time
+	}{
+		{
+			name: "Success Case - Created",
+			publish: func(ctx context.Context, fooMessaging messaging.IFooMessaging) error {
+				return fooMessaging.PublishFooCreated(ctx, foo)
+			},
+			definition: events.FooCreatedV1,
+			time:       &createdAt,
+		},
+		{
+			name: "Success Case - Updated",
+			publish: func(ctx context.Context, fooMessaging messaging.IFooMessaging) error {
+				return fooMessaging.PublishFooUpdated(ctx, &updated)
+			},
+			definition: events.FooUpdatedV1,
+			time:       &updatedAt,
+		},
+		{
+			name: "Success Case - Deleted",
+			publish: func(ctx context.Context, fooMessaging messaging.IFooMessaging) error {
+				return fooMessaging.PublishFooDeleted(ctx, fooID)
+			},
+			definition: events.FooDeletedV1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := context.Background()
+			fooMessaging, received := factory(t)
+
+			// Publishing the same change twice yields the same event id, which lets the consumers deduplicate it.
+			assert.NoError(t, testCase.publish(ctx, fooMessaging))
+			assert.NoError(t, testCase.publish(ctx, fooMessaging))
+			first, ok := receive(t, received)
+			if !ok {
+				return
+			}
+			second, ok := receive(t, received)
+			if !ok {
+				return
+			}
+			assert.Equal(t, first.Event.ID, second.Event.ID)
+
+			assert.Equal(t, testCase.definition.Subject, first.Subject)
+			assert.NotEmpty(t, first.Event.ID)
+			assert.Equal(t, testCase.definition.Type, first.Event.Type)
+			assert.Equal(t, testCase.definition.DataSchema(), first.Event.DataSchema)
+			assert.Equal(t, fooID.String(), first.Event.Subject)
+			if testCase.time != nil && assert.NotNil(t, first.Event.Time) {
+				assert.True(t, testCase.time.Equal(*first.Event.Time))
+			}
+			assert.NoError(t, events.Default.Validate(testCase.definition.Type, testCase.definition.Version, first.Event.Data))
+
+			var data struct {
+				Id uuid.UUID `json:"id"`
+			}
+			assert.NoError(t, json.Unmarshal(first.Event.Data, &data))
+			assert.Equal(t, fooID, data.Id)
+		})
+	}
+}
+
+func receive(t *testing.T, received <-chan Received) (Received, bool) {
+	t.Helper()
+	select {
+	case r := <-received:
+		return r, true
+	case <-time.After(receiveTimeout):
+		t.Error("no event received")
+		return Received{}, false
+	}
+}
@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+)
+
+// IRateLimiter defines a port for counting requests against a rate limit.
+// Allow consumes one request for the given key and reports whether it fits within the limit. Returns an error if the backend is unavailable.
+type IRateLimiter interface {
+	Allow(ctx context.Context, key string, limit model.RateLimit) (*model.RateLimitResult, error)
+}
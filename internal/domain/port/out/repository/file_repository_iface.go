@@ -2,15 +2,21 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
 	"github.com/google/uuid"
 )
 
+// IFileRepository defines a port for storing the metadata of the files.
+// FindIncompleteBefore returns up to limit pending or failed files created before the given time, the oldest first.
+// Update modifies a pending file, and returns a port.ErrNoAffectedData when the file is no longer pending, so that a
+// single one of concurrent updates settles its upload.
 type IFileRepository interface {
 	FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.File, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.File, error)
+	FindIncompleteBefore(ctx context.Context, before time.Time, limit int) ([]*model.File, error)
 	Create(ctx context.Context, file *model.File) error
 	Update(ctx context.Context, file *model.File) error
 	DeleteByID(ctx context.Context, id uuid.UUID) error
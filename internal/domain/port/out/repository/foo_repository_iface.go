@@ -15,6 +15,7 @@ import (
 // Create adds a new Foo entity to the repository.
 // Update modifies an existing Foo entity in the repository.
 // DeleteByID removes a Foo entity by its unique identifier from the repository.
+// FindByID, Update and DeleteByID return a port.ErrNotFound when no Foo exists with the given identifier.
 type IFooRepository interface {
 	FindAll(ctx context.Context, pagination data.FooReadListInput) ([]*model.Foo, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*model.Foo, error)
@@ -0,0 +1,338 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// FileRepositoryFactory returns an empty IFileRepository. It is called once per test of the suite.
+type FileRepositoryFactory func(t *testing.T) repository.IFileRepository
+
+var (
+	fileID1       = uuid.MustParse("30000000-0000-0000-0000-000000000001")
+	fileID2       = uuid.MustParse("30000000-0000-0000-0000-000000000002")
+	fileID3       = uuid.MustParse("30000000-0000-0000-0000-000000000003")
+	fileIDMissing = uuid.MustParse("40400000-0000-0000-0000-000000000000")
+
+	// fileCreatedAt is the creation time of the seeded files, whose creation times follow from the last digit of
+	// their id. It has no sub-microsecond part, which the databases would drop.
+	fileCreatedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+// RunFileRepositorySuite runs the IFileRepository conformance suite against the repositories created by factory.
+// The tests run one after the other, so that the factory may reset a shared storage.
+func RunFileRepositorySuite(t *testing.T, factory FileRepositoryFactory) {
+	t.Run("FindAll", func(t *testing.T) {
+		testFileRepositoryFindAll(t, factory)
+	})
+	t.Run("FindByID", func(t *testing.T) {
+		testFileRepositoryFindByID(t, factory)
+	})
+	t.Run("FindIncompleteBefore", func(t *testing.T) {
+		testFileRepositoryFindIncompleteBefore(t, factory)
+	})
+	t.Run("Create", func(t *testing.T) {
+		testFileRepositoryCreate(t, factory)
+	})
+	t.Run("Update", func(t *testing.T) {
+		testFileRepositoryUpdate(t, factory)
+	})
+	t.Run("DeleteByID", func(t *testing.T) {
+		testFileRepositoryDeleteByID(t, factory)
+	})
+}
+
+func testFileRepositoryFindAll(t *testing.T, factory FileRepositoryFactory) {
+	testCases := []struct {
+		name        string
+		input       data.PaginationOffset
+		expectedIDs []uuid.UUID
+	}{
+		{
+			name:        "Success Case - Ordered by creation time",
+			input:       data.PaginationOffset{Offset: 0, Limit: 20},
+			expectedIDs: []uuid.UUID{fileID3, fileID2, fileID1},
+		},
+		{
+			name:        "Success Case - With Offset",
+			input:       data.PaginationOffset{Offset: 1, Limit: 20},
+			expectedIDs: []uuid.UUID{fileID2, fileID1},
+		},
+		{
+			name:        "Success Case - With Limit",
+			input:       data.PaginationOffset{Offset: 0, Limit: 2},
+			expectedIDs: []uuid.UUID{fileID3, fileID2},
+		},
+		{
+			name:  "Success Case - Offset past the end",
+			input: data.PaginationOffset{Offset: 3, Limit: 20},
+		},
+	}
+
+	repo := factory(t)
+	seedFiles(t, repo, fileID1, fileID3, fileID2)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := repo.FindAll(context.Background(), testCase.input)
+
+			assert.NoError(t, err)
+			ids := make([]uuid.UUID, 0, len(files))
+			for _, file := range files {
+				ids = append(ids, file.Id)
+			}
+			if len(testCase.expectedIDs) == 0 {
+				assert.Empty(t, ids)
+				return
+			}
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func testFileRepositoryFindByID(t *testing.T, factory FileRepositoryFactory) {
+	repo := factory(t)
+	seedFiles(t, repo, fileID1)
+
+	t.Run("Success Case", func(t *testing.T) {
+		file, err := repo.FindByID(context.Background(), fileID1)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, file) {
+			assertFile(t, newFile(fileID1), file)
+			assert.Zero(t, file.UploadedAt)
+			assert.Zero(t, file.UpdatedAt)
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		file, err := repo.FindByID(context.Background(), fileIDMissing)
+
+		assert.Nil(t, file)
+		assertFileNotFound(t, err, fileIDMissing)
+	})
+}
+
+func testFileRepositoryFindIncompleteBefore(t *testing.T, factory FileRepositoryFactory) {
+	testCases := []struct {
+		name        string
+		before      time.Time
+		limit       int
+		expectedIDs []uuid.UUID
+	}{
+		{
+			name:        "Success Case - Oldest first",
+			before:      fileCreatedAt,
+			limit:       20,
+			expectedIDs: []uuid.UUID{fileID3, fileID1},
+		},
+		{
+			name:        "Success Case - With Limit",
+			before:      fileCreatedAt,
+			limit:       1,
+			expectedIDs: []uuid.UUID{fileID3},
+		},
+		{
+			name:        "Success Case - Created before",
+			before:      fileCreatedAt.Add(-2 * time.Minute),
+			limit:       20,
+			expectedIDs: []uuid.UUID{fileID3},
+		},
+		{
+			name:   "Success Case - None",
+			before: fileCreatedAt.Add(-time.Hour),
+			limit:  20,
+		},
+	}
+
+	ctx := context.Background()
+	repo := factory(t)
+	seedFiles(t, repo, fileID1, fileID2, fileID3)
+	settleFile(t, repo, fileID1, model.UploadStatusFailed)
+	settleFile(t, repo, fileID2, model.UploadStatusComplete)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			files, err := repo.FindIncompleteBefore(ctx, testCase.before, testCase.limit)
+
+			assert.NoError(t, err)
+			ids := make([]uuid.UUID, 0, len(files))
+			for _, file := range files {
+				ids = append(ids, file.Id)
+			}
+			if len(testCase.expectedIDs) == 0 {
+				assert.Empty(t, ids)
+				return
+			}
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func testFileRepositoryCreate(t *testing.T, factory FileRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+
+	t.Run("Success Case", func(t *testing.T) {
+		before := time.Now().Add(-time.Minute)
+		input := newFile(fileID1)
+		input.CreatedAt = time.Time{}
+
+		assert.NoError(t, repo.Create(ctx, input))
+		assert.True(t, input.CreatedAt.After(before), "the repository sets the creation time of the input")
+
+		file, err := repo.FindByID(ctx, fileID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, file) {
+			assert.Equal(t, input.Name, file.Name)
+			assert.WithinDuration(t, input.CreatedAt, file.CreatedAt, time.Millisecond)
+		}
+	})
+
+	t.Run("Failure Case - Duplicate id", func(t *testing.T) {
+		duplicate := newFile(fileID1)
+		duplicate.Name = "duplicate.txt"
+
+		assert.Error(t, repo.Create(ctx, duplicate))
+
+		file, err := repo.FindByID(ctx, fileID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, file) {
+			assert.Equal(t, "file1.txt", file.Name)
+		}
+	})
+}
+
+func testFileRepositoryUpdate(t *testing.T, factory FileRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+	seedFiles(t, repo, fileID1)
+
+	t.Run("Success Case", func(t *testing.T) {
+		update := newFile(fileID1)
+		update.Status = model.UploadStatusComplete
+		update.UploadedAt = fileCreatedAt.Add(time.Hour)
+
+		assert.NoError(t, repo.Update(ctx, update))
+		assert.NotZero(t, update.UpdatedAt, "the repository sets the update time of the input")
+
+		file, err := repo.FindByID(ctx, fileID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, file) {
+			assertFile(t, update, file)
+			assert.True(t, update.UploadedAt.Equal(file.UploadedAt))
+			assert.WithinDuration(t, update.UpdatedAt, file.UpdatedAt, time.Millisecond)
+		}
+	})
+
+	t.Run("Failure Case - Not pending", func(t *testing.T) {
+		update := newFile(fileID1)
+		update.Status = model.UploadStatusFailed
+
+		err := repo.Update(ctx, update)
+
+		var noAffectedData *port.ErrNoAffectedData
+		if assert.ErrorAs(t, err, &noAffectedData) {
+			assert.Equal(t, port.ErrNoAffectedData{Resource: "file", ID: fileID1.String()}, *noAffectedData)
+		}
+		file, err := repo.FindByID(ctx, fileID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, file) {
+			assert.Equal(t, model.UploadStatusComplete, file.Status)
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		err := repo.Update(ctx, newFile(fileIDMissing))
+
+		assertFileNotFound(t, err, fileIDMissing)
+	})
+}
+
+func testFileRepositoryDeleteByID(t *testing.T, factory FileRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+	seedFiles(t, repo, fileID1, fileID2)
+
+	t.Run("Success Case", func(t *testing.T) {
+		assert.NoError(t, repo.DeleteByID(ctx, fileID1))
+
+		_, err := repo.FindByID(ctx, fileID1)
+		assertFileNotFound(t, err, fileID1)
+		files, err := repo.FindAll(ctx, data.PaginationOffset{Offset: 0, Limit: 20})
+		assert.NoError(t, err)
+		if assert.Len(t, files, 1) {
+			assert.Equal(t, fileID2, files[0].Id)
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		err := repo.DeleteByID(ctx, fileIDMissing)
+
+		assertFileNotFound(t, err, fileIDMissing)
+	})
+}
+
+// newFile returns the pending File seeded with the given id, whose fields derive from the last digit of the id. The
+// higher the digit, the sooner the file was created.
+func newFile(id uuid.UUID) *model.File {
+	n := int(id[15])
+	return &model.File{
+		Id:        id,
+		Name:      "file" + string(rune('0'+n)) + ".txt",
+		Size:      int64(n),
+		Extension: ".txt",
+		MimeType:  "text/plain",
+		Path:      "files/" + id.String() + ".txt",
+		Status:    model.UploadStatusPending,
+		CreatedAt: fileCreatedAt.Add(-time.Duration(n) * time.Minute),
+	}
+}
+
+func seedFiles(t *testing.T, repo repository.IFileRepository, ids ...uuid.UUID) {
+	t.Helper()
+	for _, id := range ids {
+		if err := repo.Create(context.Background(), newFile(id)); err != nil {
+			t.Fatalf("fail to seed file %s: %v", id, err)
+		}
+	}
+}
+
+// settleFile updates the status of the pending file with the given id.
+func settleFile(t *testing.T, repo repository.IFileRepository, id uuid.UUID, status model.UploadStatus) {
+	t.Helper()
+	file := newFile(id)
+	file.Status = status
+	if err := repo.Update(context.Background(), file); err != nil {
+		t.Fatalf("fail to settle file %s: %v", id, err)
+	}
+}
+
+func assertFile(t *testing.T, expected, actual *model.File) {
+	t.Helper()
+	assert.Equal(t, expected.Id, actual.Id)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Size, actual.Size)
+	assert.Equal(t, expected.Extension, actual.Extension)
+	assert.Equal(t, expected.MimeType, actual.MimeType)
+	assert.Equal(t, expected.Path, actual.Path)
+	assert.Equal(t, expected.Status, actual.Status)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "created at %v, expected %v", actual.CreatedAt, expected.CreatedAt)
+}
+
+func assertFileNotFound(t *testing.T, err error, id uuid.UUID) {
+	t.Helper()
+	var notFound *port.ErrNotFound
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, port.ErrNotFound{Resource: "file", Field: "id", Value: id.String()}, *notFound)
+	}
+}
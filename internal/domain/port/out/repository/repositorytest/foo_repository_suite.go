@@ -0,0 +1,245 @@
+// Package repositorytest provides conformance suites asserting that the implementations of the repository ports
+// behave alike, whatever their storage.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// FooRepositoryFactory returns an empty IFooRepository. It is called once per test of the suite.
+type FooRepositoryFactory func(t *testing.T) repository.IFooRepository
+
+var (
+	fooID1       = uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	fooID2       = uuid.MustParse("20000000-0000-0000-0000-000000000002")
+	fooID3       = uuid.MustParse("20000000-0000-0000-0000-000000000003")
+	fooIDMissing = uuid.MustParse("40400000-0000-0000-0000-000000000000")
+)
+
+// RunFooRepositorySuite runs the IFooRepository conformance suite against the repositories created by factory.
+// The tests run one after the other, so that the factory may reset a shared storage.
+func RunFooRepositorySuite(t *testing.T, factory FooRepositoryFactory) {
+	t.Run("FindAll", func(t *testing.T) {
+		testFooRepositoryFindAll(t, factory)
+	})
+	t.Run("FindByID", func(t *testing.T) {
+		testFooRepositoryFindByID(t, factory)
+	})
+	t.Run("Create", func(t *testing.T) {
+		testFooRepositoryCreate(t, factory)
+	})
+	t.Run("Update", func(t *testing.T) {
+		testFooRepositoryUpdate(t, factory)
+	})
+	t.Run("DeleteByID", func(t *testing.T) {
+		testFooRepositoryDeleteByID(t, factory)
+	})
+}
+
+func testFooRepositoryFindAll(t *testing.T, factory FooRepositoryFactory) {
+	testCases := []struct {
+		name        string
+		input       data.FooReadListInput
+		expectedIDs []uuid.UUID
+	}{
+		{
+			name:        "Success Case - Ordered by id",
+			input:       data.FooReadListInput{Offset: 0, Limit: 20},
+			expectedIDs: []uuid.UUID{fooID1, fooID2, fooID3},
+		},
+		{
+			name:        "Success Case - With Offset",
+			input:       data.FooReadListInput{Offset: 1, Limit: 20},
+			expectedIDs: []uuid.UUID{fooID2, fooID3},
+		},
+		{
+			name:        "Success Case - With Limit",
+			input:       data.FooReadListInput{Offset: 0, Limit: 2},
+			expectedIDs: []uuid.UUID{fooID1, fooID2},
+		},
+		{
+			name:  "Success Case - Empty Limit",
+			input: data.FooReadListInput{Offset: 0, Limit: 0},
+		},
+		{
+			name:  "Success Case - Offset past the end",
+			input: data.FooReadListInput{Offset: 3, Limit: 20},
+		},
+	}
+
+	repo := factory(t)
+	seedFoos(t, repo, fooID3, fooID1, fooID2)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			foos, err := repo.FindAll(context.Background(), testCase.input)
+
+			assert.NoError(t, err)
+			ids := make([]uuid.UUID, 0, len(foos))
+			for _, foo := range foos {
+				ids = append(ids, foo.Id)
+			}
+			if len(testCase.expectedIDs) == 0 {
+				assert.Empty(t, ids)
+				return
+			}
+			assert.Equal(t, testCase.expectedIDs, ids)
+		})
+	}
+}
+
+func testFooRepositoryFindByID(t *testing.T, factory FooRepositoryFactory) {
+	repo := factory(t)
+	seedFoos(t, repo, fooID1)
+
+	t.Run("Success Case", func(t *testing.T) {
+		foo, err := repo.FindByID(context.Background(), fooID1)
+
+		assert.NoError(t, err)
+		if assert.NotNil(t, foo) {
+			assertFoo(t, newFoo(fooID1), foo)
+			assert.NotZero(t, foo.CreatedAt)
+			assert.Nil(t, foo.UpdatedAt)
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		foo, err := repo.FindByID(context.Background(), fooIDMissing)
+
+		assert.Nil(t, foo)
+		assertNotFound(t, err, fooIDMissing)
+	})
+}
+
+func testFooRepositoryCreate(t *testing.T, factory FooRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+
+	t.Run("Success Case", func(t *testing.T) {
+		before := time.Now().Add(-time.Minute)
+		input := newFoo(fooID1)
+		assert.NoError(t, repo.Create(ctx, input))
+		assert.True(t, input.CreatedAt.After(before), "the repository sets the creation time of the input")
+
+		foo, err := repo.FindByID(ctx, fooID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, foo) {
+			assertFoo(t, newFoo(fooID1), foo)
+			assert.True(t, foo.CreatedAt.After(before), "the repository sets the creation time")
+		}
+	})
+
+	t.Run("Failure Case - Duplicate id", func(t *testing.T) {
+		duplicate := newFoo(fooID1)
+		duplicate.Label = "duplicate"
+
+		assert.Error(t, repo.Create(ctx, duplicate))
+
+		foo, err := repo.FindByID(ctx, fooID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, foo) {
+			assert.Equal(t, "foo1", foo.Label)
+		}
+	})
+}
+
+func testFooRepositoryUpdate(t *testing.T, factory FooRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+	seedFoos(t, repo, fooID1)
+
+	t.Run("Success Case", func(t *testing.T) {
+		update := &model.Foo{Id: fooID1, Label: "foo_update", Secret: "secret_update", Value: 50, Weight: 1.5}
+
+		assert.NoError(t, repo.Update(ctx, update))
+		assert.NotNil(t, update.UpdatedAt, "the repository sets the update time of the input")
+
+		foo, err := repo.FindByID(ctx, fooID1)
+		assert.NoError(t, err)
+		if assert.NotNil(t, foo) {
+			assertFoo(t, update, foo)
+			assert.NotZero(t, foo.CreatedAt)
+			if assert.NotNil(t, foo.UpdatedAt) {
+				assert.WithinDuration(t, *update.UpdatedAt, *foo.UpdatedAt, time.Millisecond)
+			}
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		err := repo.Update(ctx, newFoo(fooIDMissing))
+
+		assertNotFound(t, err, fooIDMissing)
+	})
+}
+
+func testFooRepositoryDeleteByID(t *testing.T, factory FooRepositoryFactory) {
+	ctx := context.Background()
+	repo := factory(t)
+	seedFoos(t, repo, fooID1, fooID2)
+
+	t.Run("Success Case", func(t *testing.T) {
+		assert.NoError(t, repo.DeleteByID(ctx, fooID1))
+
+		_, err := repo.FindByID(ctx, fooID1)
+		assertNotFound(t, err, fooID1)
+		foos, err := repo.FindAll(ctx, data.FooReadListInput{Offset: 0, Limit: 20})
+		assert.NoError(t, err)
+		if assert.Len(t, foos, 1) {
+			assert.Equal(t, fooID2, foos[0].Id)
+		}
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		err := repo.DeleteByID(ctx, fooIDMissing)
+
+		assertNotFound(t, err, fooIDMissing)
+	})
+}
+
+// newFoo returns the Foo seeded with the given id, whose fields derive from the last digit of the id.
+func newFoo(id uuid.UUID) *model.Foo {
+	n := int(id[15])
+	return &model.Foo{
+		Id:     id,
+		Label:  "foo" + string(rune('0'+n)),
+		Secret: "secret" + string(rune('0'+n)),
+		Value:  n,
+		Weight: float32(n),
+	}
+}
+
+func seedFoos(t *testing.T, repo repository.IFooRepository, ids ...uuid.UUID) {
+	t.Helper()
+	for _, id := range ids {
+		if err := repo.Create(context.Background(), newFoo(id)); err != nil {
+			t.Fatalf("fail to seed foo %s: %v", id, err)
+		}
+	}
+}
+
+func assertFoo(t *testing.T, expected, actual *model.Foo) {
+	t.Helper()
+	assert.Equal(t, expected.Id, actual.Id)
+	assert.Equal(t, expected.Label, actual.Label)
+	assert.Equal(t, expected.Secret, actual.Secret)
+	assert.Equal(t, expected.Value, actual.Value)
+	assert.Equal(t, expected.Weight, actual.Weight)
+}
+
+func assertNotFound(t *testing.T, err error, id uuid.UUID) {
+	t.Helper()
+	var notFound *port.ErrNotFound
+	if assert.ErrorAs(t, err, &notFound) {
+		assert.Equal(t, port.ErrNotFound{Resource: "foo", Field: "id", Value: id.String()}, *notFound)
+	}
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+)
+
+// IWebhookRepository represents a port for storing the webhook subscriptions and their deliveries.
+// FindAll retrieves a paginated list of subscriptions.
+// FindByID fetches a subscription by its unique identifier.
+// FindByEventType fetches the enabled subscriptions receiving the events of a type.
+// Create adds a new subscription.
+// Update modifies an existing subscription.
+// DeleteByID removes a subscription along with its deliveries.
+// RecordSuccess resets the consecutive failures of a subscription.
+// RecordFailure counts a failure of a subscription, disables it once the failures reach threshold and reports whether it is disabled.
+// CreateDeliveries adds deliveries, ignoring the ones of an event already delivered to the same subscription.
+// FindDeliveries retrieves a paginated list of the deliveries of a subscription, most recent first.
+// ClaimDeliveries leases up to limit pending deliveries due at now, so that no other replica attempts them before the lease ends.
+// UpdateDelivery stores the outcome of an attempt.
+type IWebhookRepository interface {
+	FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.WebhookSubscription, error)
+	FindByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error)
+	FindByEventType(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error)
+	Create(ctx context.Context, subscription *model.WebhookSubscription) error
+	Update(ctx context.Context, subscription *model.WebhookSubscription) error
+	DeleteByID(ctx context.Context, id uuid.UUID) error
+	RecordSuccess(ctx context.Context, id uuid.UUID) error
+	RecordFailure(ctx context.Context, id uuid.UUID, threshold int) (bool, error)
+
+	CreateDeliveries(ctx context.Context, deliveries ...*model.WebhookDelivery) error
+	FindDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*model.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error
+}
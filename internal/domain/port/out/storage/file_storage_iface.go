@@ -3,10 +3,18 @@ package storage
 import (
 	"context"
 	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 )
 
+// IFileStorage defines a port for storing the files in an object storage, which the clients reach through presigned URLs.
+// PresignedDownloadURL returns a URL downloading the object at path, along with the headers the request must carry.
+// PresignedUploadURL returns a URL uploading the object at key with the given content type and size in bytes, along with the headers the request must carry. Uploads of another size are rejected.
+// Head describes the object at path, or returns a port.ErrNotFound when it does not exist.
+// Delete removes the object at path. Deleting a missing object succeeds.
 type IFileStorage interface {
 	PresignedDownloadURL(ctx context.Context, path string) (string, http.Header, error)
-	PresignedUploadURL(ctx context.Context, key, contentType string) (string, http.Header, error)
+	PresignedUploadURL(ctx context.Context, key, contentType string, size int64) (string, http.Header, error)
+	Head(ctx context.Context, path string) (*model.FileObject, error)
 	Delete(ctx context.Context, path string) error
 }
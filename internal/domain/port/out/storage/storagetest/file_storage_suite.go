@@ -0,0 +1,202 @@
+// Package storagetest provides conformance suites asserting that the implementations of the storage ports behave
+// alike, whatever their backend.
+package storagetest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// FileStorageFactory returns an IFileStorage whose presigned URLs are reachable by the test. It is called once per
+// test of the suite, each test using keys of its own.
+type FileStorageFactory func(t *testing.T) storage.IFileStorage
+
+// RunFileStorageSuite runs the IFileStorage conformance suite against the storages created by factory. The objects
+// are uploaded and downloaded through the presigned URLs, like the clients of the API do.
+func RunFileStorageSuite(t *testing.T, factory FileStorageFactory) {
+	t.Run("PresignedUploadURL", func(t *testing.T) {
+		testFileStorageUpload(t, factory)
+	})
+	t.Run("PresignedDownloadURL", func(t *testing.T) {
+		testFileStorageDownload(t, factory)
+	})
+	t.Run("Head", func(t *testing.T) {
+		testFileStorageHead(t, factory)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		testFileStorageDelete(t, factory)
+	})
+}
+
+func testFileStorageUpload(t *testing.T, factory FileStorageFactory) {
+	ctx := context.Background()
+	fileStorage := factory(t)
+	key := newKey()
+
+	t.Run("Success Case", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, upload(t, fileStorage, key, "text/plain", "hello"))
+
+		status, body, header := download(t, fileStorage, key)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "hello", body)
+		assert.Equal(t, "text/plain", header.Get("Content-Type"))
+		_, err := http.ParseTime(header.Get("Last-Modified"))
+		assert.NoError(t, err, "the download carries the modification date of the object")
+	})
+
+	t.Run("Success Case - Replace the object", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, upload(t, fileStorage, key, "application/json", `{"hello":"world"}`))
+
+		status, body, header := download(t, fileStorage, key)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `{"hello":"world"}`, body)
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+	})
+
+	t.Run("Failure Case - Size mismatch", func(t *testing.T) {
+		key := newKey()
+
+		assert.NotEqual(t, http.StatusOK, uploadSized(t, fileStorage, key, "text/plain", "hello world", 5))
+
+		_, err := fileStorage.Head(ctx, key)
+		assert.ErrorAs(t, err, &port.ErrorNotFound)
+	})
+
+	assert.NoError(t, fileStorage.Delete(ctx, key))
+}
+
+func testFileStorageDownload(t *testing.T, factory FileStorageFactory) {
+	fileStorage := factory(t)
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		status, _, _ := download(t, fileStorage, newKey())
+
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+}
+
+func testFileStorageHead(t *testing.T, factory FileStorageFactory) {
+	ctx := context.Background()
+	fileStorage := factory(t)
+
+	t.Run("Success Case", func(t *testing.T) {
+		key := newKey()
+		assert.Equal(t, http.StatusOK, upload(t, fileStorage, key, "text/plain", "hello"))
+
+		object, err := fileStorage.Head(ctx, key)
+		assert.NoError(t, err)
+		if assert.NotNil(t, object) {
+			assert.Equal(t, int64(5), object.Size)
+			assert.Equal(t, "text/plain", object.ContentType)
+			assert.False(t, object.ModifiedAt.IsZero())
+		}
+
+		assert.NoError(t, fileStorage.Delete(ctx, key))
+	})
+
+	t.Run("Failure Case - Not exist", func(t *testing.T) {
+		key := newKey()
+
+		object, err := fileStorage.Head(ctx, key)
+
+		assert.Nil(t, object)
+		var notFound *port.ErrNotFound
+		if assert.ErrorAs(t, err, &notFound) {
+			assert.Equal(t, port.ErrNotFound{Resource: "file object", Field: "path", Value: key}, *notFound)
+		}
+	})
+}
+
+func testFileStorageDelete(t *testing.T, factory FileStorageFactory) {
+	ctx := context.Background()
+	fileStorage := factory(t)
+
+	t.Run("Success Case", func(t *testing.T) {
+		key := newKey()
+		assert.Equal(t, http.StatusOK, upload(t, fileStorage, key, "text/plain", "hello"))
+
+		assert.NoError(t, fileStorage.Delete(ctx, key))
+
+		status, _, _ := download(t, fileStorage, key)
+		assert.Equal(t, http.StatusNotFound, status)
+	})
+
+	t.Run("Success Case - Not exist", func(t *testing.T) {
+		assert.NoError(t, fileStorage.Delete(ctx, newKey()))
+	})
+}
+
+// newKey returns a key no other test uses, with a space to check that the key is escaped in the URLs.
+func newKey() string {
+	return "storagetest/" + uuid.NewString() + "/test file.txt"
+}
+
+// upload sends the content to the presigned upload URL of key and returns the response status.
+func upload(t *testing.T, fileStorage storage.IFileStorage, key, contentType, content string) int {
+	t.Helper()
+	return uploadSized(t, fileStorage, key, contentType, content, int64(len(content)))
+}
+
+// uploadSized sends the content to the upload URL of key presigned for size bytes and returns the response status.
+func uploadSized(t *testing.T, fileStorage storage.IFileStorage, key, contentType, content string, size int64) int {
+	t.Helper()
+	url, header, err := fileStorage.PresignedUploadURL(context.Background(), key, contentType, size)
+	if err != nil {
+		t.Fatalf("fail to presign upload: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, url, strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("fail to create upload request: %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("fail to upload: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode
+}
+
+// download reads the object at key from its presigned download URL and returns the response status, body and
+// headers.
+func download(t *testing.T, fileStorage storage.IFileStorage, key string) (int, string, http.Header) {
+	t.Helper()
+	url, header, err := fileStorage.PresignedDownloadURL(context.Background(), key)
+	if err != nil {
+		t.Fatalf("fail to presign download: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("fail to create download request: %v", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("fail to download: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("fail to read download: %v", err)
+	}
+	return resp.StatusCode, string(body), resp.Header
+}
@@ -0,0 +1,15 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+)
+
+// IWebhookSender defines a port for posting a signed webhook to the endpoint of a partner.
+// CheckURL returns an error when the sender refuses to post to the url, such as one whose host is in a private network.
+// Send posts the body with the given headers and returns the status code of the response. An error means that no
+// response was received, such as on a timeout or a connection failure.
+type IWebhookSender interface {
+	CheckURL(ctx context.Context, url string) error
+	Send(ctx context.Context, url string, header http.Header, body []byte) (int, error)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
 
 	"github.com/coreos/go-oidc"
 	"go.uber.org/zap"
@@ -30,7 +31,7 @@ type AuthService struct {
 func (s *AuthService) VerifyToken(ctx context.Context, token string) (*oidc.IDToken, error) {
 	idToken, err := s.verifier.Verify(ctx, token)
 	if err != nil {
-		s.logger.Debug("failed to verify token", zap.Error(err))
+		correlation.Logger(ctx, s.logger).Debug("failed to verify token", zap.Error(err))
 		return nil, fmt.Errorf("failed to verify token: %w", err)
 	}
 	return idToken, nil
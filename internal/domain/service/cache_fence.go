@@ -0,0 +1,42 @@
+package service
+
+import "sync"
+
+// cacheFence orders the cache fills of the loads after the writes of the repository. A load notes the generation
+// before reading the repository, and only fills the cache when no write has completed since: its read may predate the
+// write, whose cache update it would overwrite. Fills hold a read lock, which a completed write takes to advance the
+// generation, so that a fill either lands before the cache update of the write or is skipped. It orders the loads and
+// writes of a single replica only.
+type cacheFence struct {
+	mu         sync.RWMutex
+	generation uint64
+}
+
+// start returns the generation a load reading the repository from now on must fill the cache with.
+func (f *cacheFence) start() uint64 {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.generation
+}
+
+// fill runs set unless a write completed since the generation was returned by start, and reports whether it ran.
+func (f *cacheFence) fill(generation uint64, set func()) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.generation != generation {
+		return false
+	}
+	set()
+	return true
+}
+
+// advance records a completed write of the repository, once the fills in progress are done. It must be called before
+// the cache is updated with the write.
+func (f *cacheFence) advance() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.generation++
+}
@@ -1,29 +1,398 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+const (
+	defaultFileMaxSize         = 100 << 20
+	defaultFileUploadTTL       = 24 * time.Hour
+	defaultFileExpireBatchSize = 100
+
+	// filePathPrefix is the prefix of the keys of the files in the object storage.
+	filePathPrefix = "files/"
+)
+
 var (
 	_ service.IFileService = (*FileService)(nil)
 )
 
+// FileConfig holds the upload policy of the files.
+// MaxSize is the maximum size, in bytes, of a file.
+// UploadTTL is the time after which an incomplete upload expires; it must exceed the expiration of the presigned URLs.
+// ExpireBatchSize is the number of expired uploads removed at once.
+type FileConfig struct {
+	MaxSize         int64         `mapstructure:"max_size"`
+	UploadTTL       time.Duration `mapstructure:"upload_ttl"`
+	ExpireBatchSize int           `mapstructure:"expire_batch_size"`
+}
+
+// FileService manages the files that the clients upload straight to the object storage through presigned URLs. The
+// service only keeps their metadata, and checks the uploaded objects when the clients complete the uploads.
 type FileService struct {
 	logger *zap.Logger
 	repo   repository.IFileRepository
 	store  storage.IFileStorage
 	msg    messaging.IFileMessaging
+	config FileConfig
+
+	now func() time.Time
+}
+
+// Create registers a pending file, stored under a key derived from its id, and returns it along with the request
+// uploading its content.
+func (s *FileService) Create(ctx context.Context, input data.FileCreateInput) (*model.File, *model.PresignedRequest, error) {
+	tracer := otel.Tracer("FileService")
+	ctx, span := tracer.Start(ctx, "FileService.Create")
+	defer span.End()
+
+	id := uuid.New()
+	extension := strings.ToLower(filepath.Ext(input.Name))
+	file := &model.File{
+		Id:        id,
+		Name:      input.Name,
+		Size:      input.Size,
+		Extension: extension,
+		MimeType:  input.MimeType,
+		Path:      filePathPrefix + id.String() + extension,
+		Status:    model.UploadStatusPending,
+	}
+
+	span.SetAttributes(
+		attribute.String("file.id", file.Id.String()),
+		attribute.String("file.mime_type", file.MimeType),
+		attribute.Int64("file.size", file.Size),
+	)
+
+	var validate = validator.New()
+	if err := validate.Struct(file); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid input")
+		s.log(ctx).Debug("invalid input", zap.Error(err))
+		return nil, nil, fmt.Errorf("invalid input: %w", err)
+	}
+	if file.Size > s.config.MaxSize {
+		err := port.NewErrTooLarge("file", file.Size, s.config.MaxSize)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid input")
+		s.log(ctx).Debug("invalid input", zap.Error(err))
+		return nil, nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, file); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to create file")
+		s.log(ctx).Debug("fail to create file", zap.Error(err))
+		return nil, nil, fmt.Errorf("fail to create file: %w", err)
+	}
+
+	url, header, err := s.store.PresignedUploadURL(ctx, file.Path, file.MimeType, file.Size)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to presign file upload")
+		s.log(ctx).Debug("fail to presign file upload", zap.Error(err))
+		return nil, nil, fmt.Errorf("fail to presign file upload: %w", err)
+	}
+
+	if err := s.msg.PublishFileCreated(ctx, file); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to publish file created")
+		s.log(ctx).Debug("fail to publish file created", zap.Error(err))
+		return nil, nil, fmt.Errorf("fail to publish file created: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return file, &model.PresignedRequest{Method: http.MethodPut, Url: url, Header: header}, nil
 }
 
-func NewFileService(logger *zap.Logger, repo repository.IFileRepository, store storage.IFileStorage, msg messaging.IFileMessaging) *FileService {
+// Complete checks the object uploaded for a pending file and marks the file complete. The upload stays pending while
+// the object is missing, so that the client may still upload it; the file is failed, and the object removed, when the
+// size of the object does not match the file. Completing a complete file returns it unchanged. The file is only
+// updated while pending, so that of concurrent completions, a single one publishes the upload.
+func (s *FileService) Complete(ctx context.Context, id uuid.UUID) (*model.File, error) {
+	tracer := otel.Tracer("FileService")
+	ctx, span := tracer.Start(ctx, "FileService.Complete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("file.id", id.String()))
+
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find file by id")
+		s.log(ctx).Debug("fail to find file by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find file by id: %w", err)
+	}
+
+	if file.Status != model.UploadStatusPending {
+		return settled(span, file)
+	}
+
+	object, err := s.store.Head(ctx, file.Path)
+	if err != nil {
+		span.RecordError(err)
+		if errors.As(err, &port.ErrorNotFound) {
+			span.SetStatus(codes.Error, "object not found")
+			return nil, port.NewErrUploadIncomplete(id.String(), "object not found")
+		}
+		span.SetStatus(codes.Error, "fail to head file object")
+		s.log(ctx).Debug("fail to head file object", zap.Error(err))
+		return nil, fmt.Errorf("fail to head file object: %w", err)
+	}
+
+	if object.Size != file.Size {
+		reason := fmt.Sprintf("object of %d bytes does not match the size of %d bytes", object.Size, file.Size)
+		file.Status = model.UploadStatusFailed
+		if err := s.repo.Update(ctx, file); err != nil {
+			if errors.As(err, &port.ErrorNoAffectedData) {
+				return s.resettled(ctx, span, id)
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "fail to update file")
+			s.log(ctx).Debug("fail to update file", zap.Error(err))
+			return nil, fmt.Errorf("fail to update file: %w", err)
+		}
+		if err := s.store.Delete(ctx, file.Path); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.Bool("storage.delete.error", true))
+			s.log(ctx).Warn("fail to delete file object", zap.Error(err))
+		}
+
+		err := port.NewErrUploadIncomplete(id.String(), reason)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "upload failed")
+		return nil, err
+	}
+
+	file.Status = model.UploadStatusComplete
+	file.UploadedAt = s.now()
+	if err := s.repo.Update(ctx, file); err != nil {
+		if errors.As(err, &port.ErrorNoAffectedData) {
+			return s.resettled(ctx, span, id)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to update file")
+		s.log(ctx).Debug("fail to update file", zap.Error(err))
+		return nil, fmt.Errorf("fail to update file: %w", err)
+	}
+
+	if err := s.msg.PublishFileUploaded(ctx, file); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to publish file uploaded")
+		s.log(ctx).Debug("fail to publish file uploaded", zap.Error(err))
+		return nil, fmt.Errorf("fail to publish file uploaded: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return file, nil
+}
+
+// resettled returns the outcome of an upload settled by another request while it was being completed.
+func (s *FileService) resettled(ctx context.Context, span trace.Span, id uuid.UUID) (*model.File, error) {
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find file by id")
+		s.log(ctx).Debug("fail to find file by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find file by id: %w", err)
+	}
+	return settled(span, file)
+}
+
+// settled returns the outcome of a settled upload: the file once complete, and an ErrUploadIncomplete once failed.
+func settled(span trace.Span, file *model.File) (*model.File, error) {
+	if file.Status == model.UploadStatusComplete {
+		span.SetStatus(codes.Ok, "")
+		return file, nil
+	}
+
+	err := port.NewErrUploadIncomplete(file.Id.String(), "upload failed")
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "upload failed")
+	return nil, err
+}
+
+// GetByID fetches a file by its unique identifier, along with the request downloading its content once its upload
+// is completed.
+func (s *FileService) GetByID(ctx context.Context, id uuid.UUID) (*model.File, *model.PresignedRequest, error) {
+	tracer := otel.Tracer("FileService")
+	ctx, span := tracer.Start(ctx, "FileService.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("file.id", id.String()))
+
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find file by id")
+		s.log(ctx).Debug("fail to find file by id", zap.Error(err))
+		return nil, nil, fmt.Errorf("fail to find file by id: %w", err)
+	}
+
+	if file.Status != model.UploadStatusComplete {
+		span.SetStatus(codes.Ok, "")
+		return file, nil, nil
+	}
+
+	url, header, err := s.store.PresignedDownloadURL(ctx, file.Path)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to presign file download")
+		s.log(ctx).Debug("fail to presign file download", zap.Error(err))
+		return nil, nil, fmt.Errorf("fail to presign file download: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return file, &model.PresignedRequest{Method: http.MethodGet, Url: url, Header: header}, nil
+}
+
+// DeleteByID removes a file along with its object, whatever the status of its upload.
+func (s *FileService) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("FileService")
+	ctx, span := tracer.Start(ctx, "FileService.DeleteByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("file.id", id.String()))
+
+	file, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find file by id")
+		s.log(ctx).Debug("fail to find file by id", zap.Error(err))
+		return fmt.Errorf("fail to find file by id: %w", err)
+	}
+
+	// The object is removed first: a failure leaves the file in place, so that deleting it again removes the object.
+	if err := s.store.Delete(ctx, file.Path); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to delete file object")
+		s.log(ctx).Debug("fail to delete file object", zap.Error(err))
+		return fmt.Errorf("fail to delete file object: %w", err)
+	}
+
+	if err := s.repo.DeleteByID(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to delete file by id")
+		s.log(ctx).Debug("fail to delete file by id", zap.Error(err))
+		return fmt.Errorf("fail to delete file by id: %w", err)
+	}
+
+	if err := s.msg.PublishFileDeleted(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to publish file deleted")
+		s.log(ctx).Debug("fail to publish file deleted", zap.Error(err))
+		return fmt.Errorf("fail to publish file deleted: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// ExpireUploads removes a batch of the files whose upload is still incomplete after the upload TTL, along with their
+// objects, and returns the number of files removed. A pending file is failed before its object is removed, so that it
+// can no longer be completed; a file completed meanwhile is kept. A file failing to be removed is only logged, and
+// removed by a later call.
+func (s *FileService) ExpireUploads(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("FileService")
+	ctx, span := tracer.Start(ctx, "FileService.ExpireUploads")
+	defer span.End()
+
+	files, err := s.repo.FindIncompleteBefore(ctx, s.now().Add(-s.config.UploadTTL), s.config.ExpireBatchSize)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find incomplete files")
+		s.log(ctx).Debug("fail to find incomplete files", zap.Error(err))
+		return 0, fmt.Errorf("fail to find incomplete files: %w", err)
+	}
+
+	count := 0
+	for _, file := range files {
+		if err := s.expire(ctx, file); err != nil {
+			if errors.As(err, &port.ErrorNoAffectedData) || errors.As(err, &port.ErrorNotFound) {
+				// Completed or removed meanwhile, possibly by another replica.
+				continue
+			}
+			span.RecordError(err)
+			s.log(ctx).Warn("fail to expire file", zap.String("file.id", file.Id.String()), zap.Error(err))
+			continue
+		}
+		count++
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+	span.SetStatus(codes.Ok, "")
+	return count, nil
+}
+
+// expire fails the file if pending, then removes its object and the file itself.
+func (s *FileService) expire(ctx context.Context, file *model.File) error {
+	if file.Status == model.UploadStatusPending {
+		file.Status = model.UploadStatusFailed
+		if err := s.repo.Update(ctx, file); err != nil {
+			return fmt.Errorf("fail to update file: %w", err)
+		}
+	}
+
+	if err := s.store.Delete(ctx, file.Path); err != nil {
+		return fmt.Errorf("fail to delete file object: %w", err)
+	}
+
+	if err := s.repo.DeleteByID(ctx, file.Id); err != nil {
+		return fmt.Errorf("fail to delete file by id: %w", err)
+	}
+
+	if err := s.msg.PublishFileDeleted(ctx, file.Id); err != nil {
+		return fmt.Errorf("fail to publish file deleted: %w", err)
+	}
+	return nil
+}
+
+func (s *FileService) log(ctx context.Context) *zap.Logger {
+	return correlation.Logger(ctx, s.logger)
+}
+
+// NewFileService initializes a new instance of FileService with the provided logger, upload policy, repository,
+// storage and messaging. Unset maximum size, upload TTL and batch size fall back to sane defaults.
+func NewFileService(logger *zap.Logger, config FileConfig, repo repository.IFileRepository, store storage.IFileStorage, msg messaging.IFileMessaging) *FileService {
+	if config.MaxSize <= 0 {
+		config.MaxSize = defaultFileMaxSize
+	}
+	if config.UploadTTL <= 0 {
+		config.UploadTTL = defaultFileUploadTTL
+	}
+	if config.ExpireBatchSize <= 0 {
+		config.ExpireBatchSize = defaultFileExpireBatchSize
+	}
+
 	return &FileService{
 		logger: logger,
 		repo:   repo,
 		store:  store,
 		msg:    msg,
+		config: config,
+		now:    time.Now,
 	}
 }
@@ -1 +1,521 @@
 package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/messaging"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/repository"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/storage"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	testFileConfig = FileConfig{MaxSize: 1024}
+	testFileNow    = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	testFileID     = uuid.MustParse("30000000-0000-0000-0000-000000000001")
+)
+
+type fileMocks struct {
+	repo  *repository.MockFileRepository
+	store *storage.MockFileStorage
+	msg   *messaging.MockFileMessaging
+}
+
+func newTestFileService() (*FileService, fileMocks) {
+	mocks := fileMocks{
+		repo:  new(repository.MockFileRepository),
+		store: new(storage.MockFileStorage),
+		msg:   new(messaging.MockFileMessaging),
+	}
+	s := NewFileService(zap.NewNop(), testFileConfig, mocks.repo, mocks.store, mocks.msg)
+	s.now = func() time.Time { return testFileNow }
+	return s, mocks
+}
+
+func (m fileMocks) assertExpectations(t *testing.T) {
+	t.Helper()
+	m.repo.AssertExpectations(t)
+	m.store.AssertExpectations(t)
+	m.msg.AssertExpectations(t)
+}
+
+func newTestFile(status model.UploadStatus) *model.File {
+	return &model.File{
+		Id:        testFileID,
+		Name:      "Report.PDF",
+		Size:      512,
+		Extension: ".pdf",
+		MimeType:  "application/pdf",
+		Path:      "files/" + testFileID.String() + ".pdf",
+		Status:    status,
+		CreatedAt: testFileNow.Add(-time.Hour),
+	}
+}
+
+func TestFileService_Create(t *testing.T) {
+	t.Parallel()
+	input := data.FileCreateInput{Name: "Report.PDF", Size: 512, MimeType: "application/pdf"}
+
+	testCases := []struct {
+		name              string
+		input             data.FileCreateInput
+		expectedErrorType error
+		expectedError     bool
+
+		setupMocks func(fileMocks)
+	}{
+		{
+			name:  "Success Case",
+			input: input,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("Create", mock.Anything, mock.MatchedBy(func(f *model.File) bool {
+					return f.Status == model.UploadStatusPending && f.Extension == ".pdf" &&
+						f.Path == "files/"+f.Id.String()+".pdf"
+				})).Return(nil)
+				m.store.On("PresignedUploadURL", mock.Anything, mock.Anything, "application/pdf", int64(512)).
+					Return("https://storage/upload", http.Header{"Content-Type": {"application/pdf"}}, nil)
+				m.msg.On("PublishFileCreated", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:          "Failure Case - Invalid Input",
+			input:         data.FileCreateInput{Name: "", Size: 512, MimeType: "application/pdf"},
+			expectedError: true,
+			setupMocks:    func(m fileMocks) {},
+		},
+		{
+			name:              "Failure Case - Too Large",
+			input:             data.FileCreateInput{Name: "Report.PDF", Size: 2048, MimeType: "application/pdf"},
+			expectedErrorType: port.ErrorTooLarge,
+			setupMocks:        func(m fileMocks) {},
+		},
+		{
+			name:          "Failure Case - Repository Error",
+			input:         input,
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+		},
+		{
+			name:          "Failure Case - Storage Error",
+			input:         input,
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.store.On("PresignedUploadURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("", http.Header(nil), errors.New("storage error"))
+			},
+		},
+		{
+			name:          "Failure Case - Messaging Error",
+			input:         input,
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+				m.store.On("PresignedUploadURL", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return("https://storage/upload", http.Header{}, nil)
+				m.msg.On("PublishFileCreated", mock.Anything, mock.Anything).Return(errors.New("messaging error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			service, mocks := newTestFileService()
+			testCase.setupMocks(mocks)
+
+			file, upload, err := service.Create(context.Background(), testCase.input)
+
+			switch {
+			case testCase.expectedErrorType != nil:
+				assert.ErrorAs(t, err, &testCase.expectedErrorType)
+			case testCase.expectedError:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, file.Id)
+				assert.Equal(t, testCase.input.Name, file.Name)
+				assert.Equal(t, model.UploadStatusPending, file.Status)
+				assert.Equal(t, &model.PresignedRequest{
+					Method: http.MethodPut,
+					Url:    "https://storage/upload",
+					Header: http.Header{"Content-Type": {"application/pdf"}},
+				}, upload)
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestFileService_Complete(t *testing.T) {
+	t.Parallel()
+	path := newTestFile(model.UploadStatusPending).Path
+
+	testCases := []struct {
+		name              string
+		expectedStatus    model.UploadStatus
+		expectedErrorType error
+		expectedError     bool
+
+		setupMocks func(fileMocks)
+	}{
+		{
+			name:           "Success Case",
+			expectedStatus: model.UploadStatusComplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+				m.store.On("Head", mock.Anything, path).Return(&model.FileObject{Size: 512}, nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(f *model.File) bool {
+					return f.Status == model.UploadStatusComplete && f.UploadedAt.Equal(testFileNow)
+				})).Return(nil)
+				m.msg.On("PublishFileUploaded", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:           "Success Case - Already Complete",
+			expectedStatus: model.UploadStatusComplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+			},
+		},
+		{
+			name:           "Success Case - Completed Concurrently",
+			expectedStatus: model.UploadStatusComplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil).Once()
+				m.store.On("Head", mock.Anything, path).Return(&model.FileObject{Size: 512}, nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).
+					Return(port.NewErrNoAffectedData("file", testFileID.String()))
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil).Once()
+			},
+		},
+		{
+			name:              "Failure Case - Not Found",
+			expectedErrorType: port.ErrorNotFound,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).
+					Return((*model.File)(nil), port.NewErrNotFound("file", "id", testFileID.String()))
+			},
+		},
+		{
+			name:              "Failure Case - Expired Concurrently",
+			expectedErrorType: port.ErrorUploadIncomplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil).Once()
+				m.store.On("Head", mock.Anything, path).Return(&model.FileObject{Size: 512}, nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).
+					Return(port.NewErrNoAffectedData("file", testFileID.String()))
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusFailed), nil).Once()
+			},
+		},
+		{
+			name:              "Failure Case - Already Failed",
+			expectedErrorType: port.ErrorUploadIncomplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusFailed), nil)
+			},
+		},
+		{
+			name:              "Failure Case - Object Not Found",
+			expectedErrorType: port.ErrorUploadIncomplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+				m.store.On("Head", mock.Anything, path).
+					Return((*model.FileObject)(nil), port.NewErrNotFound("file object", "path", path))
+			},
+		},
+		{
+			name:              "Failure Case - Size Mismatch",
+			expectedErrorType: port.ErrorUploadIncomplete,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+				m.store.On("Head", mock.Anything, path).Return(&model.FileObject{Size: 100}, nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(f *model.File) bool {
+					return f.Status == model.UploadStatusFailed
+				})).Return(nil)
+				m.store.On("Delete", mock.Anything, path).Return(nil)
+			},
+		},
+		{
+			name:          "Failure Case - Storage Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+				m.store.On("Head", mock.Anything, path).Return((*model.FileObject)(nil), errors.New("storage error"))
+			},
+		},
+		{
+			name:          "Failure Case - Messaging Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+				m.store.On("Head", mock.Anything, path).Return(&model.FileObject{Size: 512}, nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				m.msg.On("PublishFileUploaded", mock.Anything, mock.Anything).Return(errors.New("messaging error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			service, mocks := newTestFileService()
+			testCase.setupMocks(mocks)
+
+			file, err := service.Complete(context.Background(), testFileID)
+
+			switch {
+			case testCase.expectedErrorType != nil:
+				assert.ErrorAs(t, err, &testCase.expectedErrorType)
+			case testCase.expectedError:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedStatus, file.Status)
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestFileService_GetByID(t *testing.T) {
+	t.Parallel()
+	path := newTestFile(model.UploadStatusPending).Path
+
+	testCases := []struct {
+		name             string
+		expectedDownload *model.PresignedRequest
+		expectedError    bool
+
+		setupMocks func(fileMocks)
+	}{
+		{
+			name:             "Success Case - Complete",
+			expectedDownload: &model.PresignedRequest{Method: http.MethodGet, Url: "https://storage/download", Header: http.Header{}},
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+				m.store.On("PresignedDownloadURL", mock.Anything, path).Return("https://storage/download", http.Header{}, nil)
+			},
+		},
+		{
+			name: "Success Case - Pending",
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusPending), nil)
+			},
+		},
+		{
+			name:          "Failure Case - Not Found",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).
+					Return((*model.File)(nil), port.NewErrNotFound("file", "id", testFileID.String()))
+			},
+		},
+		{
+			name:          "Failure Case - Storage Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+				m.store.On("PresignedDownloadURL", mock.Anything, path).Return("", http.Header(nil), errors.New("storage error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			service, mocks := newTestFileService()
+			testCase.setupMocks(mocks)
+
+			file, download, err := service.GetByID(context.Background(), testFileID)
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testFileID, file.Id)
+				assert.Equal(t, testCase.expectedDownload, download)
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestFileService_DeleteByID(t *testing.T) {
+	t.Parallel()
+	path := newTestFile(model.UploadStatusPending).Path
+
+	testCases := []struct {
+		name              string
+		expectedErrorType error
+		expectedError     bool
+
+		setupMocks func(fileMocks)
+	}{
+		{
+			name: "Success Case",
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+				m.store.On("Delete", mock.Anything, path).Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, testFileID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, testFileID).Return(nil)
+			},
+		},
+		{
+			name:              "Failure Case - Not Found",
+			expectedErrorType: port.ErrorNotFound,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).
+					Return((*model.File)(nil), port.NewErrNotFound("file", "id", testFileID.String()))
+			},
+		},
+		{
+			name:          "Failure Case - Storage Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+				m.store.On("Delete", mock.Anything, path).Return(errors.New("storage error"))
+			},
+		},
+		{
+			name:          "Failure Case - Messaging Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindByID", mock.Anything, testFileID).Return(newTestFile(model.UploadStatusComplete), nil)
+				m.store.On("Delete", mock.Anything, path).Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, testFileID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, testFileID).Return(errors.New("messaging error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			service, mocks := newTestFileService()
+			testCase.setupMocks(mocks)
+
+			err := service.DeleteByID(context.Background(), testFileID)
+
+			switch {
+			case testCase.expectedErrorType != nil:
+				assert.ErrorAs(t, err, &testCase.expectedErrorType)
+			case testCase.expectedError:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
+
+func TestFileService_ExpireUploads(t *testing.T) {
+	t.Parallel()
+	pendingID := uuid.MustParse("30000000-0000-0000-0000-000000000002")
+	failedID := uuid.MustParse("30000000-0000-0000-0000-000000000003")
+	before := testFileNow.Add(-defaultFileUploadTTL)
+
+	newExpiredFiles := func() []*model.File {
+		pending := newTestFile(model.UploadStatusPending)
+		pending.Id, pending.Path = pendingID, "files/pending.pdf"
+		failed := newTestFile(model.UploadStatusFailed)
+		failed.Id, failed.Path = failedID, "files/failed.pdf"
+		return []*model.File{pending, failed}
+	}
+
+	testCases := []struct {
+		name          string
+		expectedCount int
+		expectedError bool
+
+		setupMocks func(fileMocks)
+	}{
+		{
+			name:          "Success Case",
+			expectedCount: 2,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindIncompleteBefore", mock.Anything, before, defaultFileExpireBatchSize).Return(newExpiredFiles(), nil)
+				m.repo.On("Update", mock.Anything, mock.MatchedBy(func(f *model.File) bool {
+					return f.Id == pendingID && f.Status == model.UploadStatusFailed
+				})).Return(nil).Once()
+				m.store.On("Delete", mock.Anything, "files/pending.pdf").Return(nil)
+				m.store.On("Delete", mock.Anything, "files/failed.pdf").Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, pendingID).Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, failedID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, pendingID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, failedID).Return(nil)
+			},
+		},
+		{
+			name:          "Success Case - None",
+			expectedCount: 0,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindIncompleteBefore", mock.Anything, before, defaultFileExpireBatchSize).Return([]*model.File(nil), nil)
+			},
+		},
+		{
+			name:          "Success Case - Completed Concurrently",
+			expectedCount: 1,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindIncompleteBefore", mock.Anything, before, defaultFileExpireBatchSize).Return(newExpiredFiles(), nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).
+					Return(port.NewErrNoAffectedData("file", pendingID.String())).Once()
+				m.store.On("Delete", mock.Anything, "files/failed.pdf").Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, failedID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, failedID).Return(nil)
+			},
+		},
+		{
+			name:          "Success Case - Storage Error",
+			expectedCount: 1,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindIncompleteBefore", mock.Anything, before, defaultFileExpireBatchSize).Return(newExpiredFiles(), nil)
+				m.repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				m.store.On("Delete", mock.Anything, "files/pending.pdf").Return(errors.New("storage error"))
+				m.store.On("Delete", mock.Anything, "files/failed.pdf").Return(nil)
+				m.repo.On("DeleteByID", mock.Anything, failedID).Return(nil)
+				m.msg.On("PublishFileDeleted", mock.Anything, failedID).Return(nil)
+			},
+		},
+		{
+			name:          "Failure Case - Repository Error",
+			expectedError: true,
+			setupMocks: func(m fileMocks) {
+				m.repo.On("FindIncompleteBefore", mock.Anything, before, defaultFileExpireBatchSize).
+					Return([]*model.File(nil), errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			service, mocks := newTestFileService()
+			testCase.setupMocks(mocks)
+
+			count, err := service.ExpireUploads(context.Background())
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedCount, count)
+			}
+			mocks.assertExpectations(t)
+		})
+	}
+}
@@ -2,37 +2,69 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 )
 
-const FooCacheExpiration = time.Minute * 15
+const (
+	defaultFooCacheTTL            = 15 * time.Minute
+	defaultFooCacheRefreshTimeout = 5 * time.Second
+	defaultFooListCacheTTL        = time.Minute
+
+	fooFilterPageSize = 1000
+)
 
 var (
 	_ service.IFooService = (*FooService)(nil)
 )
 
+// FooCacheConfig holds the caching policy of Foo entities.
+// TTL is the time an entry is served as fresh, randomly spread by ±Jitter (a fraction of TTL).
+// StaleTTL is the time an expired entry is still served while it is refreshed in the background; zero disables it.
+// MissingTTL is the lifetime of the tombstone cached for an id that does not exist; zero disables negative caching.
+// ListTTL is the lifetime of a cached list result.
+type FooCacheConfig struct {
+	TTL            time.Duration `mapstructure:"ttl"`
+	Jitter         float64       `mapstructure:"jitter"`
+	StaleTTL       time.Duration `mapstructure:"stale_ttl"`
+	MissingTTL     time.Duration `mapstructure:"missing_ttl"`
+	ListTTL        time.Duration `mapstructure:"list_ttl"`
+	RefreshTimeout time.Duration `mapstructure:"refresh_timeout"`
+}
+
 // FooService provides business logic around Foo entities, integrating data access, caching, and messaging capabilities.
 type FooService struct {
 	logger    *zap.Logger
 	repo      repository.IFooRepository
 	cache     cache.IFooCache
+	listCache cache.IFooListCache
+	filter    cache.IFooFilter
 	messaging messaging.IFooMessaging
+
+	cacheConfig FooCacheConfig
+	group       singleflight.Group
+	fence       cacheFence
 }
 
 // GetAll retrieves a list of Foo entities based on the provided input criteria and returns an error if retrieval fails.
@@ -46,21 +78,91 @@ func (s *FooService) GetAll(ctx context.Context, input data.FooReadListInput) ([
 		attribute.Int("limit", input.Limit),
 	)
 
-	foos, err := s.repo.FindAll(ctx, input)
+	if s.listCache != nil {
+		foos, found, err := s.listCache.GetList(ctx, input)
+		if err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.Bool("cache.get.error", true))
+			s.log(ctx).Debug("fail to find foo list from cache", zap.Error(err))
+		} else if found {
+			span.SetAttributes(attribute.Bool("cache.hit", true), attribute.Int("result.count", len(foos)))
+			observeListCache(cacheResultHit)
+			span.SetStatus(codes.Ok, "")
+			return foos, nil
+		}
+		span.SetAttributes(attribute.Bool("cache.miss", true))
+	}
+
+	// Identical queries are coalesced like single Foo reads; the key cannot collide with a Foo id.
+	leader := false
+	value, err, _ := s.group.Do(fmt.Sprintf("list:%d:%d", input.Offset, input.Limit), func() (interface{}, error) {
+		leader = true
+		return s.loadList(context.WithoutCancel(ctx), input)
+	})
+	if s.listCache != nil {
+		if leader {
+			observeListCache(cacheResultMiss)
+		} else {
+			observeListCache(cacheResultCoalesced)
+		}
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to find all foo")
 
-		s.logger.Debug("fail to find all foo", zap.Error(err))
+		s.log(ctx).Debug("fail to find all foo", zap.Error(err))
 		return nil, fmt.Errorf("fail to find all foo: %w", err)
 	}
 
+	foos := value.([]*model.Foo)
 	span.SetStatus(codes.Ok, "")
 	span.SetAttributes(attribute.Int("result.count", len(foos)))
 	return foos, nil
 }
 
+// loadList reads a page of Foo entities from the repository and stores it in the list cache, unless a Foo was written
+// meanwhile: the page may predate the write, whose invalidation it would outlive.
+func (s *FooService) loadList(ctx context.Context, input data.FooReadListInput) ([]*model.Foo, error) {
+	generation := s.fence.start()
+	foos, err := s.repo.FindAll(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.listCache != nil {
+		s.fence.fill(generation, func() {
+			if err := s.listCache.SetList(ctx, input, foos, s.cacheConfig.ListTTL); err != nil {
+				s.log(ctx).Warn("fail to create foo list in cache", zap.Error(err))
+			}
+		})
+	}
+
+	return foos, nil
+}
+
+// invalidateLists drops the cached list results affected by a change. Without ids, every result is dropped:
+// creations and deletions shift the pages that follow the Foo.
+func (s *FooService) invalidateLists(ctx context.Context, span trace.Span, ids ...uuid.UUID) {
+	if s.listCache == nil {
+		return
+	}
+
+	var err error
+	if len(ids) == 0 {
+		err = s.listCache.InvalidateAll(ctx)
+	} else {
+		err = s.listCache.InvalidateByIDs(ctx, ids...)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.Bool("cache.invalidate.error", true))
+		s.log(ctx).Warn("fail to invalidate foo lists in cache", zap.Error(err))
+	}
+}
+
 // GetByID retrieves a Foo entity by its ID, using a cache-first approach and falling back to the repository if needed.
+// Concurrent misses on the same id are coalesced into a single repository call. A stale entry is returned immediately
+// while it is refreshed in the background.
 func (s *FooService) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error) {
 	tracer := otel.Tracer("FooService")
 	ctx, span := tracer.Start(ctx, "FooService.GetByID")
@@ -68,36 +170,133 @@ func (s *FooService) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, err
 
 	span.SetAttributes(attribute.String("id", id.String()))
 
-	foo, err := s.cache.GetByID(ctx, id)
+	entry, err := s.cache.GetByID(ctx, id)
 	if err != nil {
 		span.RecordError(err)
 		span.SetAttributes(attribute.Bool("cache.get.error", true))
-		s.logger.Debug("fail to find foo by id from cache", zap.Error(err))
+		s.log(ctx).Debug("fail to find foo by id from cache", zap.Error(err))
 	}
 
-	if foo == nil {
-		span.SetAttributes(attribute.Bool("cache.miss", true))
+	if entry != nil && entry.Missing && entry.Fresh(time.Now()) {
+		span.SetAttributes(attribute.Bool("cache.missing", true))
+		observeCache(cacheResultMissing)
+		return nil, s.notFound(ctx, span, id)
+	}
 
-		foo, err = s.repo.FindByID(ctx, id)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, "failed to find foo by id")
-			s.logger.Debug("fail to find foo by id", zap.Error(err))
-			return nil, fmt.Errorf("fail to find foo by id: %w", err)
+	if entry != nil && !entry.Missing {
+		if entry.Fresh(time.Now()) {
+			span.SetAttributes(attribute.Bool("cache.hit", true))
+			observeCache(cacheResultHit)
+		} else {
+			span.SetAttributes(attribute.Bool("cache.stale", true))
+			observeCache(cacheResultStale)
+			s.refresh(ctx, id)
 		}
 
-		if err := s.cache.Set(ctx, foo, FooCacheExpiration); err != nil {
+		span.SetStatus(codes.Ok, "")
+		return entry.Foo, nil
+	}
+
+	span.SetAttributes(attribute.Bool("cache.miss", true))
+
+	if s.filter != nil {
+		if exists, err := s.filter.MightContain(ctx, id); err != nil {
 			span.RecordError(err)
-			span.SetAttributes(attribute.Bool("cache.set.error", true))
-			s.logger.Warn("fail to create foo in cache: %w", zap.Error(err))
+			span.SetAttributes(attribute.Bool("filter.error", true))
+			s.log(ctx).Debug("fail to check foo id in filter", zap.Error(err))
+		} else if !exists {
+			span.SetAttributes(attribute.Bool("filter.rejected", true))
+			observeCache(cacheResultFiltered)
+			return nil, s.notFound(ctx, span, id)
 		}
+	}
+
+	// The load is shared by concurrent callers: it must not be cancelled when the caller that started it goes away.
+	leader := false
+	value, err, _ := s.group.Do(id.String(), func() (interface{}, error) {
+		leader = true
+		return s.load(context.WithoutCancel(ctx), id)
+	})
+	if leader {
+		observeCache(cacheResultMiss)
 	} else {
-		span.SetAttributes(attribute.Bool("cache.hit", true))
+		span.SetAttributes(attribute.Bool("cache.coalesced", true))
+		observeCache(cacheResultCoalesced)
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to find foo by id")
+		s.log(ctx).Debug("fail to find foo by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find foo by id: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return value.(*model.Foo), nil
+}
+
+// notFound records on the span and returns the error of a Foo known to be missing without querying the repository.
+func (s *FooService) notFound(ctx context.Context, span trace.Span, id uuid.UUID) error {
+	err := port.NewErrNotFound("foo", "id", id.String())
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "failed to find foo by id")
+	s.log(ctx).Debug("fail to find foo by id", zap.Error(err))
+
+	return fmt.Errorf("fail to find foo by id: %w", err)
+}
+
+// load reads a Foo from the repository and stores it in the cache. A missing Foo is cached as a short-lived tombstone.
+// The cache is left untouched when a Foo was written meanwhile: the read may predate the write, and would overwrite
+// the cache update of the writer with a previous version, or bring a deleted Foo back.
+func (s *FooService) load(ctx context.Context, id uuid.UUID) (*model.Foo, error) {
+	generation := s.fence.start()
+	foo, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		var notFound *port.ErrNotFound
+		if errors.As(err, &notFound) && s.cacheConfig.MissingTTL > 0 {
+			s.fence.fill(generation, func() {
+				if err := s.cache.SetMissing(ctx, id, s.cacheConfig.MissingTTL); err != nil {
+					s.log(ctx).Warn("fail to create foo tombstone in cache", zap.Error(err))
+				}
+			})
+		}
+		return nil, err
 	}
 
+	s.fence.fill(generation, func() {
+		if err := s.cache.Set(ctx, foo, s.cacheTTL()); err != nil {
+			s.log(ctx).Warn("fail to create foo in cache", zap.Error(err))
+		}
+	})
+
 	return foo, nil
 }
 
+// refresh reloads a stale Foo in the background. It joins the load already in flight for the same id, if any.
+func (s *FooService) refresh(ctx context.Context, id uuid.UUID) {
+	ctx = context.WithoutCancel(ctx)
+	s.group.DoChan(id.String(), func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, s.cacheConfig.RefreshTimeout)
+		defer cancel()
+
+		foo, err := s.load(ctx, id)
+		if err != nil {
+			s.log(ctx).Warn("fail to refresh foo in cache", zap.Error(err))
+		}
+		return foo, err
+	})
+}
+
+// cacheTTL returns the lifetimes of a new cache entry. The fresh TTL is jittered so that entries written together,
+// after a deployment or a bulk import, do not expire together.
+func (s *FooService) cacheTTL() model.CacheTTL {
+	fresh := s.cacheConfig.TTL
+	if s.cacheConfig.Jitter > 0 {
+		fresh += time.Duration(float64(fresh) * s.cacheConfig.Jitter * (2*rand.Float64() - 1))
+	}
+
+	return model.CacheTTL{Fresh: fresh, Stale: s.cacheConfig.StaleTTL}
+}
+
 // Create creates a new Foo entity, stores it in the repository, and updates related cache and messaging.
 func (s *FooService) Create(ctx context.Context, input data.FooCreateInput) (*model.Foo, error) {
 	tracer := otel.Tracer("FooService")
@@ -123,27 +322,39 @@ func (s *FooService) Create(ctx context.Context, input data.FooCreateInput) (*mo
 	if err := validate.Struct(foo); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid input")
-		s.logger.Debug("invalid input", zap.Error(err))
+		s.log(ctx).Debug("invalid input", zap.Error(err))
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
 	if err := s.repo.Create(ctx, foo); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "fail to create foo")
-		s.logger.Debug("fail to create foo", zap.Error(err))
+		s.log(ctx).Debug("fail to create foo", zap.Error(err))
 		return nil, fmt.Errorf("fail to create foo: %w", err)
 	}
+	s.fence.advance()
+
+	// The id must be known by the filter before the Foo is returned; writing the Foo in the cache below also
+	// replaces any tombstone stored for its id.
+	if s.filter != nil {
+		if err := s.filter.Add(ctx, foo.Id); err != nil {
+			span.RecordError(err)
+			span.SetAttributes(attribute.Bool("filter.add.error", true))
+			s.log(ctx).Warn("fail to add foo id to filter", zap.Error(err))
+		}
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		if err := s.cache.Set(ctx, foo, FooCacheExpiration); err != nil {
+		if err := s.cache.Set(ctx, foo, s.cacheTTL()); err != nil {
 			span.RecordError(err)
 			span.SetAttributes(attribute.Bool("cache.set.error", true))
-			s.logger.Warn("fail to create foo in cache", zap.Error(err))
+			s.log(ctx).Warn("fail to create foo in cache", zap.Error(err))
 		}
+		s.invalidateLists(ctx, span)
 	}()
 
 	var errMessaging error
@@ -160,7 +371,7 @@ func (s *FooService) Create(ctx context.Context, input data.FooCreateInput) (*mo
 	wg.Wait()
 
 	if errMessaging != nil {
-		s.logger.Debug("fail to publish foo created", zap.Error(errMessaging))
+		s.log(ctx).Debug("fail to publish foo created", zap.Error(errMessaging))
 		return nil, fmt.Errorf("fail to publish foo created: %w", errMessaging)
 	}
 
@@ -169,8 +380,8 @@ func (s *FooService) Create(ctx context.Context, input data.FooCreateInput) (*mo
 }
 
 // Update applies partial updates to an existing Foo entity based on the provided input and propagates changes across systems.
-// It retrieves the entity by ID, merges changes, updates the repository, cache, and publishes an event.
-func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) error {
+// It retrieves the entity by ID, merges changes, updates the repository, cache, and publishes an event, then returns the persisted entity.
+func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) (*model.Foo, error) {
 	tracer := otel.Tracer("FooService")
 	ctx, span := tracer.Start(ctx, "FooService.Update")
 	defer span.End()
@@ -181,8 +392,8 @@ func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) er
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "fail to find foo by id")
-		s.logger.Debug("fail to find foo by id", zap.Error(err))
-		return fmt.Errorf("fail to get foo by id: %w", err)
+		s.log(ctx).Debug("fail to find foo by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to get foo by id: %w", err)
 	}
 
 	oldValues := map[string]interface{}{
@@ -194,8 +405,8 @@ func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) er
 	if err := input.Merge(foo); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "fail to merge input")
-		s.logger.Debug("fail to merge input", zap.Error(err))
-		return fmt.Errorf("fail to merge input: %w", err)
+		s.log(ctx).Debug("fail to merge input", zap.Error(err))
+		return nil, fmt.Errorf("fail to merge input: %w", err)
 	}
 
 	span.SetAttributes(
@@ -211,27 +422,32 @@ func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) er
 	if err := validate.Struct(foo); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "invalid input")
-		s.logger.Debug("invalid input", zap.Error(err))
-		return fmt.Errorf("invalid input: %w", err)
+		s.log(ctx).Debug("invalid input", zap.Error(err))
+		return nil, fmt.Errorf("invalid input: %w", err)
 	}
 
 	if err := s.repo.Update(ctx, foo); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "fail to update foo")
-		s.logger.Debug("fail to update foo", zap.Error(err))
-		return fmt.Errorf("fail to update foo: %w", err)
+		s.log(ctx).Debug("fail to update foo", zap.Error(err))
+		return nil, fmt.Errorf("fail to update foo: %w", err)
 	}
+	// Callers arriving from now on must not join a load that may have read the previous version, and the loads in
+	// flight must not cache it.
+	s.group.Forget(foo.Id.String())
+	s.fence.advance()
 
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		if err := s.cache.Set(ctx, foo, FooCacheExpiration); err != nil {
+		if err := s.cache.Set(ctx, foo, s.cacheTTL()); err != nil {
 			span.RecordError(err)
 			span.SetAttributes(attribute.Bool("cache.set.error", true))
-			s.logger.Warn("fail to update foo in cache", zap.Error(err))
+			s.log(ctx).Warn("fail to update foo in cache", zap.Error(err))
 		}
+		s.invalidateLists(ctx, span, foo.Id)
 	}()
 
 	var errMessaging error
@@ -248,12 +464,12 @@ func (s *FooService) Update(ctx context.Context, input data.IFooUpdateMerger) er
 	wg.Wait()
 
 	if errMessaging != nil {
-		s.logger.Debug("fail to publish foo updated", zap.Error(errMessaging))
-		return fmt.Errorf("fail to publish foo updated: %w", errMessaging)
+		s.log(ctx).Debug("fail to publish foo updated", zap.Error(errMessaging))
+		return nil, fmt.Errorf("fail to publish foo updated: %w", errMessaging)
 	}
 
 	span.SetStatus(codes.Ok, "")
-	return nil
+	return foo, nil
 }
 
 // DeleteByID removes a Foo entity by its ID, updates the cache, and publishes a deletion event. Returns an error if any step fails.
@@ -267,9 +483,11 @@ func (s *FooService) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	if err := s.repo.DeleteByID(ctx, id); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "fail to delete foo")
-		s.logger.Debug("fail to delete foo by id", zap.Error(err))
+		s.log(ctx).Debug("fail to delete foo by id", zap.Error(err))
 		return fmt.Errorf("fail to delete foo by id: %w", err)
 	}
+	s.group.Forget(id.String())
+	s.fence.advance()
 
 	var wg sync.WaitGroup
 	wg.Add(2)
@@ -279,8 +497,9 @@ func (s *FooService) DeleteByID(ctx context.Context, id uuid.UUID) error {
 		if err := s.cache.DeleteByID(ctx, id); err != nil {
 			span.RecordError(err)
 			span.SetAttributes(attribute.Bool("cache.delete.error", true))
-			s.logger.Warn("fail to delete foo by id from cache", zap.Error(err))
+			s.log(ctx).Warn("fail to delete foo by id from cache", zap.Error(err))
 		}
+		s.invalidateLists(ctx, span)
 	}()
 
 	var errMessaging error
@@ -296,19 +515,87 @@ func (s *FooService) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	wg.Wait()
 
 	if errMessaging != nil {
-		s.logger.Debug("fail to publish foo deleted", zap.Error(errMessaging))
+		s.log(ctx).Debug("fail to publish foo deleted", zap.Error(errMessaging))
 	}
 
 	span.SetStatus(codes.Ok, "")
 	return nil
 }
 
-// NewFooService initializes a new instance of FooService with the provided logger, repository, cache, and messaging dependencies.
-func NewFooService(logger *zap.Logger, repo repository.IFooRepository, cache cache.IFooCache, messaging messaging.IFooMessaging) *FooService {
+// PopulateFilter adds the id of every stored Foo to the filter, then marks it as populated so that it starts rejecting
+// unknown ids. It is a no-op without filter.
+func (s *FooService) PopulateFilter(ctx context.Context) error {
+	if s.filter == nil {
+		return nil
+	}
+
+	tracer := otel.Tracer("FooService")
+	ctx, span := tracer.Start(ctx, "FooService.PopulateFilter")
+	defer span.End()
+
+	count := 0
+	input := data.FooReadListInput{Offset: 0, Limit: fooFilterPageSize}
+	for {
+		foos, err := s.repo.FindAll(ctx, input)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to find all foo")
+			return fmt.Errorf("fail to find all foo: %w", err)
+		}
+		if len(foos) == 0 {
+			break
+		}
+
+		ids := make([]uuid.UUID, len(foos))
+		for i, foo := range foos {
+			ids[i] = foo.Id
+		}
+		if err := s.filter.Add(ctx, ids...); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "failed to add foo ids to filter")
+			return fmt.Errorf("fail to add foo ids to filter: %w", err)
+		}
+
+		count += len(foos)
+		input.Offset += len(foos)
+	}
+
+	if err := s.filter.MarkPopulated(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to mark filter as populated")
+		return fmt.Errorf("fail to mark filter as populated: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("result.count", count))
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// log returns the request-scoped logger carried by ctx, falling back to the service logger.
+func (s *FooService) log(ctx context.Context) *zap.Logger {
+	return correlation.Logger(ctx, s.logger)
+}
+
+// NewFooService initializes a new instance of FooService with the provided logger, cache policy, repository, caches, filter and messaging dependencies.
+// The list cache and the filter of existing ids are optional and may be nil. Unset TTLs and refresh timeout fall back to sane defaults.
+func NewFooService(logger *zap.Logger, cacheConfig FooCacheConfig, repo repository.IFooRepository, cache cache.IFooCache, listCache cache.IFooListCache, filter cache.IFooFilter, messaging messaging.IFooMessaging) *FooService {
+	if cacheConfig.TTL <= 0 {
+		cacheConfig.TTL = defaultFooCacheTTL
+	}
+	if cacheConfig.ListTTL <= 0 {
+		cacheConfig.ListTTL = defaultFooListCacheTTL
+	}
+	if cacheConfig.RefreshTimeout <= 0 {
+		cacheConfig.RefreshTimeout = defaultFooCacheRefreshTimeout
+	}
+
 	return &FooService{
-		logger:    logger,
-		repo:      repo,
-		cache:     cache,
-		messaging: messaging,
+		logger:      logger,
+		repo:        repo,
+		cache:       cache,
+		listCache:   listCache,
+		filter:      filter,
+		messaging:   messaging,
+		cacheConfig: cacheConfig,
 	}
 }
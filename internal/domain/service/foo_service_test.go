@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
 	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/cache"
 	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/messaging"
@@ -19,6 +21,11 @@ import (
 	"go.uber.org/zap"
 )
 
+var (
+	testFooCacheConfig = FooCacheConfig{TTL: 15 * time.Minute, StaleTTL: time.Minute, MissingTTL: 30 * time.Second}
+	testFooCacheTTL    = model.CacheTTL{Fresh: 15 * time.Minute, Stale: time.Minute}
+)
+
 func TestFooService_GetAll(t *testing.T) {
 	t.Parallel()
 	testCases := []struct {
@@ -94,7 +101,7 @@ func TestFooService_GetAll(t *testing.T) {
 			mockRepo := new(repository.MockFooRepository)
 			mockCache := new(cache.MockFooCache)
 			mockMessaging := new(messaging.MockFooMessaging)
-			service := NewFooService(zap.NewNop(), mockRepo, mockCache, mockMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
 
 			testCase.setupMockRepository(mockRepo)
 			testCase.setupMockCache(mockCache)
@@ -146,7 +153,7 @@ func TestFooService_GetByID(t *testing.T) {
 					"GetByID",
 					mock.Anything,
 					uuid.MustParse("20000000-0000-0000-0000-000000000001"),
-				).Return((*model.Foo)(nil), nil)
+				).Return((*model.FooCacheEntry)(nil), nil)
 
 				mockCache.On("Set",
 					mock.Anything,
@@ -158,7 +165,7 @@ func TestFooService_GetByID(t *testing.T) {
 						Weight:    1.5,
 						CreatedAt: createdTime,
 						UpdatedAt: &now,
-					}, FooCacheExpiration).Return(nil)
+					}, testFooCacheTTL).Return(nil)
 			},
 			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
 				mockRepo.On(
@@ -196,14 +203,17 @@ func TestFooService_GetByID(t *testing.T) {
 					"GetByID",
 					mock.Anything,
 					uuid.MustParse("20000000-0000-0000-0000-000000000001"),
-				).Return(&model.Foo{
-					Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
-					Label:     "foo1",
-					Secret:    "secret1",
-					Value:     1,
-					Weight:    1.5,
-					CreatedAt: createdTime,
-					UpdatedAt: &now,
+				).Return(&model.FooCacheEntry{
+					Foo: &model.Foo{
+						Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+						Label:     "foo1",
+						Secret:    "secret1",
+						Value:     1,
+						Weight:    1.5,
+						CreatedAt: createdTime,
+						UpdatedAt: &now,
+					},
+					FreshUntil: now.Add(time.Hour),
 				}, nil)
 			},
 			setupMockRepository: func(mockRepo *repository.MockFooRepository) {},
@@ -228,7 +238,7 @@ func TestFooService_GetByID(t *testing.T) {
 					"GetByID",
 					mock.Anything,
 					uuid.MustParse("20000000-0000-0000-0000-000000000001"),
-				).Return((*model.Foo)(nil), fmt.Errorf("cache error"))
+				).Return((*model.FooCacheEntry)(nil), fmt.Errorf("cache error"))
 
 				mockCache.On("Set",
 					mock.Anything,
@@ -240,7 +250,7 @@ func TestFooService_GetByID(t *testing.T) {
 						Weight:    1.5,
 						CreatedAt: createdTime,
 						UpdatedAt: &now,
-					}, FooCacheExpiration).Return(fmt.Errorf("cache error"))
+					}, testFooCacheTTL).Return(fmt.Errorf("cache error"))
 			},
 			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
 				mockRepo.On(
@@ -270,7 +280,7 @@ func TestFooService_GetByID(t *testing.T) {
 					"GetByID",
 					mock.Anything,
 					uuid.MustParse("20000000-0000-0000-0000-000000000001"),
-				).Return((*model.Foo)(nil), nil)
+				).Return((*model.FooCacheEntry)(nil), nil)
 
 			},
 			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
@@ -290,7 +300,7 @@ func TestFooService_GetByID(t *testing.T) {
 			mockRepo := new(repository.MockFooRepository)
 			mockCache := new(cache.MockFooCache)
 			mockMessaging := new(messaging.MockFooMessaging)
-			service := NewFooService(zap.NewNop(), mockRepo, mockCache, mockMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
 
 			testCase.setupMockRepository(mockRepo)
 			testCase.setupMockCache(mockCache)
@@ -350,7 +360,7 @@ func TestFooService_Create(t *testing.T) {
 						foo.Secret == "secret_create" &&
 						foo.Value == 1 &&
 						foo.Weight == 1.5
-				}), FooCacheExpiration).Return(nil)
+				}), testFooCacheTTL).Return(nil)
 			},
 			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
 				mockMess.On("PublishFooCreated", mock.Anything, mock.MatchedBy(func(foo *model.Foo) bool {
@@ -391,7 +401,7 @@ func TestFooService_Create(t *testing.T) {
 						foo.Secret == "secret_create" &&
 						foo.Value == 1 &&
 						foo.Weight == 1.5
-				}), FooCacheExpiration).Return(fmt.Errorf("cache error"))
+				}), testFooCacheTTL).Return(fmt.Errorf("cache error"))
 			},
 			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
 				mockMess.On("PublishFooCreated", mock.Anything, mock.MatchedBy(func(foo *model.Foo) bool {
@@ -447,7 +457,7 @@ func TestFooService_Create(t *testing.T) {
 						foo.Secret == "secret_create" &&
 						foo.Value == 1 &&
 						foo.Weight == 1.5
-				}), FooCacheExpiration).Return(nil)
+				}), testFooCacheTTL).Return(nil)
 			},
 			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
 				mockMess.On("PublishFooCreated", mock.Anything, mock.MatchedBy(func(foo *model.Foo) bool {
@@ -466,7 +476,7 @@ func TestFooService_Create(t *testing.T) {
 			mockRepo := new(repository.MockFooRepository)
 			mockCache := new(cache.MockFooCache)
 			mockMessaging := new(messaging.MockFooMessaging)
-			service := NewFooService(zap.NewNop(), mockRepo, mockCache, mockMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
 
 			testCase.setupMockRepository(mockRepo)
 			testCase.setupMockCache(mockCache)
@@ -536,7 +546,7 @@ func TestFooService_Update(t *testing.T) {
 					Secret: "secret_update",
 					Value:  1,
 					Weight: 1.5,
-				}, FooCacheExpiration).Return(nil)
+				}, testFooCacheTTL).Return(nil)
 			},
 			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
 				mockMess.On("PublishFooUpdated", mock.Anything, &model.Foo{
@@ -587,7 +597,7 @@ func TestFooService_Update(t *testing.T) {
 					Secret: "secret_update",
 					Value:  1,
 					Weight: 1.5,
-				}, FooCacheExpiration).Return(fmt.Errorf("cache error"))
+				}, testFooCacheTTL).Return(fmt.Errorf("cache error"))
 			},
 			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
 				mockMess.On("PublishFooUpdated", mock.Anything, &model.Foo{
@@ -628,18 +638,21 @@ func TestFooService_Update(t *testing.T) {
 			mockRepo := new(repository.MockFooRepository)
 			mockCache := new(cache.MockFooCache)
 			mockMessaging := new(messaging.MockFooMessaging)
-			service := NewFooService(zap.NewNop(), mockRepo, mockCache, mockMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
 
 			testCase.setupMockRepository(mockRepo)
 			testCase.setupMockCache(mockCache)
 			testCase.setupMockMessaging(mockMessaging)
 
-			err := service.Update(context.Background(), testCase.input)
+			foo, err := service.Update(context.Background(), testCase.input)
 
 			if testCase.expectedError != nil {
 				assert.EqualError(t, err, testCase.expectedError.Error())
+				assert.Nil(t, foo)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, "foo_update", foo.Label)
+				assert.Equal(t, "secret_update", foo.Secret)
 			}
 		})
 	}
@@ -733,7 +746,7 @@ func TestFooService_DeleteByID(t *testing.T) {
 			mockRepo := new(repository.MockFooRepository)
 			mockCache := new(cache.MockFooCache)
 			mockMessaging := new(messaging.MockFooMessaging)
-			service := NewFooService(zap.NewNop(), mockRepo, mockCache, mockMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
 
 			testCase.setupMockRepository(mockRepo)
 			testCase.setupMockCache(mockCache)
@@ -749,3 +762,442 @@ func TestFooService_DeleteByID(t *testing.T) {
 		})
 	}
 }
+
+func TestFooService_GetByID_Stale(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	stale := &model.Foo{Id: id, Label: "stale", Secret: "secret1", Value: 1, Weight: 1.5}
+	fresh := &model.Foo{Id: id, Label: "fresh", Secret: "secret1", Value: 1, Weight: 1.5}
+
+	mockRepo := new(repository.MockFooRepository)
+	mockCache := new(cache.MockFooCache)
+	mockMessaging := new(messaging.MockFooMessaging)
+	service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
+
+	refreshed := make(chan struct{})
+	mockCache.On("GetByID", mock.Anything, id).Return(&model.FooCacheEntry{Foo: stale, FreshUntil: time.Now().Add(-time.Second)}, nil)
+	mockRepo.On("FindByID", mock.Anything, id).Return(fresh, nil)
+	mockCache.On("Set", mock.Anything, fresh, testFooCacheTTL).Return(nil).Run(func(mock.Arguments) {
+		close(refreshed)
+	})
+
+	result, err := service.GetByID(context.Background(), id)
+
+	assert.NoError(t, err)
+	assert.Equal(t, stale, result)
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale entry was not refreshed")
+	}
+	mockRepo.AssertNumberOfCalls(t, "FindByID", 1)
+}
+
+func TestFooService_GetByID_Coalesced(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	foo := &model.Foo{Id: id, Label: "foo1", Secret: "secret1", Value: 1, Weight: 1.5}
+	const callers = 10
+
+	mockRepo := new(repository.MockFooRepository)
+	mockCache := new(cache.MockFooCache)
+	mockMessaging := new(messaging.MockFooMessaging)
+	service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
+
+	// Every caller misses the cache before the repository answers.
+	var misses sync.WaitGroup
+	misses.Add(callers)
+	mockCache.On("GetByID", mock.Anything, id).Return((*model.FooCacheEntry)(nil), nil).Run(func(mock.Arguments) {
+		misses.Done()
+	})
+	mockRepo.On("FindByID", mock.Anything, id).Return(foo, nil).Run(func(mock.Arguments) {
+		misses.Wait()
+		time.Sleep(50 * time.Millisecond)
+	})
+	mockCache.On("Set", mock.Anything, foo, testFooCacheTTL).Return(nil)
+
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := service.GetByID(context.Background(), id)
+			assert.NoError(t, err)
+			assert.Equal(t, foo, result)
+		}()
+	}
+	wg.Wait()
+
+	mockRepo.AssertNumberOfCalls(t, "FindByID", 1)
+}
+
+func TestFooService_GetByID_ConcurrentWrite(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+
+	testCases := []struct {
+		name  string
+		write func(*FooService) error
+
+		setupMocks func(*repository.MockFooRepository, *cache.MockFooCache, *messaging.MockFooMessaging)
+	}{
+		{
+			name: "Success Case - Update",
+			write: func(s *FooService) error {
+				_, err := s.Update(context.Background(), &data.FooUpdateInput{Id: id, Label: "new", Secret: "secret1", Value: 1, Weight: 1.5})
+				return err
+			},
+			setupMocks: func(mockRepo *repository.MockFooRepository, mockCache *cache.MockFooCache, mockMess *messaging.MockFooMessaging) {
+				mockRepo.On("FindByID", mock.Anything, id).Return(&model.Foo{Id: id, Label: "old", Secret: "secret1", Value: 1, Weight: 1.5}, nil)
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockMess.On("PublishFooUpdated", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Success Case - Delete",
+			write: func(s *FooService) error {
+				return s.DeleteByID(context.Background(), id)
+			},
+			setupMocks: func(mockRepo *repository.MockFooRepository, mockCache *cache.MockFooCache, mockMess *messaging.MockFooMessaging) {
+				mockRepo.On("DeleteByID", mock.Anything, id).Return(nil)
+				mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				mockCache.On("DeleteByID", mock.Anything, id).Return(nil)
+				mockMess.On("PublishFooDeleted", mock.Anything, id).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockFooRepository)
+			mockCache := new(cache.MockFooCache)
+			mockMessaging := new(messaging.MockFooMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, nil, mockMessaging)
+
+			// The load reads the previous version, then stalls until the write has updated the cache.
+			reading, release := make(chan struct{}), make(chan struct{})
+			mockCache.On("GetByID", mock.Anything, id).Return((*model.FooCacheEntry)(nil), nil)
+			mockRepo.On("FindByID", mock.Anything, id).Return(&model.Foo{Id: id, Label: "old", Secret: "secret1", Value: 1, Weight: 1.5}, nil).
+				Run(func(mock.Arguments) {
+					close(reading)
+					<-release
+				}).Once()
+			testCase.setupMocks(mockRepo, mockCache, mockMessaging)
+
+			loaded := make(chan struct{})
+			go func() {
+				defer close(loaded)
+				result, err := service.GetByID(context.Background(), id)
+				assert.NoError(t, err)
+				assert.Equal(t, "old", result.Label)
+			}()
+			<-reading
+			assert.NoError(t, testCase.write(service))
+			close(release)
+			<-loaded
+
+			for _, call := range mockCache.Calls {
+				if call.Method == "Set" {
+					assert.NotEqual(t, "old", call.Arguments.Get(1).(*model.Foo).Label, "the load overwrote the write in the cache")
+				}
+			}
+		})
+	}
+}
+
+func TestFooService_GetAll_ConcurrentWrite(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	input := data.FooReadListInput{Offset: 0, Limit: 10}
+
+	mockRepo := new(repository.MockFooRepository)
+	mockCache := new(cache.MockFooCache)
+	mockListCache := new(cache.MockFooListCache)
+	mockMessaging := new(messaging.MockFooMessaging)
+	service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, mockListCache, nil, mockMessaging)
+
+	// The page is read before the deletion, then stalls until the deletion has invalidated the lists.
+	reading, release := make(chan struct{}), make(chan struct{})
+	mockListCache.On("GetList", mock.Anything, input).Return([]*model.Foo(nil), false, nil)
+	mockRepo.On("FindAll", mock.Anything, input).Return([]*model.Foo{{Id: id, Label: "old"}}, nil).Run(func(mock.Arguments) {
+		close(reading)
+		<-release
+	})
+	mockRepo.On("DeleteByID", mock.Anything, id).Return(nil)
+	mockCache.On("DeleteByID", mock.Anything, id).Return(nil)
+	mockListCache.On("InvalidateAll", mock.Anything).Return(nil)
+	mockMessaging.On("PublishFooDeleted", mock.Anything, id).Return(nil)
+
+	loaded := make(chan struct{})
+	go func() {
+		defer close(loaded)
+		_, err := service.GetAll(context.Background(), input)
+		assert.NoError(t, err)
+	}()
+	<-reading
+	assert.NoError(t, service.DeleteByID(context.Background(), id))
+	close(release)
+	<-loaded
+
+	mockListCache.AssertNotCalled(t, "SetList", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFooService_CacheTTL(t *testing.T) {
+	t.Parallel()
+	service := NewFooService(zap.NewNop(), FooCacheConfig{TTL: time.Minute, Jitter: 0.1, StaleTTL: time.Second}, nil, nil, nil, nil, nil)
+
+	for range 100 {
+		ttl := service.cacheTTL()
+		assert.GreaterOrEqual(t, ttl.Fresh, 54*time.Second)
+		assert.LessOrEqual(t, ttl.Fresh, 66*time.Second)
+		assert.Equal(t, time.Second, ttl.Stale)
+	}
+}
+
+func TestFooService_GetByID_Missing(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("40400000-0000-0000-0000-000000000000")
+	notFound := port.NewErrNotFound("foo", "id", id.String())
+
+	testCases := []struct {
+		name          string
+		expectedError error
+
+		setupMockCache      func(*cache.MockFooCache)
+		setupMockFilter     func(*cache.MockFooFilter)
+		setupMockRepository func(*repository.MockFooRepository)
+	}{
+		{
+			name:          "Success Case - Tombstone Hit",
+			expectedError: fmt.Errorf("fail to find foo by id: %w", notFound),
+			setupMockCache: func(mockCache *cache.MockFooCache) {
+				mockCache.On("GetByID", mock.Anything, id).Return(&model.FooCacheEntry{Missing: true, FreshUntil: time.Now().Add(time.Minute)}, nil)
+			},
+			setupMockFilter:     func(mockFilter *cache.MockFooFilter) {},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {},
+		},
+		{
+			name:          "Success Case - Tombstone Created",
+			expectedError: fmt.Errorf("fail to find foo by id: %w", notFound),
+			setupMockCache: func(mockCache *cache.MockFooCache) {
+				mockCache.On("GetByID", mock.Anything, id).Return((*model.FooCacheEntry)(nil), nil)
+				mockCache.On("SetMissing", mock.Anything, id, 30*time.Second).Return(nil)
+			},
+			setupMockFilter: func(mockFilter *cache.MockFooFilter) {
+				mockFilter.On("MightContain", mock.Anything, id).Return(true, nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("FindByID", mock.Anything, id).Return((*model.Foo)(nil), notFound)
+			},
+		},
+		{
+			name:          "Success Case - Filter Rejected",
+			expectedError: fmt.Errorf("fail to find foo by id: %w", notFound),
+			setupMockCache: func(mockCache *cache.MockFooCache) {
+				mockCache.On("GetByID", mock.Anything, id).Return((*model.FooCacheEntry)(nil), nil)
+			},
+			setupMockFilter: func(mockFilter *cache.MockFooFilter) {
+				mockFilter.On("MightContain", mock.Anything, id).Return(false, nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockFooRepository)
+			mockCache := new(cache.MockFooCache)
+			mockFilter := new(cache.MockFooFilter)
+			mockMessaging := new(messaging.MockFooMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, nil, mockFilter, mockMessaging)
+
+			testCase.setupMockRepository(mockRepo)
+			testCase.setupMockCache(mockCache)
+			testCase.setupMockFilter(mockFilter)
+
+			result, err := service.GetByID(context.Background(), id)
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, testCase.expectedError.Error())
+			assert.ErrorAs(t, err, new(*port.ErrNotFound))
+			mockRepo.AssertExpectations(t)
+			mockCache.AssertExpectations(t)
+			mockFilter.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFooService_PopulateFilter(t *testing.T) {
+	t.Parallel()
+	id1 := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	id2 := uuid.MustParse("20000000-0000-0000-0000-000000000002")
+
+	mockRepo := new(repository.MockFooRepository)
+	mockFilter := new(cache.MockFooFilter)
+	service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, nil, nil, mockFilter, nil)
+
+	mockRepo.On("FindAll", mock.Anything, data.FooReadListInput{Offset: 0, Limit: fooFilterPageSize}).
+		Return([]*model.Foo{{Id: id1}, {Id: id2}}, nil)
+	mockRepo.On("FindAll", mock.Anything, data.FooReadListInput{Offset: 2, Limit: fooFilterPageSize}).
+		Return([]*model.Foo{}, nil)
+	mockFilter.On("Add", mock.Anything, []uuid.UUID{id1, id2}).Return(nil)
+	mockFilter.On("MarkPopulated", mock.Anything).Return(nil)
+
+	assert.NoError(t, service.PopulateFilter(context.Background()))
+	mockRepo.AssertExpectations(t)
+	mockFilter.AssertExpectations(t)
+}
+
+func TestFooService_GetAll_ListCache(t *testing.T) {
+	t.Parallel()
+	input := data.FooReadListInput{Offset: 0, Limit: 10}
+	foos := []*model.Foo{
+		{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001"), Label: "Foo1"},
+		{Id: uuid.MustParse("20000000-0000-0000-0000-000000000002"), Label: "Foo2"},
+	}
+
+	testCases := []struct {
+		name          string
+		expectedCount int
+
+		setupMockListCache  func(*cache.MockFooListCache)
+		setupMockRepository func(*repository.MockFooRepository)
+	}{
+		{
+			name:          "Success Case - Cache Hit",
+			expectedCount: 2,
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("GetList", mock.Anything, input).Return(foos, true, nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {},
+		},
+		{
+			name:          "Success Case - Cache Miss",
+			expectedCount: 2,
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("GetList", mock.Anything, input).Return(([]*model.Foo)(nil), false, nil)
+				mockListCache.On("SetList", mock.Anything, input, foos, time.Minute).Return(nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("FindAll", mock.Anything, input).Return(foos, nil)
+			},
+		},
+		{
+			name:          "Success Case - Cache Error",
+			expectedCount: 2,
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("GetList", mock.Anything, input).Return(([]*model.Foo)(nil), false, errors.New("cache error"))
+				mockListCache.On("SetList", mock.Anything, input, foos, time.Minute).Return(errors.New("cache error"))
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("FindAll", mock.Anything, input).Return(foos, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockFooRepository)
+			mockListCache := new(cache.MockFooListCache)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, nil, mockListCache, nil, nil)
+
+			testCase.setupMockRepository(mockRepo)
+			testCase.setupMockListCache(mockListCache)
+
+			result, err := service.GetAll(context.Background(), input)
+
+			assert.NoError(t, err)
+			assert.Len(t, result, testCase.expectedCount)
+			mockRepo.AssertExpectations(t)
+			mockListCache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFooService_ListCacheInvalidation(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	foo := &model.Foo{Id: id, Label: "foo1", Secret: "secret1", Value: 1, Weight: 1.5}
+
+	testCases := []struct {
+		name   string
+		action func(*FooService) error
+
+		setupMockListCache  func(*cache.MockFooListCache)
+		setupMockRepository func(*repository.MockFooRepository)
+		setupMockMessaging  func(*messaging.MockFooMessaging)
+	}{
+		{
+			name: "Success Case - Create",
+			action: func(s *FooService) error {
+				_, err := s.Create(context.Background(), data.FooCreateInput{Label: "foo1", Secret: "secret1", Value: 1, Weight: 1.5})
+				return err
+			},
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("InvalidateAll", mock.Anything).Return(nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+			},
+			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
+				mockMess.On("PublishFooCreated", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Success Case - Update",
+			action: func(s *FooService) error {
+				_, err := s.Update(context.Background(), &data.FooUpdateInput{Id: id, Label: "foo2", Secret: "secret1", Value: 1, Weight: 1.5})
+				return err
+			},
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("InvalidateByIDs", mock.Anything, []uuid.UUID{id}).Return(nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("FindByID", mock.Anything, id).Return(foo, nil)
+				mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
+				mockMess.On("PublishFooUpdated", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name: "Success Case - Delete",
+			action: func(s *FooService) error {
+				return s.DeleteByID(context.Background(), id)
+			},
+			setupMockListCache: func(mockListCache *cache.MockFooListCache) {
+				mockListCache.On("InvalidateAll", mock.Anything).Return(nil)
+			},
+			setupMockRepository: func(mockRepo *repository.MockFooRepository) {
+				mockRepo.On("DeleteByID", mock.Anything, id).Return(nil)
+			},
+			setupMockMessaging: func(mockMess *messaging.MockFooMessaging) {
+				mockMess.On("PublishFooDeleted", mock.Anything, id).Return(nil)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockFooRepository)
+			mockCache := new(cache.MockFooCache)
+			mockListCache := new(cache.MockFooListCache)
+			mockMessaging := new(messaging.MockFooMessaging)
+			service := NewFooService(zap.NewNop(), testFooCacheConfig, mockRepo, mockCache, mockListCache, nil, mockMessaging)
+
+			mockCache.On("Set", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockCache.On("DeleteByID", mock.Anything, mock.Anything).Return(nil)
+			testCase.setupMockRepository(mockRepo)
+			testCase.setupMockListCache(mockListCache)
+			testCase.setupMockMessaging(mockMessaging)
+
+			assert.NoError(t, testCase.action(service))
+			mockListCache.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package service
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	cacheResultHit       = "hit"
+	cacheResultMiss      = "miss"
+	cacheResultStale     = "stale"
+	cacheResultCoalesced = "coalesced"
+	cacheResultMissing   = "missing"
+	cacheResultFiltered  = "filtered"
+
+	webhookResultSucceeded = "succeeded"
+	webhookResultRetried   = "retried"
+	webhookResultFailed    = "failed"
+	webhookResultDiscarded = "discarded"
+)
+
+var (
+	FooCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foo_cache_requests_total",
+			Help: "Total number of Foo reads by cache result (hit, miss, stale, coalesced, missing, filtered)",
+		},
+		[]string{"result"},
+	)
+
+	FooListCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foo_list_cache_requests_total",
+			Help: "Total number of Foo list reads by cache result (hit, miss, coalesced)",
+		},
+		[]string{"result"},
+	)
+
+	WebhookDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of webhook delivery attempts by result (succeeded, retried, failed, discarded)",
+		},
+		[]string{"result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(FooCacheRequests)
+	prometheus.MustRegister(FooListCacheRequests)
+	prometheus.MustRegister(WebhookDeliveries)
+}
+
+func observeCache(result string) {
+	FooCacheRequests.WithLabelValues(result).Inc()
+}
+
+func observeListCache(result string) {
+	FooListCacheRequests.WithLabelValues(result).Inc()
+}
+
+func observeWebhookDelivery(result string) {
+	WebhookDeliveries.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStaticAuthService_VerifyToken(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		configured    string
+		token         string
+		expectedError bool
+	}{
+		{
+			name:       "Success Case - Static token",
+			configured: "dev-token",
+			token:      "dev-token",
+		},
+		{
+			name:          "Failure Case - Other token",
+			configured:    "dev-token",
+			token:         "dev-token2",
+			expectedError: true,
+		},
+		{
+			name:          "Failure Case - No token configured",
+			configured:    "",
+			token:         "",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			auth := NewStaticAuthService(zap.NewNop(), tc.configured)
+
+			idToken, err := auth.VerifyToken(context.Background(), tc.token)
+			if tc.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, idToken)
+				return
+			}
+
+			assert.NoError(t, err)
+			claims, err := auth.GetClaims(idToken)
+			assert.NoError(t, err)
+			assert.Equal(t, StaticSubject, claims.Subject)
+			assert.Equal(t, StaticSubject, claims.PreferredUsername)
+		})
+	}
+}
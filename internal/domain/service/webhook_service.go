@@ -0,0 +1,534 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/webhook"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+	webhook2 "github.com/TancelinMazzotti/astigo/pkg/webhook"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultWebhookMaxAttempts      = 8
+	defaultWebhookBackOff          = 30 * time.Second
+	defaultWebhookMaxBackOff       = 6 * time.Hour
+	defaultWebhookFailureThreshold = 20
+	defaultWebhookBatchSize        = 10
+	defaultWebhookLease            = time.Minute
+
+	webhookSecretPrefix = "whsec_"
+	webhookContentType  = "application/cloudevents+json"
+	webhookUserAgent    = "Astigo-Webhook/1.0"
+	// webhookErrorMaxLength bounds the error stored with a failed attempt.
+	webhookErrorMaxLength = 1024
+)
+
+var (
+	_ service.IWebhookService = (*WebhookService)(nil)
+
+	// webhookEventTypes are the types of the events enqueued for the webhooks, those of the Foo events.
+	webhookEventTypes = []string{events.FooCreatedType, events.FooUpdatedType, events.FooDeletedType}
+)
+
+// WebhookConfig holds the delivery policy of the webhooks.
+// A failed attempt is retried after BackOff, doubled on every attempt up to MaxBackOff, until MaxAttempts is reached.
+// A subscription is disabled after FailureThreshold consecutive failed attempts, whatever the deliveries.
+// Every run of DeliverPending attempts up to BatchSize deliveries, leased for Lease to the replica running it.
+type WebhookConfig struct {
+	MaxAttempts      int           `mapstructure:"max_attempts"`
+	BackOff          time.Duration `mapstructure:"backoff"`
+	MaxBackOff       time.Duration `mapstructure:"max_backoff"`
+	FailureThreshold int           `mapstructure:"failure_threshold"`
+	BatchSize        int           `mapstructure:"batch_size"`
+	Lease            time.Duration `mapstructure:"lease"`
+}
+
+// WebhookService manages the webhook subscriptions and delivers the events to their endpoints.
+type WebhookService struct {
+	logger *zap.Logger
+	repo   repository.IWebhookRepository
+	sender webhook.IWebhookSender
+	config WebhookConfig
+
+	now func() time.Time
+}
+
+// GetAll retrieves a paginated list of webhook subscriptions.
+func (s *WebhookService) GetAll(ctx context.Context, input data.PaginationOffset) ([]*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.GetAll")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("offset", input.Offset),
+		attribute.Int("limit", input.Limit),
+	)
+
+	subscriptions, err := s.repo.FindAll(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find all webhooks")
+		s.log(ctx).Debug("fail to find all webhooks", zap.Error(err))
+		return nil, fmt.Errorf("fail to find all webhooks: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(subscriptions)))
+	return subscriptions, nil
+}
+
+// GetByID fetches a webhook subscription by its unique identifier.
+func (s *WebhookService) GetByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.GetByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	subscription, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhook by id")
+		s.log(ctx).Debug("fail to find webhook by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find webhook by id: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return subscription, nil
+}
+
+// Create registers a webhook subscription. Its secret is generated when the input does not provide one.
+func (s *WebhookService) Create(ctx context.Context, input data.WebhookCreateInput) (*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.Create")
+	defer span.End()
+
+	subscription := &model.WebhookSubscription{
+		Id:         uuid.New(),
+		Url:        input.Url,
+		EventTypes: input.EventTypes,
+		Secret:     input.Secret,
+		Enabled:    true,
+	}
+	if subscription.Secret == "" {
+		secret, err := newWebhookSecret()
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "fail to generate webhook secret")
+			return nil, fmt.Errorf("fail to generate webhook secret: %w", err)
+		}
+		subscription.Secret = secret
+	}
+
+	span.SetAttributes(
+		attribute.String("webhook.id", subscription.Id.String()),
+		attribute.String("webhook.url", subscription.Url),
+		attribute.StringSlice("webhook.event_types", subscription.EventTypes),
+	)
+
+	if err := s.validateWebhook(ctx, subscription); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid input")
+		s.log(ctx).Debug("invalid input", zap.Error(err))
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := s.repo.Create(ctx, subscription); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to create webhook")
+		s.log(ctx).Debug("fail to create webhook", zap.Error(err))
+		return nil, fmt.Errorf("fail to create webhook: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return subscription, nil
+}
+
+// Update modifies a webhook subscription. Enabling a disabled subscription resets its consecutive failures.
+func (s *WebhookService) Update(ctx context.Context, input data.WebhookUpdateInput) (*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", input.Id.String()))
+
+	subscription, err := s.repo.FindByID(ctx, input.Id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhook by id")
+		s.log(ctx).Debug("fail to find webhook by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find webhook by id: %w", err)
+	}
+
+	subscription.Url = input.Url
+	subscription.EventTypes = input.EventTypes
+	if input.Secret.Set {
+		subscription.Secret = input.Secret.Value
+	}
+	switch {
+	case input.Enabled && !subscription.Enabled:
+		subscription.ConsecutiveFailures = 0
+		subscription.DisabledAt = nil
+	case !input.Enabled && subscription.Enabled:
+		now := s.now()
+		subscription.DisabledAt = &now
+	}
+	subscription.Enabled = input.Enabled
+
+	span.SetAttributes(
+		attribute.String("webhook.url", subscription.Url),
+		attribute.StringSlice("webhook.event_types", subscription.EventTypes),
+		attribute.Bool("webhook.enabled", subscription.Enabled),
+	)
+
+	if err := s.validateWebhook(ctx, subscription); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "invalid input")
+		s.log(ctx).Debug("invalid input", zap.Error(err))
+		return nil, fmt.Errorf("invalid input: %w", err)
+	}
+
+	if err := s.repo.Update(ctx, subscription); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to update webhook")
+		s.log(ctx).Debug("fail to update webhook", zap.Error(err))
+		return nil, fmt.Errorf("fail to update webhook: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return subscription, nil
+}
+
+// DeleteByID removes a webhook subscription along with its deliveries.
+func (s *WebhookService) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.DeleteByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	if err := s.repo.DeleteByID(ctx, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to delete webhook by id")
+		s.log(ctx).Debug("fail to delete webhook by id", zap.Error(err))
+		return fmt.Errorf("fail to delete webhook by id: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// GetDeliveries retrieves the deliveries of a webhook subscription, most recent first.
+func (s *WebhookService) GetDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.GetDeliveries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", input.SubscriptionId.String()),
+		attribute.Int("offset", input.Offset),
+		attribute.Int("limit", input.Limit),
+	)
+
+	// An unknown subscription is reported as such rather than as an empty list.
+	if _, err := s.repo.FindByID(ctx, input.SubscriptionId); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhook by id")
+		s.log(ctx).Debug("fail to find webhook by id", zap.Error(err))
+		return nil, fmt.Errorf("fail to find webhook by id: %w", err)
+	}
+
+	deliveries, err := s.repo.FindDeliveries(ctx, input)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhook deliveries")
+		s.log(ctx).Debug("fail to find webhook deliveries", zap.Error(err))
+		return nil, fmt.Errorf("fail to find webhook deliveries: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(deliveries)))
+	return deliveries, nil
+}
+
+// Enqueue schedules the delivery of an event to every enabled subscription of its type. Enqueuing the same event again
+// does not deliver it twice to a subscription.
+func (s *WebhookService) Enqueue(ctx context.Context, input data.WebhookEventInput) error {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.Enqueue")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("event.id", input.Id),
+		attribute.String("event.type", input.Type),
+	)
+
+	subscriptions, err := s.repo.FindByEventType(ctx, input.Type)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhooks by event type")
+		s.log(ctx).Debug("fail to find webhooks by event type", zap.Error(err))
+		return fmt.Errorf("fail to find webhooks by event type: %w", err)
+	}
+	span.SetAttributes(attribute.Int("webhook.count", len(subscriptions)))
+	if len(subscriptions) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	now := s.now()
+	deliveries := make([]*model.WebhookDelivery, len(subscriptions))
+	for i, subscription := range subscriptions {
+		deliveries[i] = &model.WebhookDelivery{
+			Id:             uuid.New(),
+			SubscriptionId: subscription.Id,
+			EventId:        input.Id,
+			EventType:      input.Type,
+			Payload:        input.Payload,
+			Status:         model.WebhookDeliveryPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+		}
+	}
+
+	if err := s.repo.CreateDeliveries(ctx, deliveries...); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to create webhook deliveries")
+		s.log(ctx).Debug("fail to create webhook deliveries", zap.Error(err))
+		return fmt.Errorf("fail to create webhook deliveries: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeliverPending attempts the deliveries that are due, concurrently, and returns how many were attempted.
+// The failure of an attempt is stored with its delivery and does not fail the run.
+func (s *WebhookService) DeliverPending(ctx context.Context) (int, error) {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.DeliverPending")
+	defer span.End()
+
+	deliveries, err := s.repo.ClaimDeliveries(ctx, s.now(), s.config.BatchSize, s.config.Lease)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to claim webhook deliveries")
+		s.log(ctx).Debug("fail to claim webhook deliveries", zap.Error(err))
+		return 0, fmt.Errorf("fail to claim webhook deliveries: %w", err)
+	}
+	span.SetAttributes(attribute.Int("webhook.delivery.count", len(deliveries)))
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.deliver(ctx, delivery); err != nil {
+				span.RecordError(err)
+				s.log(ctx).Warn("fail to store webhook delivery",
+					zap.Stringer("delivery_id", delivery.Id),
+					zap.Error(err),
+				)
+			}
+		}()
+	}
+	wg.Wait()
+
+	span.SetStatus(codes.Ok, "")
+	return len(deliveries), nil
+}
+
+// deliver attempts a delivery and stores its outcome: succeeded, pending until its next attempt, or failed once its
+// attempts are exhausted or its subscription is disabled.
+func (s *WebhookService) deliver(ctx context.Context, delivery *model.WebhookDelivery) error {
+	tracer := otel.Tracer("WebhookService")
+	ctx, span := tracer.Start(ctx, "WebhookService.deliver")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", delivery.SubscriptionId.String()),
+		attribute.String("webhook.delivery.id", delivery.Id.String()),
+		attribute.String("event.id", delivery.EventId),
+		attribute.String("event.type", delivery.EventType),
+	)
+
+	subscription, err := s.repo.FindByID(ctx, delivery.SubscriptionId)
+	var notFound *port.ErrNotFound
+	switch {
+	case errors.As(err, &notFound):
+		subscription = nil
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "fail to find webhook by id")
+		return fmt.Errorf("fail to find webhook by id: %w", err)
+	}
+
+	if subscription == nil || !subscription.Enabled {
+		delivery.Status = model.WebhookDeliveryFailed
+		delivery.Error = "webhook disabled"
+		observeWebhookDelivery(webhookResultDiscarded)
+		span.SetStatus(codes.Error, "webhook disabled")
+		return s.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	delivery.Attempts++
+	span.SetAttributes(attribute.Int("webhook.delivery.attempt", delivery.Attempts))
+
+	sentAt := s.now()
+	statusCode, err := s.sender.Send(ctx, subscription.Url, s.header(subscription, delivery, sentAt), delivery.Payload)
+	delivery.Duration = s.now().Sub(sentAt)
+	delivery.StatusCode = statusCode
+	delivery.Error = ""
+	switch {
+	case err != nil:
+		delivery.Error = truncate(err.Error(), webhookErrorMaxLength)
+	case statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices:
+		delivery.Error = fmt.Sprintf("unexpected status code %d", statusCode)
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", statusCode))
+
+	if delivery.Error == "" {
+		delivery.Status = model.WebhookDeliverySucceeded
+		observeWebhookDelivery(webhookResultSucceeded)
+		if err := s.repo.RecordSuccess(ctx, subscription.Id); err != nil {
+			span.RecordError(err)
+			s.log(ctx).Warn("fail to reset webhook failures", zap.Error(err))
+		}
+		span.SetStatus(codes.Ok, "")
+		return s.repo.UpdateDelivery(ctx, delivery)
+	}
+
+	span.SetStatus(codes.Error, delivery.Error)
+	disabled, err := s.repo.RecordFailure(ctx, subscription.Id, s.config.FailureThreshold)
+	if err != nil {
+		span.RecordError(err)
+		s.log(ctx).Warn("fail to count webhook failure", zap.Error(err))
+	}
+	if disabled {
+		s.log(ctx).Warn("webhook disabled after consecutive failures",
+			zap.Stringer("webhook_id", subscription.Id),
+			zap.String("url", subscription.Url),
+			zap.Int("threshold", s.config.FailureThreshold),
+		)
+	}
+
+	if disabled || delivery.Attempts >= s.config.MaxAttempts {
+		delivery.Status = model.WebhookDeliveryFailed
+		observeWebhookDelivery(webhookResultFailed)
+	} else {
+		delivery.Status = model.WebhookDeliveryPending
+		delivery.NextAttemptAt = s.now().Add(s.backOff(delivery.Attempts))
+		observeWebhookDelivery(webhookResultRetried)
+	}
+	return s.repo.UpdateDelivery(ctx, delivery)
+}
+
+// header returns the headers of an attempt, signed at sentAt with the secret of the subscription.
+func (s *WebhookService) header(subscription *model.WebhookSubscription, delivery *model.WebhookDelivery, sentAt time.Time) http.Header {
+	header := make(http.Header)
+	header.Set("Content-Type", webhookContentType)
+	header.Set("User-Agent", webhookUserAgent)
+	header.Set(webhook2.HeaderID, delivery.Id.String())
+	header.Set(webhook2.HeaderEvent, delivery.EventType)
+	header.Set(webhook2.HeaderTimestamp, fmt.Sprintf("%d", sentAt.Unix()))
+	header.Set(webhook2.HeaderSignature, webhook2.Sign(subscription.Secret, sentAt, delivery.Payload))
+	return header
+}
+
+// backOff returns the delay before the attempt following the given one, doubled on every attempt up to MaxBackOff.
+func (s *WebhookService) backOff(attempt int) time.Duration {
+	delay := s.config.BackOff
+	for i := 1; i < attempt && delay < s.config.MaxBackOff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.config.MaxBackOff)
+}
+
+func (s *WebhookService) log(ctx context.Context) *zap.Logger {
+	return correlation.Logger(ctx, s.logger)
+}
+
+// validateWebhook checks the fields of a subscription, that every event type is delivered to the webhooks and that the
+// sender accepts to post to its url, which rejects the hosts of the private networks.
+func (s *WebhookService) validateWebhook(ctx context.Context, subscription *model.WebhookSubscription) error {
+	var validate = validator.New()
+	if err := validate.Struct(subscription); err != nil {
+		return err
+	}
+	for _, eventType := range subscription.EventTypes {
+		if !slices.Contains(webhookEventTypes, eventType) {
+			return port.NewErrInvalidReference("webhook", "event_type", eventType)
+		}
+	}
+	if err := s.sender.CheckURL(ctx, subscription.Url); err != nil {
+		return fmt.Errorf("%w: %w", port.NewErrInvalidReference("webhook", "url", subscription.Url), err)
+	}
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+	return s[:length]
+}
+
+// NewWebhookService initializes a new instance of WebhookService with the provided logger, delivery policy, repository
+// and sender. Unset fields of the policy fall back to sane defaults.
+func NewWebhookService(logger *zap.Logger, config WebhookConfig, repo repository.IWebhookRepository, sender webhook.IWebhookSender) *WebhookService {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaultWebhookMaxAttempts
+	}
+	if config.BackOff <= 0 {
+		config.BackOff = defaultWebhookBackOff
+	}
+	if config.MaxBackOff <= 0 {
+		config.MaxBackOff = defaultWebhookMaxBackOff
+	}
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = defaultWebhookFailureThreshold
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultWebhookBatchSize
+	}
+	if config.Lease <= 0 {
+		config.Lease = defaultWebhookLease
+	}
+
+	return &WebhookService{
+		logger: logger,
+		repo:   repo,
+		sender: sender,
+		config: config,
+		now:    time.Now,
+	}
+}
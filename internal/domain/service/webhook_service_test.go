@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/repository"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/webhook"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+	webhook2 "github.com/TancelinMazzotti/astigo/pkg/webhook"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+var (
+	testWebhookConfig = WebhookConfig{
+		MaxAttempts:      3,
+		BackOff:          time.Second,
+		MaxBackOff:       3 * time.Second,
+		FailureThreshold: 5,
+		BatchSize:        10,
+		Lease:            time.Minute,
+	}
+	testWebhookNow = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func newTestWebhookService(repo *repository.MockWebhookRepository, sender *webhook.MockWebhookSender) *WebhookService {
+	s := NewWebhookService(zap.NewNop(), testWebhookConfig, repo, sender)
+	s.now = func() time.Time { return testWebhookNow }
+	return s
+}
+
+func TestWebhookService_Create(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name              string
+		input             data.WebhookCreateInput
+		checkURLError     error
+		expectedErrorType error
+		expectedError     bool
+
+		setupMockRepository func(*repository.MockWebhookRepository)
+	}{
+		{
+			name:  "Success Case - Provided Secret",
+			input: data.WebhookCreateInput{Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType}, Secret: "0123456789abcdef"},
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *model.WebhookSubscription) bool {
+					return s.Secret == "0123456789abcdef" && s.Enabled
+				})).Return(nil)
+			},
+		},
+		{
+			name:  "Success Case - Generated Secret",
+			input: data.WebhookCreateInput{Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType, events.FooDeletedType}},
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *model.WebhookSubscription) bool {
+					return strings.HasPrefix(s.Secret, webhookSecretPrefix) && len(s.Secret) == len(webhookSecretPrefix)+48
+				})).Return(nil)
+			},
+		},
+		{
+			name:                "Failure Case - Invalid Url",
+			input:               data.WebhookCreateInput{Url: "partner", EventTypes: []string{events.FooCreatedType}},
+			expectedError:       true,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {},
+		},
+		{
+			name:                "Failure Case - No Event Type",
+			input:               data.WebhookCreateInput{Url: "https://partner.example.com/hooks"},
+			expectedError:       true,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {},
+		},
+		{
+			name:                "Failure Case - Unknown Event Type",
+			input:               data.WebhookCreateInput{Url: "https://partner.example.com/hooks", EventTypes: []string{"com.astigo.bar.created"}},
+			expectedErrorType:   port.ErrorInvalidReference,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {},
+		},
+		{
+			name:                "Failure Case - File Event Type",
+			input:               data.WebhookCreateInput{Url: "https://partner.example.com/hooks", EventTypes: []string{events.FileUploadedType}},
+			expectedErrorType:   port.ErrorInvalidReference,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {},
+		},
+		{
+			name:                "Failure Case - Private Url",
+			input:               data.WebhookCreateInput{Url: "http://169.254.169.254/latest", EventTypes: []string{events.FooCreatedType}},
+			checkURLError:       errors.New("private address: 169.254.169.254"),
+			expectedErrorType:   port.ErrorInvalidReference,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {},
+		},
+		{
+			name:          "Failure Case - Repository Error",
+			input:         data.WebhookCreateInput{Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType}},
+			expectedError: true,
+			setupMockRepository: func(mockRepo *repository.MockWebhookRepository) {
+				mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockWebhookRepository)
+			testCase.setupMockRepository(mockRepo)
+			mockSender := new(webhook.MockWebhookSender)
+			mockSender.On("CheckURL", mock.Anything, testCase.input.Url).Return(testCase.checkURLError).Maybe()
+			service := newTestWebhookService(mockRepo, mockSender)
+
+			result, err := service.Create(context.Background(), testCase.input)
+
+			switch {
+			case testCase.expectedErrorType != nil:
+				assert.ErrorAs(t, err, &testCase.expectedErrorType)
+			case testCase.expectedError:
+				assert.Error(t, err)
+			default:
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.Nil, result.Id)
+				assert.Equal(t, testCase.input.EventTypes, result.EventTypes)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Update(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("30000000-0000-0000-0000-000000000001")
+	disabledAt := testWebhookNow.Add(-time.Hour)
+
+	testCases := []struct {
+		name     string
+		current  model.WebhookSubscription
+		input    data.WebhookUpdateInput
+		expected func(t *testing.T, s *model.WebhookSubscription)
+	}{
+		{
+			name:    "Success Case - Enable Again",
+			current: model.WebhookSubscription{Id: id, Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType}, Secret: "0123456789abcdef", ConsecutiveFailures: 5, DisabledAt: &disabledAt},
+			input:   data.WebhookUpdateInput{Id: id, Url: "https://partner.example.com/v2/hooks", EventTypes: []string{events.FooUpdatedType}, Enabled: true},
+			expected: func(t *testing.T, s *model.WebhookSubscription) {
+				assert.True(t, s.Enabled)
+				assert.Zero(t, s.ConsecutiveFailures)
+				assert.Nil(t, s.DisabledAt)
+				assert.Equal(t, "https://partner.example.com/v2/hooks", s.Url)
+				assert.Equal(t, "0123456789abcdef", s.Secret)
+			},
+		},
+		{
+			name:    "Success Case - Disable And Rotate Secret",
+			current: model.WebhookSubscription{Id: id, Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType}, Secret: "0123456789abcdef", Enabled: true},
+			input:   data.WebhookUpdateInput{Id: id, Url: "https://partner.example.com/hooks", EventTypes: []string{events.FooCreatedType}, Secret: data.Optional[string]{Value: "fedcba9876543210", Set: true}},
+			expected: func(t *testing.T, s *model.WebhookSubscription) {
+				assert.False(t, s.Enabled)
+				assert.Equal(t, testWebhookNow, *s.DisabledAt)
+				assert.Equal(t, "fedcba9876543210", s.Secret)
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockWebhookRepository)
+			current := testCase.current
+			mockRepo.On("FindByID", mock.Anything, id).Return(&current, nil)
+			mockRepo.On("Update", mock.Anything, &current).Return(nil)
+			mockSender := new(webhook.MockWebhookSender)
+			mockSender.On("CheckURL", mock.Anything, testCase.input.Url).Return(nil)
+			service := newTestWebhookService(mockRepo, mockSender)
+
+			result, err := service.Update(context.Background(), testCase.input)
+
+			assert.NoError(t, err)
+			testCase.expected(t, result)
+			mockRepo.AssertExpectations(t)
+			mockSender.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Enqueue(t *testing.T) {
+	t.Parallel()
+	input := data.WebhookEventInput{Id: "foo.created:1", Type: events.FooCreatedType, Payload: []byte(`{"id":"1"}`)}
+	subscriptions := []*model.WebhookSubscription{
+		{Id: uuid.MustParse("30000000-0000-0000-0000-000000000001")},
+		{Id: uuid.MustParse("30000000-0000-0000-0000-000000000002")},
+	}
+
+	t.Run("Success Case - Matching Subscriptions", func(t *testing.T) {
+		t.Parallel()
+		mockRepo := new(repository.MockWebhookRepository)
+		mockRepo.On("FindByEventType", mock.Anything, events.FooCreatedType).Return(subscriptions, nil)
+		mockRepo.On("CreateDeliveries", mock.Anything, mock.MatchedBy(func(deliveries []*model.WebhookDelivery) bool {
+			if len(deliveries) != 2 {
+				return false
+			}
+			for i, delivery := range deliveries {
+				if delivery.SubscriptionId != subscriptions[i].Id || delivery.EventId != input.Id ||
+					delivery.Status != model.WebhookDeliveryPending || !delivery.NextAttemptAt.Equal(testWebhookNow) {
+					return false
+				}
+			}
+			return true
+		})).Return(nil)
+		service := newTestWebhookService(mockRepo, new(webhook.MockWebhookSender))
+
+		assert.NoError(t, service.Enqueue(context.Background(), input))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success Case - No Subscription", func(t *testing.T) {
+		t.Parallel()
+		mockRepo := new(repository.MockWebhookRepository)
+		mockRepo.On("FindByEventType", mock.Anything, events.FooCreatedType).Return([]*model.WebhookSubscription{}, nil)
+		service := newTestWebhookService(mockRepo, new(webhook.MockWebhookSender))
+
+		assert.NoError(t, service.Enqueue(context.Background(), input))
+		mockRepo.AssertNotCalled(t, "CreateDeliveries", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure Case - Repository Error", func(t *testing.T) {
+		t.Parallel()
+		mockRepo := new(repository.MockWebhookRepository)
+		mockRepo.On("FindByEventType", mock.Anything, events.FooCreatedType).Return(([]*model.WebhookSubscription)(nil), errors.New("repository error"))
+		service := newTestWebhookService(mockRepo, new(webhook.MockWebhookSender))
+
+		assert.EqualError(t, service.Enqueue(context.Background(), input), "fail to find webhooks by event type: repository error")
+	})
+}
+
+func TestWebhookService_DeliverPending(t *testing.T) {
+	t.Parallel()
+	subscriptionID := uuid.MustParse("30000000-0000-0000-0000-000000000001")
+	payload := []byte(`{"specversion":"1.0"}`)
+
+	testCases := []struct {
+		name               string
+		attempts           int
+		enabled            bool
+		statusCode         int
+		sendErr            error
+		disabled           bool
+		expectedStatus     model.WebhookDeliveryStatus
+		expectedAttempts   int
+		expectedNextAttempt time.Time
+		expectedError      string
+	}{
+		{name: "Success Case - Delivered", enabled: true, statusCode: http.StatusNoContent, expectedStatus: model.WebhookDeliverySucceeded, expectedAttempts: 1},
+		{name: "Failure Case - Retried", attempts: 1, enabled: true, statusCode: http.StatusInternalServerError, expectedStatus: model.WebhookDeliveryPending, expectedAttempts: 2, expectedNextAttempt: testWebhookNow.Add(2 * time.Second), expectedError: "unexpected status code 500"},
+		{name: "Failure Case - Retried Without Response", enabled: true, sendErr: errors.New("timeout"), expectedStatus: model.WebhookDeliveryPending, expectedAttempts: 1, expectedNextAttempt: testWebhookNow.Add(time.Second), expectedError: "timeout"},
+		{name: "Failure Case - Attempts Exhausted", attempts: 2, enabled: true, statusCode: http.StatusGone, expectedStatus: model.WebhookDeliveryFailed, expectedAttempts: 3, expectedError: "unexpected status code 410"},
+		{name: "Failure Case - Subscription Disabled By Failure", enabled: true, statusCode: http.StatusBadGateway, disabled: true, expectedStatus: model.WebhookDeliveryFailed, expectedAttempts: 1, expectedError: "unexpected status code 502"},
+		{name: "Failure Case - Subscription Disabled", enabled: false, expectedStatus: model.WebhookDeliveryFailed, expectedError: "webhook disabled"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockRepo := new(repository.MockWebhookRepository)
+			mockSender := new(webhook.MockWebhookSender)
+			delivery := &model.WebhookDelivery{
+				Id:             uuid.MustParse("40000000-0000-0000-0000-000000000001"),
+				SubscriptionId: subscriptionID,
+				EventId:        "foo.created:1",
+				EventType:      events.FooCreatedType,
+				Payload:        payload,
+				Status:         model.WebhookDeliveryPending,
+				Attempts:       testCase.attempts,
+			}
+			subscription := &model.WebhookSubscription{Id: subscriptionID, Url: "https://partner.example.com/hooks", Secret: "0123456789abcdef", Enabled: testCase.enabled}
+
+			mockRepo.On("ClaimDeliveries", mock.Anything, testWebhookNow, 10, time.Minute).Return([]*model.WebhookDelivery{delivery}, nil)
+			mockRepo.On("FindByID", mock.Anything, subscriptionID).Return(subscription, nil)
+			mockRepo.On("RecordSuccess", mock.Anything, subscriptionID).Return(nil)
+			mockRepo.On("RecordFailure", mock.Anything, subscriptionID, 5).Return(testCase.disabled, nil)
+			mockRepo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+			mockSender.On("Send", mock.Anything, subscription.Url, mock.MatchedBy(func(header http.Header) bool {
+				timestamp := header.Get(webhook2.HeaderTimestamp)
+				return header.Get(webhook2.HeaderEvent) == events.FooCreatedType &&
+					timestamp == strconv.FormatInt(testWebhookNow.Unix(), 10) &&
+					webhook2.Verify(subscription.Secret, header.Get(webhook2.HeaderSignature), timestamp, payload, 0, testWebhookNow) == nil
+			}), payload).Return(testCase.statusCode, testCase.sendErr)
+			service := newTestWebhookService(mockRepo, mockSender)
+
+			count, err := service.DeliverPending(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, count)
+			assert.Equal(t, testCase.expectedStatus, delivery.Status)
+			assert.Equal(t, testCase.expectedAttempts, delivery.Attempts)
+			assert.Equal(t, testCase.expectedError, delivery.Error)
+			if !testCase.expectedNextAttempt.IsZero() {
+				assert.Equal(t, testCase.expectedNextAttempt, delivery.NextAttemptAt)
+			}
+			if !testCase.enabled {
+				mockSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+			mockRepo.AssertCalled(t, "UpdateDelivery", mock.Anything, delivery)
+		})
+	}
+}
+
+func TestWebhookService_BackOff(t *testing.T) {
+	t.Parallel()
+	service := NewWebhookService(zap.NewNop(), WebhookConfig{BackOff: time.Second, MaxBackOff: 10 * time.Second}, nil, nil)
+
+	assert.Equal(t, time.Second, service.backOff(1))
+	assert.Equal(t, 2*time.Second, service.backOff(2))
+	assert.Equal(t, 8*time.Second, service.backOff(4))
+	assert.Equal(t, 10*time.Second, service.backOff(5))
+	assert.Equal(t, 10*time.Second, service.backOff(50))
+}
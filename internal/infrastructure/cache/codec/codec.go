@@ -0,0 +1,128 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ugorji "github.com/ugorji/go/codec"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	_ Codec = JSON{}
+	_ Codec = MessagePack{}
+	_ Codec = Protobuf{}
+)
+
+// ErrUnsupported is returned when a codec cannot encode or decode the type of the value it is given.
+var ErrUnsupported = errors.New("type not supported by codec")
+
+// ID identifies a codec in the header of a serialized payload.
+type ID byte
+
+const (
+	IDJSON        ID = 1
+	IDMessagePack ID = 2
+	IDProtobuf    ID = 3
+)
+
+// Codec turns cache values into bytes and back.
+type Codec interface {
+	ID() ID
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// ProtoMarshaler is implemented by values that are not protobuf messages but have a protobuf representation.
+type ProtoMarshaler interface {
+	MarshalProto() ([]byte, error)
+	UnmarshalProto(data []byte) error
+}
+
+// JSON encodes values with encoding/json.
+type JSON struct{}
+
+func (JSON) ID() ID       { return IDJSON }
+func (JSON) Name() string { return "json" }
+
+func (JSON) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSON) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+// msgpackHandle is shared by every MessagePack encoder and decoder; it must not be modified after its first use.
+var msgpackHandle = func() *ugorji.MsgpackHandle {
+	handle := &ugorji.MsgpackHandle{}
+	handle.WriteExt = true
+	handle.RawToString = true
+	return handle
+}()
+
+// MessagePack encodes values in the MessagePack format, using the json tags of structs as field names.
+type MessagePack struct{}
+
+func (MessagePack) ID() ID       { return IDMessagePack }
+func (MessagePack) Name() string { return "msgpack" }
+
+func (MessagePack) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := ugorji.NewEncoder(&buf, msgpackHandle).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (MessagePack) Unmarshal(data []byte, v any) error {
+	return ugorji.NewDecoderBytes(data, msgpackHandle).Decode(v)
+}
+
+// Protobuf encodes protobuf messages and values implementing ProtoMarshaler.
+type Protobuf struct{}
+
+func (Protobuf) ID() ID       { return IDProtobuf }
+func (Protobuf) Name() string { return "protobuf" }
+
+func (Protobuf) Marshal(v any) ([]byte, error) {
+	switch value := v.(type) {
+	case proto.Message:
+		return proto.Marshal(value)
+	case ProtoMarshaler:
+		return value.MarshalProto()
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupported, v)
+	}
+}
+
+func (Protobuf) Unmarshal(data []byte, v any) error {
+	switch value := v.(type) {
+	case proto.Message:
+		return proto.Unmarshal(data, value)
+	case ProtoMarshaler:
+		return value.UnmarshalProto(data)
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupported, v)
+	}
+}
+
+// codecs lists the built-in codecs by the ID written in payload headers.
+var codecs = map[ID]Codec{
+	IDJSON:        JSON{},
+	IDMessagePack: MessagePack{},
+	IDProtobuf:    Protobuf{},
+}
+
+// New returns the built-in codec registered under name.
+func New(name string) (Codec, error) {
+	for _, c := range codecs {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown cache codec %q", name)
+}
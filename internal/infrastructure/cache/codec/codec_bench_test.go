@@ -0,0 +1,96 @@
+package codec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity"
+
+	"github.com/google/uuid"
+)
+
+// benchmarkConfigs compares every codec, with and without compression.
+var benchmarkConfigs = []Config{
+	{Name: "json"},
+	{Name: "json", CompressionThreshold: 256},
+	{Name: "msgpack"},
+	{Name: "msgpack", CompressionThreshold: 256},
+	{Name: "protobuf"},
+	{Name: "protobuf", CompressionThreshold: 256},
+}
+
+func benchmarkValues() map[string]any {
+	foos := make([]*model.Foo, 100)
+	for i := range foos {
+		foos[i] = &model.Foo{
+			Id:        uuid.New(),
+			Label:     fmt.Sprintf("foo%d", i),
+			Secret:    fmt.Sprintf("secret%d", i),
+			Value:     i,
+			Weight:    float32(i) / 3,
+			CreatedAt: testCreatedAt,
+		}
+	}
+	return map[string]any{
+		"entry": entity.NewFooCacheEntity(testFoo, testCreatedAt),
+		"list":  entity.NewFooListEntity(foos),
+	}
+}
+
+func benchmarkName(config Config, value string) string {
+	return fmt.Sprintf("%s/%s/compression=%d", value, config.Name, config.CompressionThreshold)
+}
+
+func BenchmarkSerializer_Marshal(b *testing.B) {
+	for valueName, value := range benchmarkValues() {
+		for _, config := range benchmarkConfigs {
+			serializer, err := NewSerializer(config)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(benchmarkName(config, valueName), func(b *testing.B) {
+				var size int
+				for b.Loop() {
+					data, err := serializer.Marshal(value)
+					if err != nil {
+						b.Fatal(err)
+					}
+					size = len(data)
+				}
+				b.ReportMetric(float64(size), "bytes/value")
+			})
+		}
+	}
+}
+
+func BenchmarkSerializer_Unmarshal(b *testing.B) {
+	for valueName, value := range benchmarkValues() {
+		for _, config := range benchmarkConfigs {
+			serializer, err := NewSerializer(config)
+			if err != nil {
+				b.Fatal(err)
+			}
+			data, err := serializer.Marshal(value)
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.Run(benchmarkName(config, valueName), func(b *testing.B) {
+				for b.Loop() {
+					var err error
+					switch value.(type) {
+					case *entity.FooCacheEntity:
+						err = serializer.Unmarshal(data, &entity.FooCacheEntity{})
+					case *entity.FooListEntity:
+						err = serializer.Unmarshal(data, &entity.FooListEntity{})
+					}
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
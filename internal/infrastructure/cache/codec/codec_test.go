@@ -0,0 +1,179 @@
+package codec
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testCreatedAt = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	testUpdatedAt = time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+	testFoo       = &model.Foo{
+		Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+		Label:     "foo1",
+		Secret:    "secret1",
+		Value:     1,
+		Weight:    1.5,
+		CreatedAt: testCreatedAt,
+		UpdatedAt: &testUpdatedAt,
+	}
+)
+
+// legacyEntity has the schema version of a previous deployment.
+type legacyEntity struct {
+	entity.FooCacheEntity
+}
+
+func (legacyEntity) SchemaVersion() uint8 {
+	return entity.SchemaVersion + 1
+}
+
+func TestSerializer_RoundTrip(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		config    Config
+		value     *entity.FooCacheEntity
+		expectZip bool
+	}{
+		{
+			name:   "Success Case - JSON",
+			config: Config{Name: "json"},
+			value:  entity.NewFooCacheEntity(testFoo, testCreatedAt),
+		},
+		{
+			name:   "Success Case - MessagePack",
+			config: Config{Name: "msgpack"},
+			value:  entity.NewFooCacheEntity(testFoo, testCreatedAt),
+		},
+		{
+			name:   "Success Case - Protobuf",
+			config: Config{Name: "protobuf"},
+			value:  entity.NewFooCacheEntity(testFoo, testCreatedAt),
+		},
+		{
+			name:   "Success Case - Tombstone",
+			config: Config{Name: "protobuf"},
+			value:  entity.NewFooTombstoneEntity(testFoo.Id, testCreatedAt),
+		},
+		{
+			name:   "Success Case - Compressed",
+			config: Config{Name: "json", CompressionThreshold: 64},
+			value: entity.NewFooCacheEntity(&model.Foo{
+				Id:    testFoo.Id,
+				Label: strings.Repeat("foo", 100),
+			}, testCreatedAt),
+			expectZip: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			serializer, err := NewSerializer(testCase.config)
+			assert.NoError(t, err)
+
+			data, err := serializer.Marshal(testCase.value)
+			assert.NoError(t, err)
+			assert.Equal(t, entity.SchemaVersion, data[1])
+			assert.Equal(t, testCase.expectZip, data[3]&flagCompressed != 0)
+
+			var result entity.FooCacheEntity
+			assert.NoError(t, serializer.Unmarshal(data, &result))
+			assert.Equal(t, testCase.value.ToModel().Missing, result.ToModel().Missing)
+			assert.True(t, testCase.value.FreshUntil.Equal(result.FreshUntil))
+			assert.Equal(t, testCase.value.Id, result.Id)
+			assert.Equal(t, testCase.value.Label, result.Label)
+			assert.Equal(t, testCase.value.Weight, result.Weight)
+			assert.True(t, testCase.value.CreatedAt.Equal(result.CreatedAt))
+		})
+	}
+}
+
+func TestSerializer_List(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"json", "msgpack", "protobuf"} {
+		t.Run("Success Case - "+name, func(t *testing.T) {
+			t.Parallel()
+			serializer, err := NewSerializer(Config{Name: name})
+			assert.NoError(t, err)
+
+			data, err := serializer.Marshal(entity.NewFooListEntity([]*model.Foo{testFoo, testFoo}))
+			assert.NoError(t, err)
+
+			var result entity.FooListEntity
+			assert.NoError(t, serializer.Unmarshal(data, &result))
+			assert.Len(t, result, 2)
+			assert.Equal(t, testFoo.Id, result[1].Id)
+			assert.True(t, testUpdatedAt.Equal(*result[1].UpdatedAt))
+		})
+	}
+}
+
+func TestSerializer_Unmarshal(t *testing.T) {
+	t.Parallel()
+	jsonSerializer, _ := NewSerializer(Config{Name: "json"})
+	protobufSerializer, _ := NewSerializer(Config{Name: "protobuf"})
+	current, _ := protobufSerializer.Marshal(entity.NewFooCacheEntity(testFoo, testCreatedAt))
+	legacy, _ := jsonSerializer.Marshal(&legacyEntity{*entity.NewFooCacheEntity(testFoo, testCreatedAt)})
+	unknownCodec := append([]byte{}, current...)
+	unknownCodec[2] = 0xFF
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{
+			name: "Success Case - Written With Another Codec",
+			data: current,
+		},
+		{
+			name:          "Failure Case - Headerless Payload",
+			data:          []byte(`{"id":"20000000-0000-0000-0000-000000000001","label":"foo1"}`),
+			expectedError: ErrIncompatible,
+		},
+		{
+			name:          "Failure Case - Other Schema Version",
+			data:          legacy,
+			expectedError: ErrIncompatible,
+		},
+		{
+			name:          "Failure Case - Unknown Codec",
+			data:          unknownCodec,
+			expectedError: ErrIncompatible,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			var result entity.FooCacheEntity
+			err := jsonSerializer.Unmarshal(testCase.data, &result)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testFoo.Label, result.Label)
+		})
+	}
+}
+
+func TestNewSerializer(t *testing.T) {
+	t.Parallel()
+	serializer, err := NewSerializer(Config{})
+	assert.NoError(t, err)
+	assert.Equal(t, "json", serializer.Name())
+
+	_, err = NewSerializer(Config{Name: "xml"})
+	assert.Error(t, err)
+}
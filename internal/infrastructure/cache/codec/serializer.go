@@ -0,0 +1,127 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrIncompatible is returned when a payload was written with another schema version or in an unknown format.
+// Callers should treat it as a cache miss: the entry is overwritten by the next write.
+var ErrIncompatible = errors.New("incompatible cache payload")
+
+const (
+	// magic starts every payload so that values written before the header existed are recognized as incompatible.
+	magic      byte = 0xCA
+	headerSize      = 4
+
+	flagCompressed byte = 1 << 0
+)
+
+// Versioned is implemented by values carrying the version of their schema. It must be bumped whenever the shape of
+// the value changes, so that payloads of the previous version are ignored instead of decoded into the new shape.
+type Versioned interface {
+	SchemaVersion() uint8
+}
+
+var (
+	encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	decoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+// Config defines the codec used to write cache values and the size above which they are compressed.
+type Config struct {
+	Name                 string `mapstructure:"name"`
+	CompressionThreshold int    `mapstructure:"compression_threshold"`
+}
+
+// Serializer writes cache values as a header followed by the encoded value, compressed with zstd when it exceeds
+// the compression threshold. The header holds the schema version of the value, the codec and the compression flag:
+//
+//	magic | schema version | codec id | flags | payload
+//
+// Payloads are decoded with the codec named in their header, so changing the configured codec does not invalidate
+// the entries already written.
+type Serializer struct {
+	codec     Codec
+	threshold int
+}
+
+// Name returns the name of the codec used to write values.
+func (s *Serializer) Name() string {
+	return s.codec.Name()
+}
+
+// Marshal encodes v, prefixed by its header.
+func (s *Serializer) Marshal(v any) ([]byte, error) {
+	payload, err := s.codec.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("fail to encode value with %s: %w", s.codec.Name(), err)
+	}
+
+	var flags byte
+	if s.threshold > 0 && len(payload) > s.threshold {
+		if compressed := encoder.EncodeAll(payload, nil); len(compressed) < len(payload) {
+			payload = compressed
+			flags |= flagCompressed
+		}
+	}
+
+	data := make([]byte, headerSize, headerSize+len(payload))
+	data[0] = magic
+	data[1] = schemaVersion(v)
+	data[2] = byte(s.codec.ID())
+	data[3] = flags
+	return append(data, payload...), nil
+}
+
+// Unmarshal decodes data into v. It returns ErrIncompatible when data was not written by a Serializer, or was
+// written for another schema version than the one of v.
+func (s *Serializer) Unmarshal(data []byte, v any) error {
+	if len(data) < headerSize || data[0] != magic {
+		return fmt.Errorf("%w: missing header", ErrIncompatible)
+	}
+	if version := schemaVersion(v); data[1] != version {
+		return fmt.Errorf("%w: schema version %d, expected %d", ErrIncompatible, data[1], version)
+	}
+	c, ok := codecs[ID(data[2])]
+	if !ok {
+		return fmt.Errorf("%w: unknown codec %d", ErrIncompatible, data[2])
+	}
+
+	payload := data[headerSize:]
+	if data[3]&flagCompressed != 0 {
+		decompressed, err := decoder.DecodeAll(payload, nil)
+		if err != nil {
+			return fmt.Errorf("fail to decompress value: %w", err)
+		}
+		payload = decompressed
+	}
+
+	if err := c.Unmarshal(payload, v); err != nil {
+		return fmt.Errorf("fail to decode value with %s: %w", c.Name(), err)
+	}
+	return nil
+}
+
+func schemaVersion(v any) uint8 {
+	if versioned, ok := v.(Versioned); ok {
+		return versioned.SchemaVersion()
+	}
+	return 0
+}
+
+// NewSerializer creates a Serializer writing values with the codec named in the configuration, defaulting to JSON.
+func NewSerializer(config Config) (*Serializer, error) {
+	if config.Name == "" {
+		config.Name = JSON{}.Name()
+	}
+
+	c, err := New(config.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Serializer{codec: c, threshold: config.CompressionThreshold}, nil
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/memory"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	tierLocal  = "local"
+	tierRemote = "remote"
+)
+
+var _ cache.IFooCache = (*FooCacheTiered)(nil)
+
+// LocalConfig holds the settings of the in-process tier kept in front of the shared cache.
+// TTL bounds the time a local entry can be served, which also bounds staleness when an invalidation is lost.
+type LocalConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Size    int           `mapstructure:"size"`
+	TTL     time.Duration `mapstructure:"ttl"`
+}
+
+// IFooInvalidator broadcasts the ids of the Foo entities whose local copies must be dropped by the other instances.
+type IFooInvalidator interface {
+	PublishInvalidation(ctx context.Context, id uuid.UUID) error
+}
+
+// FooCacheTiered decorates a shared cache, usually Redis, with a bounded in-process tier.
+// Reads are served by the local tier first. Writes go to both tiers and are broadcast so that the other instances
+// drop their local copy: every Set and DeleteByID is broadcast, including cache fills after a miss, which are rare
+// and only cost other instances a read of the shared tier.
+type FooCacheTiered struct {
+	logger      *zap.Logger
+	local       *memory.FooMemory
+	remote      cache.IFooCache
+	invalidator IFooInvalidator
+}
+
+func (c *FooCacheTiered) GetByID(ctx context.Context, id uuid.UUID) (*model.FooCacheEntry, error) {
+	if entry, _ := c.local.GetByID(ctx, id); entry != nil {
+		observe(tierLocal, resultHit)
+		return entry, nil
+	}
+	observe(tierLocal, resultMiss)
+
+	entry, err := c.remote.GetByID(ctx, id)
+	if err != nil {
+		observe(tierRemote, resultError)
+		return nil, err
+	}
+	if entry == nil {
+		observe(tierRemote, resultMiss)
+		return nil, nil
+	}
+
+	observe(tierRemote, resultHit)
+	c.local.Put(id, entry)
+	return entry, nil
+}
+
+func (c *FooCacheTiered) Set(ctx context.Context, foo *model.Foo, ttl model.CacheTTL) error {
+	if err := c.remote.Set(ctx, foo, ttl); err != nil {
+		// The local copy may now be older than the shared one: drop it rather than serving it.
+		_ = c.local.DeleteByID(ctx, foo.Id)
+		return err
+	}
+
+	_ = c.local.Set(ctx, foo, ttl)
+	c.invalidate(ctx, foo.Id)
+	return nil
+}
+
+func (c *FooCacheTiered) SetMissing(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	if err := c.remote.SetMissing(ctx, id, ttl); err != nil {
+		return err
+	}
+
+	_ = c.local.SetMissing(ctx, id, ttl)
+	return nil
+}
+
+func (c *FooCacheTiered) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	_ = c.local.DeleteByID(ctx, id)
+	if err := c.remote.DeleteByID(ctx, id); err != nil {
+		return err
+	}
+
+	c.invalidate(ctx, id)
+	return nil
+}
+
+// Evict drops the local copy of a Foo. It handles the invalidations broadcast by the other instances.
+func (c *FooCacheTiered) Evict(ctx context.Context, id uuid.UUID) {
+	_ = c.local.DeleteByID(ctx, id)
+}
+
+func (c *FooCacheTiered) invalidate(ctx context.Context, id uuid.UUID) {
+	if err := c.invalidator.PublishInvalidation(ctx, id); err != nil {
+		c.logger.Warn("fail to broadcast foo cache invalidation", zap.String("foo.id", id.String()), zap.Error(err))
+	}
+}
+
+func NewFooCacheTiered(logger *zap.Logger, local *memory.FooMemory, remote cache.IFooCache, invalidator IFooInvalidator) *FooCacheTiered {
+	return &FooCacheTiered{
+		logger:      logger,
+		local:       local,
+		remote:      remote,
+		invalidator: invalidator,
+	}
+}
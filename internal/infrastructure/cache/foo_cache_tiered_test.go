@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/memory"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/cache"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type stubInvalidator struct {
+	ids []uuid.UUID
+}
+
+func (s *stubInvalidator) PublishInvalidation(_ context.Context, id uuid.UUID) error {
+	s.ids = append(s.ids, id)
+	return nil
+}
+
+func TestFooCacheTiered_GetByID(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	entry := &model.FooCacheEntry{Foo: &model.Foo{Id: id, Label: "foo1"}, FreshUntil: time.Now().Add(time.Minute)}
+
+	remote := new(cache.MockFooCache)
+	remote.On("GetByID", mock.Anything, id).Return(entry, nil).Once()
+	tiered := NewFooCacheTiered(zap.NewNop(), memory.NewFooMemory(10, time.Minute), remote, &stubInvalidator{})
+
+	// The first read fills the local tier, the second one does not reach the remote tier.
+	result, err := tiered.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, entry, result)
+
+	result, err = tiered.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, entry, result)
+	remote.AssertNumberOfCalls(t, "GetByID", 1)
+}
+
+func TestFooCacheTiered_Invalidation(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	foo := &model.Foo{Id: id, Label: "foo1"}
+	ttl := model.CacheTTL{Fresh: time.Minute}
+
+	testCases := []struct {
+		name                  string
+		setupMockCache        func(*cache.MockFooCache)
+		action                func(*FooCacheTiered) error
+		expectedError         error
+		expectedLocal         bool
+		expectedInvalidations int
+	}{
+		{
+			name: "Success Case - Set",
+			setupMockCache: func(remote *cache.MockFooCache) {
+				remote.On("Set", mock.Anything, foo, ttl).Return(nil)
+			},
+			action:                func(c *FooCacheTiered) error { return c.Set(ctx, foo, ttl) },
+			expectedLocal:         true,
+			expectedInvalidations: 1,
+		},
+		{
+			name: "Success Case - Delete",
+			setupMockCache: func(remote *cache.MockFooCache) {
+				remote.On("DeleteByID", mock.Anything, id).Return(nil)
+			},
+			action:                func(c *FooCacheTiered) error { return c.DeleteByID(ctx, id) },
+			expectedLocal:         false,
+			expectedInvalidations: 1,
+		},
+		{
+			name:                  "Success Case - Evict",
+			setupMockCache:        func(remote *cache.MockFooCache) {},
+			action:                func(c *FooCacheTiered) error { c.Evict(ctx, id); return nil },
+			expectedLocal:         false,
+			expectedInvalidations: 0,
+		},
+		{
+			name: "Failure Case - Remote Set Error",
+			setupMockCache: func(remote *cache.MockFooCache) {
+				remote.On("Set", mock.Anything, foo, ttl).Return(errors.New("redis error"))
+			},
+			action:                func(c *FooCacheTiered) error { return c.Set(ctx, foo, ttl) },
+			expectedError:         errors.New("redis error"),
+			expectedLocal:         false,
+			expectedInvalidations: 0,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			local := memory.NewFooMemory(10, time.Minute)
+			assert.NoError(t, local.Set(ctx, &model.Foo{Id: id, Label: "old"}, ttl))
+			remote := new(cache.MockFooCache)
+			testCase.setupMockCache(remote)
+			invalidator := &stubInvalidator{}
+			tiered := NewFooCacheTiered(zap.NewNop(), local, remote, invalidator)
+
+			err := testCase.action(tiered)
+
+			if testCase.expectedError != nil {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			entry, _ := local.GetByID(ctx, id)
+			assert.Equal(t, testCase.expectedLocal, entry != nil)
+			if testCase.expectedLocal {
+				assert.Equal(t, "foo1", entry.Foo.Label)
+			}
+			assert.Len(t, invalidator.ids, testCase.expectedInvalidations)
+		})
+	}
+}
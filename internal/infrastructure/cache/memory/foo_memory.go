@@ -0,0 +1,148 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+
+	"github.com/google/uuid"
+)
+
+const defaultSize = 10000
+
+var _ cache.IFooCache = (*FooMemory)(nil)
+
+// item is an entry of the cache. A zero expiresAt never expires: the entry only leaves the cache when evicted or deleted.
+type item struct {
+	id        uuid.UUID
+	entry     model.FooCacheEntry
+	expiresAt time.Time
+}
+
+// FooMemory is a bounded in-process Foo cache. The least recently used entry is evicted when the cache is full,
+// and entries expire after their own expiration or after maxTTL, whichever comes first.
+type FooMemory struct {
+	mu     sync.Mutex
+	size   int
+	maxTTL time.Duration
+	order  *list.List
+	items  map[uuid.UUID]*list.Element
+	now    func() time.Time
+}
+
+func (m *FooMemory) GetByID(_ context.Context, id uuid.UUID) (*model.FooCacheEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.items[id]
+	if !ok {
+		return nil, nil
+	}
+
+	it := element.Value.(*item)
+	if !it.expiresAt.IsZero() && !m.now().Before(it.expiresAt) {
+		m.remove(element)
+		return nil, nil
+	}
+
+	m.order.MoveToFront(element)
+	entry := it.entry
+	return &entry, nil
+}
+
+func (m *FooMemory) Set(_ context.Context, foo *model.Foo, ttl model.CacheTTL) error {
+	m.store(foo.Id, model.FooCacheEntry{Foo: foo, FreshUntil: m.now().Add(ttl.Fresh)}, ttl.Expiration())
+	return nil
+}
+
+func (m *FooMemory) SetMissing(_ context.Context, id uuid.UUID, ttl time.Duration) error {
+	m.mu.Lock()
+	element, exists := m.items[id]
+	exists = exists && !element.Value.(*item).entry.Missing
+	m.mu.Unlock()
+
+	// Like in Redis, a tombstone never replaces an existing Foo.
+	if !exists {
+		m.store(id, model.FooCacheEntry{Missing: true, FreshUntil: m.now().Add(ttl)}, ttl)
+	}
+	return nil
+}
+
+func (m *FooMemory) DeleteByID(_ context.Context, id uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.items[id]; ok {
+		m.remove(element)
+	}
+	return nil
+}
+
+// Put stores an entry read from another cache as is, keeping its freshness deadline. It expires after maxTTL,
+// or at the end of its freshness for a tombstone.
+func (m *FooMemory) Put(id uuid.UUID, entry *model.FooCacheEntry) {
+	var expiration time.Duration
+	if entry.Missing {
+		if expiration = entry.FreshUntil.Sub(m.now()); expiration <= 0 {
+			return
+		}
+	}
+	m.store(id, *entry, expiration)
+}
+
+// Len returns the number of entries held, including expired ones not evicted yet.
+func (m *FooMemory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+func (m *FooMemory) store(id uuid.UUID, entry model.FooCacheEntry, expiration time.Duration) {
+	if m.maxTTL > 0 && (expiration <= 0 || expiration > m.maxTTL) {
+		expiration = m.maxTTL
+	}
+	var expiresAt time.Time
+	if expiration > 0 {
+		expiresAt = m.now().Add(expiration)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.items[id]; ok {
+		element.Value = &item{id: id, entry: entry, expiresAt: expiresAt}
+		m.order.MoveToFront(element)
+		return
+	}
+
+	m.items[id] = m.order.PushFront(&item{id: id, entry: entry, expiresAt: expiresAt})
+	for m.order.Len() > m.size {
+		m.remove(m.order.Back())
+	}
+}
+
+func (m *FooMemory) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.items, element.Value.(*item).id)
+}
+
+// NewFooMemory creates a FooMemory holding up to size entries, each kept at most maxTTL (zero for no limit).
+// An unset size falls back to a sane default.
+func NewFooMemory(size int, maxTTL time.Duration) *FooMemory {
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	return &FooMemory{
+		size:   size,
+		maxTTL: maxTTL,
+		order:  list.New(),
+		items:  make(map[uuid.UUID]*list.Element),
+		now:    time.Now,
+	}
+}
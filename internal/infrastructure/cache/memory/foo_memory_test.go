@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache/cachetest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFooMemory_Eviction(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	id1 := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	id2 := uuid.MustParse("20000000-0000-0000-0000-000000000002")
+	id3 := uuid.MustParse("20000000-0000-0000-0000-000000000003")
+	ttl := model.CacheTTL{Fresh: time.Minute}
+
+	cache := NewFooMemory(2, 0)
+	assert.NoError(t, cache.Set(ctx, &model.Foo{Id: id1}, ttl))
+	assert.NoError(t, cache.Set(ctx, &model.Foo{Id: id2}, ttl))
+
+	// Reading id1 makes id2 the least recently used entry.
+	entry, _ := cache.GetByID(ctx, id1)
+	assert.NotNil(t, entry)
+	assert.NoError(t, cache.Set(ctx, &model.Foo{Id: id3}, ttl))
+
+	entry, _ = cache.GetByID(ctx, id2)
+	assert.Nil(t, entry)
+	entry, _ = cache.GetByID(ctx, id1)
+	assert.NotNil(t, entry)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestFooMemory_Expiration(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		maxTTL        time.Duration
+		ttl           model.CacheTTL
+		elapsed       time.Duration
+		expectedFound bool
+		expectedFresh bool
+	}{
+		{
+			name:          "Success Case - Fresh",
+			ttl:           model.CacheTTL{Fresh: time.Minute, Stale: time.Minute},
+			elapsed:       30 * time.Second,
+			expectedFound: true,
+			expectedFresh: true,
+		},
+		{
+			name:          "Success Case - Stale",
+			ttl:           model.CacheTTL{Fresh: time.Minute, Stale: time.Minute},
+			elapsed:       90 * time.Second,
+			expectedFound: true,
+			expectedFresh: false,
+		},
+		{
+			name:          "Success Case - Expired",
+			ttl:           model.CacheTTL{Fresh: time.Minute, Stale: time.Minute},
+			elapsed:       2 * time.Minute,
+			expectedFound: false,
+		},
+		{
+			name:          "Success Case - Capped by max TTL",
+			maxTTL:        10 * time.Second,
+			ttl:           model.CacheTTL{Fresh: time.Minute},
+			elapsed:       10 * time.Second,
+			expectedFound: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			cache := NewFooMemory(10, testCase.maxTTL)
+			cache.now = func() time.Time { return now }
+			assert.NoError(t, cache.Set(ctx, &model.Foo{Id: id}, testCase.ttl))
+
+			now = now.Add(testCase.elapsed)
+			entry, err := cache.GetByID(ctx, id)
+
+			assert.NoError(t, err)
+			if !testCase.expectedFound {
+				assert.Nil(t, entry)
+				return
+			}
+			assert.NotNil(t, entry)
+			assert.Equal(t, testCase.expectedFresh, entry.Fresh(now))
+		})
+	}
+}
+
+func TestFooMemory_SetMissing(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	existing := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	missing := uuid.MustParse("40400000-0000-0000-0000-000000000000")
+
+	cache := NewFooMemory(10, 0)
+	assert.NoError(t, cache.Set(ctx, &model.Foo{Id: existing}, model.CacheTTL{Fresh: time.Minute}))
+	assert.NoError(t, cache.SetMissing(ctx, existing, time.Minute))
+	assert.NoError(t, cache.SetMissing(ctx, missing, time.Minute))
+
+	entry, _ := cache.GetByID(ctx, existing)
+	assert.False(t, entry.Missing)
+	entry, _ = cache.GetByID(ctx, missing)
+	assert.True(t, entry.Missing)
+
+	assert.NoError(t, cache.DeleteByID(ctx, missing))
+	entry, _ = cache.GetByID(ctx, missing)
+	assert.Nil(t, entry)
+}
+
+func TestFooMemory_Contract(t *testing.T) {
+	t.Parallel()
+	cachetest.RunFooCacheSuite(t, func(t *testing.T) cache.IFooCache {
+		return NewFooMemory(10, 0)
+	})
+}
@@ -0,0 +1,27 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	resultHit   = "hit"
+	resultMiss  = "miss"
+	resultError = "error"
+)
+
+var (
+	TierRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "foo_cache_tier_requests_total",
+			Help: "Total number of Foo cache reads by tier (local, remote) and result (hit, miss, error)",
+		},
+		[]string{"tier", "result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(TierRequests)
+}
+
+func observe(tier string, result string) {
+	TierRequests.WithLabelValues(tier, result).Inc()
+}
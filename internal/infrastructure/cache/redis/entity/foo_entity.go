@@ -9,6 +9,10 @@ import (
 	"github.com/google/uuid"
 )
 
+// SchemaVersion is the version of the cached entities, written in the header of every payload. Bump it whenever
+// their shape changes so that entries written by the previous version are ignored instead of misread.
+const SchemaVersion uint8 = 1
+
 // FooKey represents a unique identifier for a Foo entity using a UUID.
 type FooKey struct {
 	Id uuid.UUID
@@ -55,3 +59,49 @@ func NewFooEntity(foo *model.Foo) *FooEntity {
 		UpdatedAt: foo.UpdatedAt,
 	}
 }
+
+// FooCacheEntity is the value stored in Redis for a Foo: the Foo itself and the time until which it is fresh.
+// Entries written before FreshUntil existed decode with a zero time and are therefore considered stale.
+// A Missing entity is a tombstone for an id that does not exist.
+type FooCacheEntity struct {
+	FooEntity
+	Missing    bool      `json:"missing,omitempty"`
+	FreshUntil time.Time `json:"freshUntil"`
+}
+
+// SchemaVersion returns the version of the schema of the entity.
+func (f *FooCacheEntity) SchemaVersion() uint8 {
+	return SchemaVersion
+}
+
+// ToModel converts the FooCacheEntity instance into a model.FooCacheEntry object.
+func (f *FooCacheEntity) ToModel() *model.FooCacheEntry {
+	if f.Missing {
+		return &model.FooCacheEntry{
+			Missing:    true,
+			FreshUntil: f.FreshUntil,
+		}
+	}
+
+	return &model.FooCacheEntry{
+		Foo:        f.FooEntity.ToModel(),
+		FreshUntil: f.FreshUntil,
+	}
+}
+
+// NewFooTombstoneEntity creates a tombstone for the provided Foo id, fresh until its eviction.
+func NewFooTombstoneEntity(id uuid.UUID, freshUntil time.Time) *FooCacheEntity {
+	return &FooCacheEntity{
+		FooEntity:  FooEntity{Id: id},
+		Missing:    true,
+		FreshUntil: freshUntil,
+	}
+}
+
+// NewFooCacheEntity creates a new instance of FooCacheEntity from the provided model.Foo object and freshness deadline.
+func NewFooCacheEntity(foo *model.Foo, freshUntil time.Time) *FooCacheEntity {
+	return &FooCacheEntity{
+		FooEntity:  *NewFooEntity(foo),
+		FreshUntil: freshUntil,
+	}
+}
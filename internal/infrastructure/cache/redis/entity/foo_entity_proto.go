@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity/pb"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MarshalProto encodes the FooCacheEntity instance with its protobuf representation.
+func (f *FooCacheEntity) MarshalProto() ([]byte, error) {
+	return proto.Marshal(&pb.FooCacheEntity{
+		Foo:        f.FooEntity.toProto(),
+		Missing:    f.Missing,
+		FreshUntil: toTimestamp(f.FreshUntil),
+	})
+}
+
+// UnmarshalProto decodes the protobuf representation of a FooCacheEntity into the instance.
+func (f *FooCacheEntity) UnmarshalProto(data []byte) error {
+	var message pb.FooCacheEntity
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	foo, err := fooEntityFromProto(message.GetFoo())
+	if err != nil {
+		return err
+	}
+
+	*f = FooCacheEntity{
+		FooEntity:  *foo,
+		Missing:    message.GetMissing(),
+		FreshUntil: fromTimestamp(message.GetFreshUntil()),
+	}
+	return nil
+}
+
+// MarshalProto encodes the FooListEntity instance with its protobuf representation.
+func (f *FooListEntity) MarshalProto() ([]byte, error) {
+	message := &pb.FooListEntity{Items: make([]*pb.FooEntity, len(*f))}
+	for i := range *f {
+		message.Items[i] = (*f)[i].toProto()
+	}
+	return proto.Marshal(message)
+}
+
+// UnmarshalProto decodes the protobuf representation of a FooListEntity into the instance.
+func (f *FooListEntity) UnmarshalProto(data []byte) error {
+	var message pb.FooListEntity
+	if err := proto.Unmarshal(data, &message); err != nil {
+		return err
+	}
+
+	entities := make(FooListEntity, len(message.GetItems()))
+	for i, item := range message.GetItems() {
+		foo, err := fooEntityFromProto(item)
+		if err != nil {
+			return err
+		}
+		entities[i] = *foo
+	}
+
+	*f = entities
+	return nil
+}
+
+func (f *FooEntity) toProto() *pb.FooEntity {
+	message := &pb.FooEntity{
+		Id:        f.Id.String(),
+		Label:     f.Label,
+		Secret:    f.Secret,
+		Value:     int64(f.Value),
+		Weight:    f.Weight,
+		CreatedAt: toTimestamp(f.CreatedAt),
+	}
+	if f.UpdatedAt != nil {
+		message.UpdatedAt = timestamppb.New(*f.UpdatedAt)
+	}
+	return message
+}
+
+func fooEntityFromProto(message *pb.FooEntity) (*FooEntity, error) {
+	id, err := uuid.Parse(message.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("invalid foo id: %w", err)
+	}
+
+	foo := &FooEntity{
+		Id:        id,
+		Label:     message.GetLabel(),
+		Secret:    message.GetSecret(),
+		Value:     int(message.GetValue()),
+		Weight:    message.GetWeight(),
+		CreatedAt: fromTimestamp(message.GetCreatedAt()),
+	}
+	if message.GetUpdatedAt() != nil {
+		updatedAt := message.GetUpdatedAt().AsTime()
+		foo.UpdatedAt = &updatedAt
+	}
+	return foo, nil
+}
+
+// toTimestamp leaves zero times unset so that they decode back to the zero time.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func fromTimestamp(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
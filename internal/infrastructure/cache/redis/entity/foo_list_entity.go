@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// FooListAllTagKey is the tag set listing every cached Foo list query.
+// List keys share the {foo:list} hash tag so that invalidation scripts touch a single Redis Cluster slot.
+const FooListAllTagKey = "{foo:list}:tag:all"
+
+// FooListKey identifies the cached result of a Foo list query.
+type FooListKey struct {
+	Offset int
+	Limit  int
+}
+
+// GetKey generates the key of the query, normalized so that equivalent requests share the same entry.
+func (f FooListKey) GetKey() string {
+	return fmt.Sprintf("{foo:list}:query:offset=%d&limit=%d", max(f.Offset, 0), max(f.Limit, 0))
+}
+
+// FooListTagKey returns the tag set listing the cached Foo list queries containing the Foo identified by id.
+func FooListTagKey(id uuid.UUID) string {
+	return fmt.Sprintf("{foo:list}:tag:%s", id)
+}
+
+// FooListEntity is the value stored in Redis for the result of a Foo list query.
+type FooListEntity []FooEntity
+
+// SchemaVersion returns the version of the schema of the entity.
+func (f *FooListEntity) SchemaVersion() uint8 {
+	return SchemaVersion
+}
+
+// ToModel converts the FooListEntity instance into a slice of model.Foo objects.
+func (f *FooListEntity) ToModel() []*model.Foo {
+	foos := make([]*model.Foo, len(*f))
+	for i := range *f {
+		foos[i] = (*f)[i].ToModel()
+	}
+	return foos
+}
+
+// NewFooListEntity creates a new instance of FooListEntity from the provided model.Foo objects.
+func NewFooListEntity(foos []*model.Foo) *FooListEntity {
+	entities := make(FooListEntity, len(foos))
+	for i, foo := range foos {
+		entities[i] = *NewFooEntity(foo)
+	}
+	return &entities
+}
@@ -0,0 +1,301 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.31.1
+// source: foo_cache.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FooEntity is the protobuf representation of a cached Foo.
+type FooEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Value         int64                  `protobuf:"varint,4,opt,name=value,proto3" json:"value,omitempty"`
+	Weight        float32                `protobuf:"fixed32,5,opt,name=weight,proto3" json:"weight,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unset if never updated
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FooEntity) Reset() {
+	*x = FooEntity{}
+	mi := &file_foo_cache_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FooEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FooEntity) ProtoMessage() {}
+
+func (x *FooEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_foo_cache_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FooEntity.ProtoReflect.Descriptor instead.
+func (*FooEntity) Descriptor() ([]byte, []int) {
+	return file_foo_cache_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FooEntity) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FooEntity) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FooEntity) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *FooEntity) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FooEntity) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+func (x *FooEntity) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FooEntity) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// FooCacheEntity is the protobuf representation of a Foo cache entry or tombstone.
+type FooCacheEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Foo           *FooEntity             `protobuf:"bytes,1,opt,name=foo,proto3" json:"foo,omitempty"`
+	Missing       bool                   `protobuf:"varint,2,opt,name=missing,proto3" json:"missing,omitempty"`
+	FreshUntil    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=fresh_until,json=freshUntil,proto3" json:"fresh_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FooCacheEntity) Reset() {
+	*x = FooCacheEntity{}
+	mi := &file_foo_cache_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FooCacheEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FooCacheEntity) ProtoMessage() {}
+
+func (x *FooCacheEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_foo_cache_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FooCacheEntity.ProtoReflect.Descriptor instead.
+func (*FooCacheEntity) Descriptor() ([]byte, []int) {
+	return file_foo_cache_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FooCacheEntity) GetFoo() *FooEntity {
+	if x != nil {
+		return x.Foo
+	}
+	return nil
+}
+
+func (x *FooCacheEntity) GetMissing() bool {
+	if x != nil {
+		return x.Missing
+	}
+	return false
+}
+
+func (x *FooCacheEntity) GetFreshUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FreshUntil
+	}
+	return nil
+}
+
+// FooListEntity is the protobuf representation of a cached Foo list query result.
+type FooListEntity struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*FooEntity           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FooListEntity) Reset() {
+	*x = FooListEntity{}
+	mi := &file_foo_cache_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FooListEntity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FooListEntity) ProtoMessage() {}
+
+func (x *FooListEntity) ProtoReflect() protoreflect.Message {
+	mi := &file_foo_cache_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FooListEntity.ProtoReflect.Descriptor instead.
+func (*FooListEntity) Descriptor() ([]byte, []int) {
+	return file_foo_cache_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FooListEntity) GetItems() []*FooEntity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+var File_foo_cache_proto protoreflect.FileDescriptor
+
+const file_foo_cache_proto_rawDesc = "" +
+	"\n" +
+	"\x0ffoo_cache.proto\x12\x02pb\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x01\n" +
+	"\tFooEntity\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\x03R\x05value\x12\x16\n" +
+	"\x06weight\x18\x05 \x01(\x02R\x06weight\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x88\x01\n" +
+	"\x0eFooCacheEntity\x12\x1f\n" +
+	"\x03foo\x18\x01 \x01(\v2\r.pb.FooEntityR\x03foo\x12\x18\n" +
+	"\amissing\x18\x02 \x01(\bR\amissing\x12;\n" +
+	"\vfresh_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"freshUntil\"4\n" +
+	"\rFooListEntity\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.pb.FooEntityR\x05itemsBUZSgithub.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity/pb;pbb\x06proto3"
+
+var (
+	file_foo_cache_proto_rawDescOnce sync.Once
+	file_foo_cache_proto_rawDescData []byte
+)
+
+func file_foo_cache_proto_rawDescGZIP() []byte {
+	file_foo_cache_proto_rawDescOnce.Do(func() {
+		file_foo_cache_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_foo_cache_proto_rawDesc), len(file_foo_cache_proto_rawDesc)))
+	})
+	return file_foo_cache_proto_rawDescData
+}
+
+var file_foo_cache_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_foo_cache_proto_goTypes = []any{
+	(*FooEntity)(nil),             // 0: pb.FooEntity
+	(*FooCacheEntity)(nil),        // 1: pb.FooCacheEntity
+	(*FooListEntity)(nil),         // 2: pb.FooListEntity
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_foo_cache_proto_depIdxs = []int32{
+	3, // 0: pb.FooEntity.created_at:type_name -> google.protobuf.Timestamp
+	3, // 1: pb.FooEntity.updated_at:type_name -> google.protobuf.Timestamp
+	0, // 2: pb.FooCacheEntity.foo:type_name -> pb.FooEntity
+	3, // 3: pb.FooCacheEntity.fresh_until:type_name -> google.protobuf.Timestamp
+	0, // 4: pb.FooListEntity.items:type_name -> pb.FooEntity
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_foo_cache_proto_init() }
+func file_foo_cache_proto_init() {
+	if File_foo_cache_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_foo_cache_proto_rawDesc), len(file_foo_cache_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_foo_cache_proto_goTypes,
+		DependencyIndexes: file_foo_cache_proto_depIdxs,
+		MessageInfos:      file_foo_cache_proto_msgTypes,
+	}.Build()
+	File_foo_cache_proto = out.File
+	file_foo_cache_proto_goTypes = nil
+	file_foo_cache_proto_depIdxs = nil
+}
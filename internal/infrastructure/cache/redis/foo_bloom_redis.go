@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	fooBloomKey          = "foo:bloom"
+	fooBloomPopulatedKey = "foo:bloom:populated"
+
+	defaultBloomSize   = 1 << 24
+	defaultBloomHashes = 7
+)
+
+var (
+	_ cache.IFooFilter = (*FooBloomRedis)(nil)
+)
+
+// BloomConfig holds the settings of the Bloom filter of existing Foo ids.
+// Size is the number of bits of the filter and Hashes the number of bits set per id. With the defaults (16 Mib, 7 hashes)
+// the false positive rate stays below 1% up to about 1.7 million Foo entities.
+type BloomConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Size    uint64 `mapstructure:"size"`
+	Hashes  int    `mapstructure:"hashes"`
+}
+
+// FooBloomRedis is a Bloom filter of existing Foo ids stored as a Redis bitmap, shared by every replica.
+// Redis does not ship a Bloom filter without the RedisBloom module: bits are set and read with SETBIT and GETBIT.
+type FooBloomRedis struct {
+	db     redis.UniversalClient
+	size   uint64
+	hashes int
+}
+
+func (f FooBloomRedis) Add(ctx context.Context, ids ...uuid.UUID) error {
+	tracer := otel.Tracer("FooBloomRedis")
+	ctx, span := tracer.Start(ctx, "FooBloomRedis.Add")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("bloom.ids", len(ids)))
+
+	pipe := f.db.Pipeline()
+	for _, id := range ids {
+		for _, offset := range f.offsets(id) {
+			pipe.SetBit(ctx, fooBloomKey, int64(offset), 1)
+		}
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to set bits in redis")
+		return fmt.Errorf("fail to add foo ids to bloom filter: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (f FooBloomRedis) MightContain(ctx context.Context, id uuid.UUID) (bool, error) {
+	tracer := otel.Tracer("FooBloomRedis")
+	ctx, span := tracer.Start(ctx, "FooBloomRedis.MightContain")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("foo.id", id.String()))
+
+	pipe := f.db.Pipeline()
+	populated := pipe.Exists(ctx, fooBloomPopulatedKey)
+	bits := make([]*redis.IntCmd, 0, f.hashes)
+	for _, offset := range f.offsets(id) {
+		bits = append(bits, pipe.GetBit(ctx, fooBloomKey, int64(offset)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get bits from redis")
+		return true, fmt.Errorf("fail to check foo id in bloom filter: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	if populated.Val() == 0 {
+		span.SetAttributes(attribute.Bool("bloom.populated", false))
+		return true, nil
+	}
+	for _, bit := range bits {
+		if bit.Val() == 0 {
+			span.SetAttributes(attribute.Bool("bloom.contains", false))
+			return false, nil
+		}
+	}
+
+	span.SetAttributes(attribute.Bool("bloom.contains", true))
+	return true, nil
+}
+
+func (f FooBloomRedis) MarkPopulated(ctx context.Context) error {
+	tracer := otel.Tracer("FooBloomRedis")
+	ctx, span := tracer.Start(ctx, "FooBloomRedis.MarkPopulated")
+	defer span.End()
+
+	if err := f.db.Set(ctx, fooBloomPopulatedKey, 1, 0).Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to set in redis")
+		return fmt.Errorf("fail to mark bloom filter as populated: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// offsets returns the bits of the filter set for an id, using double hashing of the two halves of a 128-bit FNV-1a hash.
+func (f FooBloomRedis) offsets(id uuid.UUID) []uint64 {
+	hash := fnv.New128a()
+	hash.Write(id[:])
+	sum := hash.Sum(nil)
+	h1 := binary.BigEndian.Uint64(sum[:8])
+	h2 := binary.BigEndian.Uint64(sum[8:])
+
+	offsets := make([]uint64, f.hashes)
+	for i := range offsets {
+		offsets[i] = (h1 + uint64(i)*h2) % f.size
+	}
+
+	return offsets
+}
+
+// NewFooBloomRedis creates a FooBloomRedis, falling back to sane defaults for unset size and hash count.
+func NewFooBloomRedis(db redis.UniversalClient, config BloomConfig) *FooBloomRedis {
+	if config.Size == 0 {
+		config.Size = defaultBloomSize
+	}
+	if config.Hashes <= 0 {
+		config.Hashes = defaultBloomHashes
+	}
+
+	return &FooBloomRedis{
+		db:     db,
+		size:   config.Size,
+		hashes: config.Hashes,
+	}
+}
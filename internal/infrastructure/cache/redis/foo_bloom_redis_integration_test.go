@@ -0,0 +1,43 @@
+package redis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationFooBloomRedis(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateRedisContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis, err := NewRedis(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := NewFooBloomRedis(redis, BloomConfig{Enabled: true})
+
+	existing := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	unknown := uuid.MustParse("40400000-0000-0000-0000-000000000000")
+	assert.NoError(t, filter.Add(ctx, existing))
+
+	// The filter cannot be trusted before it is populated.
+	contains, err := filter.MightContain(ctx, unknown)
+	assert.NoError(t, err)
+	assert.True(t, contains)
+
+	assert.NoError(t, filter.MarkPopulated(ctx))
+
+	contains, err = filter.MightContain(ctx, existing)
+	assert.NoError(t, err)
+	assert.True(t, contains)
+
+	contains, err = filter.MightContain(ctx, unknown)
+	assert.NoError(t, err)
+	assert.False(t, contains)
+}
@@ -0,0 +1,178 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/codec"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	_ cache.IFooListCache = (*FooListRedis)(nil)
+)
+
+// invalidateTagsScript deletes every key listed in the tag sets given as KEYS, then the tag sets themselves.
+var invalidateTagsScript = redis.NewScript(`
+local deleted = 0
+for _, tag in ipairs(KEYS) do
+	for _, key in ipairs(redis.call('SMEMBERS', tag)) do
+		deleted = deleted + redis.call('DEL', key)
+	end
+	redis.call('DEL', tag)
+end
+return deleted
+`)
+
+// FooListRedis caches the results of Foo list queries in Redis. Each result is added to the tag set of every Foo it
+// contains and to a tag set of all queries, so that the results affected by a change can be found and deleted.
+type FooListRedis struct {
+	db         redis.UniversalClient
+	serializer *codec.Serializer
+}
+
+func (f FooListRedis) GetList(ctx context.Context, input data.FooReadListInput) ([]*model.Foo, bool, error) {
+	tracer := otel.Tracer("FooListRedis")
+	ctx, span := tracer.Start(ctx, "FooListRedis.GetList")
+	defer span.End()
+
+	key := entity.FooListKey{Offset: input.Offset, Limit: input.Limit}
+	span.SetAttributes(
+		attribute.String("redis.key", key.GetKey()),
+		attribute.String("cache.codec", f.serializer.Name()),
+	)
+
+	value, err := f.db.Get(ctx, key.GetKey()).Bytes()
+	if errors.Is(err, redis.Nil) {
+		span.SetStatus(codes.Ok, "")
+		span.SetAttributes(attribute.Bool("cache.miss", true))
+		return nil, false, nil
+	} else if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to get from redis")
+		return nil, false, fmt.Errorf("fail to find foo list: %w", err)
+	}
+
+	var entities entity.FooListEntity
+	if err := f.serializer.Unmarshal(value, &entities); errors.Is(err, codec.ErrIncompatible) {
+		span.SetStatus(codes.Ok, "")
+		span.SetAttributes(attribute.Bool("cache.miss", true), attribute.Bool("cache.incompatible", true))
+		return nil, false, nil
+	} else if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to unmarshal foo list")
+		return nil, false, fmt.Errorf("fail to unmarshal foo list: %w", err)
+	}
+
+	foos := entities.ToModel()
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(
+		attribute.Bool("cache.hit", true),
+		attribute.Int("value.size", len(value)),
+		attribute.Int("result.count", len(foos)),
+	)
+	return foos, true, nil
+}
+
+func (f FooListRedis) SetList(ctx context.Context, input data.FooReadListInput, foos []*model.Foo, ttl time.Duration) error {
+	tracer := otel.Tracer("FooListRedis")
+	ctx, span := tracer.Start(ctx, "FooListRedis.SetList")
+	defer span.End()
+
+	key := entity.FooListKey{Offset: input.Offset, Limit: input.Limit}
+	span.SetAttributes(
+		attribute.String("redis.key", key.GetKey()),
+		attribute.Int64("redis.expiration", int64(ttl.Seconds())),
+		attribute.Int("result.count", len(foos)),
+		attribute.String("cache.codec", f.serializer.Name()),
+	)
+
+	value, err := f.serializer.Marshal(entity.NewFooListEntity(foos))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to marshal foo list")
+		return fmt.Errorf("fail to marshal foo list: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("value.size", len(value)))
+
+	// Tag sets live as long as the queries they list: every query shares the same TTL, so extending a tag set
+	// to the TTL of its newest query keeps it alive as long as any of them.
+	_, err = f.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, key.GetKey(), value, ttl)
+		pipe.SAdd(ctx, entity.FooListAllTagKey, key.GetKey())
+		pipe.Expire(ctx, entity.FooListAllTagKey, ttl)
+		for _, foo := range foos {
+			pipe.SAdd(ctx, entity.FooListTagKey(foo.Id), key.GetKey())
+			pipe.Expire(ctx, entity.FooListTagKey(foo.Id), ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to set in redis")
+		return fmt.Errorf("fail to set foo list: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (f FooListRedis) InvalidateByIDs(ctx context.Context, ids ...uuid.UUID) error {
+	tracer := otel.Tracer("FooListRedis")
+	ctx, span := tracer.Start(ctx, "FooListRedis.InvalidateByIDs")
+	defer span.End()
+
+	tags := make([]string, len(ids))
+	for i, id := range ids {
+		tags[i] = entity.FooListTagKey(id)
+	}
+	span.SetAttributes(attribute.StringSlice("cache.tags", tags))
+
+	return f.invalidate(ctx, span, tags)
+}
+
+func (f FooListRedis) InvalidateAll(ctx context.Context) error {
+	tracer := otel.Tracer("FooListRedis")
+	ctx, span := tracer.Start(ctx, "FooListRedis.InvalidateAll")
+	defer span.End()
+
+	span.SetAttributes(attribute.StringSlice("cache.tags", []string{entity.FooListAllTagKey}))
+
+	return f.invalidate(ctx, span, []string{entity.FooListAllTagKey})
+}
+
+func (f FooListRedis) invalidate(ctx context.Context, span trace.Span, tags []string) error {
+	if len(tags) == 0 {
+		span.SetStatus(codes.Ok, "")
+		return nil
+	}
+
+	deleted, err := invalidateTagsScript.Run(ctx, f.db, tags).Int64()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to invalidate in redis")
+		return fmt.Errorf("fail to invalidate foo lists: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int64("redis.deleted_count", deleted))
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func NewFooListRedis(db redis.UniversalClient, serializer *codec.Serializer) *FooListRedis {
+	return &FooListRedis{db: db, serializer: serializer}
+}
@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationFooListRedis_Invalidate(t *testing.T) {
+	t.Parallel()
+	foo1 := &model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001"), Label: "foo1", Secret: "secret1", Value: 1, Weight: 1.0}
+	foo2 := &model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000002"), Label: "foo2", Secret: "secret2", Value: 2, Weight: 2.0}
+	firstPage := data.FooReadListInput{Offset: 0, Limit: 1}
+	secondPage := data.FooReadListInput{Offset: 1, Limit: 1}
+
+	testCases := []struct {
+		name         string
+		invalidate   func(*FooListRedis) error
+		expectedHits []bool
+	}{
+		{
+			name: "Success Case - By ID",
+			invalidate: func(cache *FooListRedis) error {
+				return cache.InvalidateByIDs(context.Background(), foo1.Id)
+			},
+			expectedHits: []bool{false, true},
+		},
+		{
+			name: "Success Case - All",
+			invalidate: func(cache *FooListRedis) error {
+				return cache.InvalidateAll(context.Background())
+			},
+			expectedHits: []bool{false, false},
+		},
+	}
+
+	ctx := context.Background()
+	container, err := CreateRedisContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis, err := NewRedis(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cache := NewFooListRedis(redis, testSerializer)
+
+			assert.NoError(t, cache.SetList(ctx, firstPage, []*model.Foo{foo1}, time.Minute))
+			assert.NoError(t, cache.SetList(ctx, secondPage, []*model.Foo{foo2}, time.Minute))
+
+			result, ok, err := cache.GetList(ctx, firstPage)
+			assert.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, foo1.Id, result[0].Id)
+
+			assert.NoError(t, testCase.invalidate(cache))
+
+			for i, input := range []data.FooReadListInput{firstPage, secondPage} {
+				_, ok, err := cache.GetList(ctx, input)
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedHits[i], ok)
+			}
+		})
+	}
+}
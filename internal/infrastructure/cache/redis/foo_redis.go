@@ -2,12 +2,12 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/codec"
 	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
@@ -17,10 +17,11 @@ import (
 )
 
 type FooRedis struct {
-	db *redis.Client
+	db         redis.UniversalClient
+	serializer *codec.Serializer
 }
 
-func (f FooRedis) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error) {
+func (f FooRedis) GetByID(ctx context.Context, id uuid.UUID) (*model.FooCacheEntry, error) {
 	tracer := otel.Tracer("FooRedis")
 	ctx, span := tracer.Start(ctx, "FooRedis.GetByID")
 	defer span.End()
@@ -29,9 +30,10 @@ func (f FooRedis) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error)
 	span.SetAttributes(
 		attribute.String("foo.id", id.String()),
 		attribute.String("redis.key", key.GetKey()),
+		attribute.String("cache.codec", f.serializer.Name()),
 	)
 
-	value, err := f.db.Get(ctx, key.GetKey()).Result()
+	value, err := f.db.Get(ctx, key.GetKey()).Bytes()
 	if errors.Is(err, redis.Nil) {
 		span.SetStatus(codes.Ok, "")
 		span.SetAttributes(attribute.Bool("cache.miss", true))
@@ -42,26 +44,36 @@ func (f FooRedis) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error)
 		return nil, fmt.Errorf("fail to find foo by id: %w", err)
 	}
 
-	var fooEntity entity.FooEntity
-	if err := json.Unmarshal([]byte(value), &fooEntity); err != nil {
+	var fooEntity entity.FooCacheEntity
+	if err := f.serializer.Unmarshal(value, &fooEntity); errors.Is(err, codec.ErrIncompatible) {
+		// Written by a deployment with another schema: ignore it, the next write replaces it.
+		span.SetStatus(codes.Ok, "")
+		span.SetAttributes(attribute.Bool("cache.miss", true), attribute.Bool("cache.incompatible", true))
+		return nil, nil
+	} else if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to unmarshal foo")
 		return nil, fmt.Errorf("fail to unmarshal foo: %w", err)
 	}
 
-	foo := fooEntity.ToModel()
+	entry := fooEntity.ToModel()
 	span.SetStatus(codes.Ok, "")
+	if entry.Missing {
+		span.SetAttributes(attribute.Bool("cache.missing", true))
+		return entry, nil
+	}
 	span.SetAttributes(
 		attribute.Bool("cache.hit", true),
 		attribute.Int("value.size", len(value)),
-		attribute.String("foo.label", foo.Label),
-		attribute.Int("foo.value", foo.Value),
-		attribute.Float64("foo.weight", float64(foo.Weight)),
+		attribute.String("foo.label", entry.Foo.Label),
+		attribute.Int("foo.value", entry.Foo.Value),
+		attribute.Float64("foo.weight", float64(entry.Foo.Weight)),
+		attribute.String("cache.fresh_until", entry.FreshUntil.Format(time.RFC3339)),
 	)
-	return foo, nil
+	return entry, nil
 }
 
-func (f FooRedis) Set(ctx context.Context, foo *model.Foo, expiration time.Duration) error {
+func (f FooRedis) Set(ctx context.Context, foo *model.Foo, ttl model.CacheTTL) error {
 	tracer := otel.Tracer("FooRedis")
 	ctx, span := tracer.Start(ctx, "FooRedis.Set")
 	defer span.End()
@@ -70,14 +82,16 @@ func (f FooRedis) Set(ctx context.Context, foo *model.Foo, expiration time.Durat
 	span.SetAttributes(
 		attribute.String("foo.id", foo.Id.String()),
 		attribute.String("redis.key", key.GetKey()),
-		attribute.Int64("redis.expiration", int64(expiration.Seconds())),
+		attribute.Int64("redis.expiration", int64(ttl.Expiration().Seconds())),
+		attribute.Int64("cache.fresh", int64(ttl.Fresh.Seconds())),
+		attribute.String("cache.codec", f.serializer.Name()),
 		attribute.String("foo.label", foo.Label),
 		attribute.Int("foo.value", foo.Value),
 		attribute.Float64("foo.weight", float64(foo.Weight)),
 	)
 
-	value := entity.NewFooEntity(foo)
-	valueByte, err := json.Marshal(value)
+	value := entity.NewFooCacheEntity(foo, time.Now().Add(ttl.Fresh))
+	valueByte, err := f.serializer.Marshal(value)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to marshal foo")
@@ -86,7 +100,7 @@ func (f FooRedis) Set(ctx context.Context, foo *model.Foo, expiration time.Durat
 
 	span.SetAttributes(attribute.Int("value.size", len(valueByte)))
 
-	if result := f.db.Set(ctx, key.GetKey(), valueByte, expiration); result.Err() != nil {
+	if result := f.db.Set(ctx, key.GetKey(), valueByte, ttl.Expiration()); result.Err() != nil {
 		span.RecordError(result.Err())
 		span.SetStatus(codes.Error, "failed to set in redis")
 		return fmt.Errorf("fail to set foo: %w", result.Err())
@@ -96,6 +110,36 @@ func (f FooRedis) Set(ctx context.Context, foo *model.Foo, expiration time.Durat
 	return nil
 }
 
+func (f FooRedis) SetMissing(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	tracer := otel.Tracer("FooRedis")
+	ctx, span := tracer.Start(ctx, "FooRedis.SetMissing")
+	defer span.End()
+
+	key := entity.FooKey{Id: id}
+	span.SetAttributes(
+		attribute.String("foo.id", id.String()),
+		attribute.String("redis.key", key.GetKey()),
+		attribute.Int64("redis.expiration", int64(ttl.Seconds())),
+	)
+
+	valueByte, err := f.serializer.Marshal(entity.NewFooTombstoneEntity(id, time.Now().Add(ttl)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to marshal tombstone")
+		return fmt.Errorf("fail to marshal foo tombstone: %w", err)
+	}
+
+	// A concurrent creation of the Foo must win over the tombstone: only write it when the key is absent.
+	if result := f.db.SetNX(ctx, key.GetKey(), valueByte, ttl); result.Err() != nil {
+		span.RecordError(result.Err())
+		span.SetStatus(codes.Error, "failed to set in redis")
+		return fmt.Errorf("fail to set foo tombstone: %w", result.Err())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
 func (f FooRedis) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	tracer := otel.Tracer("FooRedis")
 	ctx, span := tracer.Start(ctx, "FooRedis.DeleteByID")
@@ -119,6 +163,6 @@ func (f FooRedis) DeleteByID(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func NewFooRedis(db *redis.Client) *FooRedis {
-	return &FooRedis{db: db}
+func NewFooRedis(db redis.UniversalClient, serializer *codec.Serializer) *FooRedis {
+	return &FooRedis{db: db, serializer: serializer}
 }
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache/cachetest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -54,7 +56,7 @@ func TestIntegrationFooRedis_GetByID(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			cache := NewFooRedis(redis)
+			cache := NewFooRedis(redis, testSerializer)
 
 			result, err := cache.GetByID(context.Background(), testCase.id)
 
@@ -69,7 +71,9 @@ func TestIntegrationFooRedis_GetByID(t *testing.T) {
 				if testCase.expectedData == nil {
 					assert.Nil(t, result)
 				} else {
-					assert.True(t, cmp.Equal(testCase.expectedData, result, opts...), cmp.Diff(testCase.expectedData, result, opts...))
+					// Seeded entries have no freshness deadline: they are served stale until refreshed.
+					assert.False(t, result.Fresh(time.Now()))
+					assert.True(t, cmp.Equal(testCase.expectedData, result.Foo, opts...), cmp.Diff(testCase.expectedData, result.Foo, opts...))
 				}
 			}
 		})
@@ -126,9 +130,9 @@ func TestIntegrationFooRedis_Set(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			cache := NewFooRedis(redis)
+			cache := NewFooRedis(redis, testSerializer)
 
-			err := cache.Set(ctx, testCase.foo, 0)
+			err := cache.Set(ctx, testCase.foo, model.CacheTTL{Fresh: time.Minute, Stale: time.Minute})
 
 			opts := []cmp.Option{
 				cmpopts.IgnoreFields(model.Foo{}, "CreatedAt", "UpdatedAt"),
@@ -140,7 +144,8 @@ func TestIntegrationFooRedis_Set(t *testing.T) {
 				assert.NoError(t, err)
 				result, err := cache.GetByID(ctx, testCase.foo.Id)
 				assert.NoError(t, err)
-				assert.True(t, cmp.Equal(testCase.foo, result, opts...), cmp.Diff(testCase.foo, result, opts...))
+				assert.True(t, result.Fresh(time.Now()))
+				assert.True(t, cmp.Equal(testCase.foo, result.Foo, opts...), cmp.Diff(testCase.foo, result.Foo, opts...))
 			}
 		})
 	}
@@ -171,7 +176,7 @@ func TestIntegrationFooRedis_DeleteByID(t *testing.T) {
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			cache := NewFooRedis(redis)
+			cache := NewFooRedis(redis, testSerializer)
 
 			err := cache.DeleteByID(ctx, testCase.id)
 
@@ -186,3 +191,89 @@ func TestIntegrationFooRedis_DeleteByID(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegrationFooRedis_SetMissing(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateRedisContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis, err := NewRedis(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewFooRedis(redis, testSerializer)
+
+	missing := uuid.MustParse("40400000-0000-0000-0000-000000000000")
+	assert.NoError(t, cache.SetMissing(ctx, missing, time.Minute))
+	result, err := cache.GetByID(ctx, missing)
+	assert.NoError(t, err)
+	assert.True(t, result.Missing)
+	assert.Nil(t, result.Foo)
+
+	// A tombstone never replaces an existing Foo.
+	existing := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+	assert.NoError(t, cache.SetMissing(ctx, existing, time.Minute))
+	result, err = cache.GetByID(ctx, existing)
+	assert.NoError(t, err)
+	assert.False(t, result.Missing)
+
+	// Creating the Foo replaces the tombstone.
+	assert.NoError(t, cache.Set(ctx, &model.Foo{Id: missing, Label: "created"}, model.CacheTTL{Fresh: time.Minute}))
+	result, err = cache.GetByID(ctx, missing)
+	assert.NoError(t, err)
+	assert.False(t, result.Missing)
+	assert.Equal(t, "created", result.Foo.Label)
+}
+
+func TestIntegrationFooRedis_Incompatible(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateRedisContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis, err := NewRedis(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Payload written before values carried a header.
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000003")
+	legacy := `{"id":"20000000-0000-0000-0000-000000000003","label":"foo3","secret":"secret3","value":3,"weight":3.0}`
+	if err := redis.Set(ctx, "foo:"+id.String(), legacy, 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewFooRedis(redis, testSerializer)
+	result, err := cache.GetByID(ctx, id)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+// TestIntegrationFooRedis_Contract runs the IFooCache conformance suite against Redis, flushing the seeded data
+// before each test.
+func TestIntegrationFooRedis_Contract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateRedisContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	redis, err := NewRedis(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cachetest.RunFooCacheSuite(t, func(t *testing.T) cache.IFooCache {
+		if err := redis.FlushDB(ctx).Err(); err != nil {
+			t.Fatal(err)
+		}
+		return NewFooRedis(redis, testSerializer)
+	})
+}
@@ -2,33 +2,172 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"fmt"
+	"os"
 	"strconv"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
 // Config represents the configuration settings required to connect to a Redis server.
+// In standalone mode the server is Host:Port, or the first of Addrs when set. In sentinel mode Addrs lists the
+// sentinels monitoring MasterName, and in cluster mode Addrs lists the seed nodes of the cluster.
 type Config struct {
-	Host     string `mapstructure:"host"`
-	Port     int    `mapstructure:"port"`
-	Password string `mapstructure:"password"`
-	DB       int    `mapstructure:"db"`
+	Mode       string   `mapstructure:"mode"`
+	Host       string   `mapstructure:"host"`
+	Port       int      `mapstructure:"port"`
+	Addrs      []string `mapstructure:"addrs"`
+	MasterName string   `mapstructure:"master_name"`
+	Username   string   `mapstructure:"username"`
+	Password   string   `mapstructure:"password"`
+	DB         int      `mapstructure:"db"`
+
+	SentinelUsername string `mapstructure:"sentinel_username"`
+	SentinelPassword string `mapstructure:"sentinel_password"`
+
+	TLS  TLSConfig  `mapstructure:"tls"`
+	Pool PoolConfig `mapstructure:"pool"`
+}
+
+// TLSConfig defines the TLS settings of the connections to Redis. CAFile replaces the system roots to verify the
+// server certificate; CertFile and KeyFile provide a client certificate for mutual TLS.
+type TLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	CAFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file"`
+	KeyFile            string `mapstructure:"key_file"`
+	ServerName         string `mapstructure:"server_name"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// PoolConfig tunes the connection pool and the network timeouts. Zero values keep the go-redis defaults.
+type PoolConfig struct {
+	Size            int           `mapstructure:"size"`
+	MinIdle         int           `mapstructure:"min_idle"`
+	MaxIdle         int           `mapstructure:"max_idle"`
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	PoolTimeout     time.Duration `mapstructure:"pool_timeout"`
+	DialTimeout     time.Duration `mapstructure:"dial_timeout"`
+	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
+	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 }
 
-// NewRedis initializes a new Redis client using the provided configuration and verifies the connection with a ping.
-func NewRedis(ctx context.Context, config Config) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     config.Host + ":" + strconv.Itoa(config.Port),
-		Password: config.Password,
-		DB:       config.DB,
-	})
+// NewRedis initializes a new Redis client for the configured mode and verifies the connection with a ping.
+func NewRedis(ctx context.Context, config Config) (redis.UniversalClient, error) {
+	options, err := newUniversalOptions(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis configuration: %w", err)
+	}
+
+	client := redis.NewUniversalClient(options)
 
 	// Vérifier la connexion
-	_, err := client.Ping(ctx).Result()
+	_, err = client.Ping(ctx).Result()
 	if err != nil {
+		_ = client.Close()
 		return nil, fmt.Errorf("failed to ping redis : %w", err)
 	}
 
 	return client, nil
 }
+
+// newUniversalOptions translates the configuration into options from which redis.NewUniversalClient builds the
+// client of the configured mode.
+func newUniversalOptions(config Config) (*redis.UniversalOptions, error) {
+	options := &redis.UniversalOptions{
+		Addrs:            config.Addrs,
+		Username:         config.Username,
+		Password:         config.Password,
+		SentinelUsername: config.SentinelUsername,
+		SentinelPassword: config.SentinelPassword,
+		DB:               config.DB,
+		PoolSize:         config.Pool.Size,
+		MinIdleConns:     config.Pool.MinIdle,
+		MaxIdleConns:     config.Pool.MaxIdle,
+		ConnMaxIdleTime:  config.Pool.ConnMaxIdleTime,
+		ConnMaxLifetime:  config.Pool.ConnMaxLifetime,
+		PoolTimeout:      config.Pool.PoolTimeout,
+		DialTimeout:      config.Pool.DialTimeout,
+		ReadTimeout:      config.Pool.ReadTimeout,
+		WriteTimeout:     config.Pool.WriteTimeout,
+	}
+
+	switch config.Mode {
+	case "", ModeStandalone:
+		if len(config.Addrs) == 0 {
+			options.Addrs = []string{config.Host + ":" + strconv.Itoa(config.Port)}
+		}
+		// A single address without a master name always yields a standalone client.
+		options.Addrs = options.Addrs[:1]
+	case ModeSentinel:
+		if config.MasterName == "" {
+			return nil, errors.New("sentinel mode requires a master name")
+		}
+		if len(config.Addrs) == 0 {
+			return nil, errors.New("sentinel mode requires the sentinel addresses")
+		}
+		options.MasterName = config.MasterName
+	case ModeCluster:
+		if len(config.Addrs) == 0 {
+			return nil, errors.New("cluster mode requires the seed node addresses")
+		}
+		if config.DB != 0 {
+			return nil, errors.New("cluster mode only supports db 0")
+		}
+		options.IsClusterMode = true
+	default:
+		return nil, fmt.Errorf("unknown mode %q", config.Mode)
+	}
+
+	if config.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
+	}
+
+	return options, nil
+}
+
+// newTLSConfig loads the CA and client certificate referenced by the configuration.
+func newTLSConfig(config TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CAFile != "" {
+		ca, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read redis ca file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in redis ca file %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("fail to load redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,150 @@
+package redis
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUniversalOptions(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		config        Config
+		expectedAddrs []string
+		expectedType  any
+		expectError   bool
+	}{
+		{
+			name:          "Success Case - Standalone",
+			config:        Config{Host: "localhost", Port: 6379},
+			expectedAddrs: []string{"localhost:6379"},
+			expectedType:  &redis.Client{},
+		},
+		{
+			name:          "Success Case - Standalone From Addrs",
+			config:        Config{Mode: ModeStandalone, Addrs: []string{"redis-1:6379", "redis-2:6379"}},
+			expectedAddrs: []string{"redis-1:6379"},
+			expectedType:  &redis.Client{},
+		},
+		{
+			name:          "Success Case - Sentinel",
+			config:        Config{Mode: ModeSentinel, MasterName: "mymaster", Addrs: []string{"sentinel-1:26379", "sentinel-2:26379"}},
+			expectedAddrs: []string{"sentinel-1:26379", "sentinel-2:26379"},
+			expectedType:  &redis.Client{},
+		},
+		{
+			name:          "Success Case - Cluster With A Single Seed",
+			config:        Config{Mode: ModeCluster, Addrs: []string{"cluster:6379"}},
+			expectedAddrs: []string{"cluster:6379"},
+			expectedType:  &redis.ClusterClient{},
+		},
+		{
+			name:        "Failure Case - Sentinel Without Master",
+			config:      Config{Mode: ModeSentinel, Addrs: []string{"sentinel-1:26379"}},
+			expectError: true,
+		},
+		{
+			name:        "Failure Case - Cluster With DB",
+			config:      Config{Mode: ModeCluster, Addrs: []string{"cluster:6379"}, DB: 1},
+			expectError: true,
+		},
+		{
+			name:        "Failure Case - Unknown Mode",
+			config:      Config{Mode: "replicated"},
+			expectError: true,
+		},
+		{
+			name:        "Failure Case - Missing CA File",
+			config:      Config{Host: "localhost", Port: 6379, TLS: TLSConfig{Enabled: true, CAFile: "missing.pem"}},
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			options, err := newUniversalOptions(testCase.config)
+
+			if testCase.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedAddrs, options.Addrs)
+
+			client := redis.NewUniversalClient(options)
+			defer client.Close()
+			assert.IsType(t, testCase.expectedType, client)
+		})
+	}
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir)
+
+	tlsConfig, err := newTLSConfig(TLSConfig{
+		Enabled:    true,
+		CAFile:     certFile,
+		CertFile:   certFile,
+		KeyFile:    keyFile,
+		ServerName: "redis.internal",
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Len(t, tlsConfig.Certificates, 1)
+	assert.Equal(t, "redis.internal", tlsConfig.ServerName)
+
+	_, err = newTLSConfig(TLSConfig{Enabled: true, CAFile: keyFile})
+	assert.Error(t, err)
+}
+
+// writeTestCertificate writes a self-signed certificate and its key, used both as CA and client certificate.
+func writeTestCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "redis.internal"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
@@ -6,9 +6,15 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/codec"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache/redis/entity"
+
 	"github.com/testcontainers/testcontainers-go/modules/redis"
 )
 
+// testSerializer is the serializer used by the adapters under test and to seed the container.
+var testSerializer, _ = codec.NewSerializer(codec.Config{Name: "json"})
+
 // RedisContainer represents a wrapper around a Redis container with configuration and container-related functionalities.
 type RedisContainer struct {
 	*redis.RedisContainer
@@ -47,14 +53,15 @@ func CreateRedisContainer(ctx context.Context) (*RedisContainer, error) {
 	}, nil
 }
 
-// SeedFromJSON reads a JSON file and populates a Redis instance with key-value pairs derived from the file's content.
+// SeedFromJSON reads a JSON file and populates a Redis instance with the Foo cache entities it contains, encoded with
+// testSerializer.
 func SeedFromJSON(ctx context.Context, config Config) error {
 	data, err := os.ReadFile("testdata.json")
 	if err != nil {
 		return fmt.Errorf("failed to read JSON file: %w", err)
 	}
 
-	var entries map[string]interface{}
+	var entries map[string]entity.FooCacheEntity
 	if err := json.Unmarshal(data, &entries); err != nil {
 		return fmt.Errorf("failed to parse JSON: %w", err)
 	}
@@ -65,12 +72,12 @@ func SeedFromJSON(ctx context.Context, config Config) error {
 	}
 
 	for key, value := range entries {
-		jsonValue, err := json.Marshal(value)
+		encoded, err := testSerializer.Marshal(&value)
 		if err != nil {
 			return fmt.Errorf("failed to marshal value for key %s: %w", key, err)
 		}
 
-		err = r.Set(ctx, key, jsonValue, 0).Err()
+		err = r.Set(ctx, key, encoded, 0).Err()
 		if err != nil {
 			return fmt.Errorf("failed to set key %s: %w", key, err)
 		}
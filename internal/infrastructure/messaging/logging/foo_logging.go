@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var _ messaging.IFooMessaging = (*FooLogging)(nil)
+
+// FooLogging writes the Foo events to the logs instead of a broker, which helps to follow them during development
+// or to audit them alongside another sink.
+type FooLogging struct {
+	logger *zap.Logger
+}
+
+func (l *FooLogging) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
+	event, err := message.NewFooCreatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	l.log(ctx, event)
+	return nil
+}
+
+func (l *FooLogging) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
+	event, err := message.NewFooUpdatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	l.log(ctx, event)
+	return nil
+}
+
+func (l *FooLogging) PublishFooDeleted(ctx context.Context, id uuid.UUID) error {
+	event, err := message.NewFooDeletedEvent(id, time.Now())
+	if err != nil {
+		return err
+	}
+	l.log(ctx, event)
+	return nil
+}
+
+func (l *FooLogging) log(ctx context.Context, event cloudevents.Event) {
+	correlation.Logger(ctx, l.logger).Info("foo event published",
+		zap.String("event_id", event.ID),
+		zap.String("event_type", event.Type),
+		zap.String("subject", event.Subject),
+		zap.ByteString("data", event.Data),
+	)
+}
+
+// NewFooLogging creates a FooLogging writing the Foo events to the given logger.
+func NewFooLogging(logger *zap.Logger) *FooLogging {
+	return &FooLogging{logger: logger}
+}
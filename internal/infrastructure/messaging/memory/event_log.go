@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+)
+
+// DefaultSize is the number of events kept by a memory publisher created without a size.
+const DefaultSize = 1000
+
+// Listener is called with every event published to a memory publisher, along with its NATS subject.
+type Listener func(subject string, event cloudevents.Event)
+
+// eventLog keeps the last events published in process memory, in publication order, and passes them to in-process
+// listeners.
+type eventLog struct {
+	mu        sync.Mutex
+	size      int
+	events    []cloudevents.Event
+	listeners []Listener
+}
+
+// Events returns a copy of the events kept, oldest first.
+func (l *eventLog) Events() []cloudevents.Event {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]cloudevents.Event(nil), l.events...)
+}
+
+// Listen registers a listener called synchronously with every event published from now on.
+func (l *eventLog) Listen(listener Listener) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.listeners = append(l.listeners, listener)
+}
+
+// append keeps the event, dropping the oldest one once the log is full, then passes it to the listeners.
+func (l *eventLog) append(subject string, event cloudevents.Event) {
+	l.mu.Lock()
+	if len(l.events) == l.size {
+		l.events = append(l.events[:0], l.events[1:]...)
+	}
+	l.events = append(l.events, event)
+	listeners := l.listeners
+	l.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(subject, event)
+	}
+}
+
+// newEventLog creates an eventLog keeping the last size events, or DefaultSize when size is not positive.
+func newEventLog(size int) *eventLog {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &eventLog{size: size, events: make([]cloudevents.Event, 0, size)}
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+
+	"github.com/google/uuid"
+)
+
+var _ messaging.IFileMessaging = (*FileMemory)(nil)
+
+// FileMemory keeps the last File events published in process memory, in publication order, like FooMemory does for
+// the Foo events.
+type FileMemory struct {
+	*eventLog
+}
+
+func (m *FileMemory) PublishFileCreated(_ context.Context, file *model.File) error {
+	event, err := message.NewFileCreatedEvent(file)
+	if err != nil {
+		return err
+	}
+	m.append(message.FileCreatedSubject, event)
+	return nil
+}
+
+func (m *FileMemory) PublishFileUploaded(_ context.Context, file *model.File) error {
+	event, err := message.NewFileUploadedEvent(file)
+	if err != nil {
+		return err
+	}
+	m.append(message.FileUploadedSubject, event)
+	return nil
+}
+
+func (m *FileMemory) PublishFileDeleted(_ context.Context, id uuid.UUID) error {
+	event, err := message.NewFileDeletedEvent(id, time.Now())
+	if err != nil {
+		return err
+	}
+	m.append(message.FileDeletedSubject, event)
+	return nil
+}
+
+// NewFileMemory creates a FileMemory keeping the last size events, or DefaultSize when size is not positive.
+func NewFileMemory(size int) *FileMemory {
+	return &FileMemory{eventLog: newEventLog(size)}
+}
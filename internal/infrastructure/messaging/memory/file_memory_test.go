@@ -0,0 +1,21 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging/messagingtest"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+)
+
+func TestFileMemory_Contract(t *testing.T) {
+	t.Parallel()
+	messagingtest.RunFileMessagingSuite(t, func(t *testing.T) (messaging.IFileMessaging, <-chan messagingtest.Received) {
+		received := make(chan messagingtest.Received, 10)
+		memory := NewFileMemory(10)
+		memory.Listen(func(subject string, event cloudevents.Event) {
+			received <- messagingtest.Received{Subject: subject, Event: event}
+		})
+		return memory, received
+	})
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+
+	"github.com/google/uuid"
+)
+
+var _ messaging.IFooMessaging = (*FooMemory)(nil)
+
+// FooMemory keeps the last Foo events published in process memory, in publication order. It stands in for a broker
+// in development and tests, where the published events can be inspected or dispatched to in-process listeners.
+type FooMemory struct {
+	*eventLog
+}
+
+func (m *FooMemory) PublishFooCreated(_ context.Context, foo *model.Foo) error {
+	event, err := message.NewFooCreatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	m.append(message.FooCreatedSubject, event)
+	return nil
+}
+
+func (m *FooMemory) PublishFooUpdated(_ context.Context, foo *model.Foo) error {
+	event, err := message.NewFooUpdatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	m.append(message.FooUpdatedSubject, event)
+	return nil
+}
+
+func (m *FooMemory) PublishFooDeleted(_ context.Context, id uuid.UUID) error {
+	event, err := message.NewFooDeletedEvent(id, time.Now())
+	if err != nil {
+		return err
+	}
+	m.append(message.FooDeletedSubject, event)
+	return nil
+}
+
+// NewFooMemory creates a FooMemory keeping the last size events, or DefaultSize when size is not positive.
+func NewFooMemory(size int) *FooMemory {
+	return &FooMemory{eventLog: newEventLog(size)}
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging/messagingtest"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFooMemory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	foo := &model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001"), Label: "Foo1", CreatedAt: createdAt}
+
+	memory := NewFooMemory(2)
+	var subjects []string
+	memory.Listen(func(subject string, _ cloudevents.Event) {
+		subjects = append(subjects, subject)
+	})
+	assert.NoError(t, memory.PublishFooCreated(ctx, foo))
+	assert.NoError(t, memory.PublishFooUpdated(ctx, foo))
+	assert.NoError(t, memory.PublishFooDeleted(ctx, foo.Id))
+
+	// The oldest event is dropped once the memory is full.
+	published := memory.Events()
+	assert.Len(t, published, 2)
+	assert.Equal(t, events.FooUpdatedV1.Type, published[0].Type)
+	assert.Equal(t, events.FooDeletedV1.Type, published[1].Type)
+	assert.Equal(t, foo.Id.String(), published[1].Subject)
+
+	assert.Equal(t, []string{"foo.created", "foo.updated", "foo.deleted"}, subjects)
+
+	published[0].Type = "changed"
+	assert.Equal(t, events.FooUpdatedV1.Type, memory.Events()[0].Type)
+}
+
+func TestFooMemory_Contract(t *testing.T) {
+	t.Parallel()
+	messagingtest.RunFooMessagingSuite(t, func(t *testing.T) (messaging.IFooMessaging, <-chan messagingtest.Received) {
+		received := make(chan messagingtest.Received, 10)
+		memory := NewFooMemory(10)
+		memory.Listen(func(subject string, event cloudevents.Event) {
+			received <- messagingtest.Received{Subject: subject, Event: event}
+		})
+		return memory, received
+	})
+}
@@ -0,0 +1,152 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+)
+
+var (
+	_ messaging.IFileMessaging = (*FileNats)(nil)
+)
+
+// FileNats wraps a NATS connection and implements the IFileMessaging interface for publishing File-related messages
+// as CloudEvents, like FooNats. The File events are published to core NATS, the Foo stream only persisting the Foo
+// subjects.
+type FileNats struct {
+	conn    *nats.Conn
+	encoder *cloudevents.Encoder
+}
+
+// PublishFileCreated publishes a "file.created" message when a File is created, pending its upload.
+func (n *FileNats) PublishFileCreated(ctx context.Context, file *model.File) error {
+	tracer := otel.Tracer("FileNats")
+	ctx, span := tracer.Start(ctx, "FileNats.PublishFileCreated", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(fileAttributes(file, message.FileCreatedSubject)...)
+
+	event, err := message.NewFileCreatedEvent(file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to serialize file")
+		return err
+	}
+
+	if err := n.publish(ctx, message.FileCreatedSubject, event); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to publish message")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// PublishFileUploaded publishes a "file.uploaded" message when the upload of a File is completed.
+func (n *FileNats) PublishFileUploaded(ctx context.Context, file *model.File) error {
+	tracer := otel.Tracer("FileNats")
+	ctx, span := tracer.Start(ctx, "FileNats.PublishFileUploaded", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(fileAttributes(file, message.FileUploadedSubject)...)
+
+	event, err := message.NewFileUploadedEvent(file)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to serialize file")
+		return err
+	}
+
+	if err := n.publish(ctx, message.FileUploadedSubject, event); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to publish message")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// PublishFileDeleted publishes a "file.deleted" message when a File is deleted.
+func (n *FileNats) PublishFileDeleted(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("FileNats")
+	ctx, span := tracer.Start(ctx, "FileNats.PublishFileDeleted", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("file.id", id.String()),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(message.FileDeletedSubject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
+	)
+
+	event, err := message.NewFileDeletedEvent(id, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to serialize id")
+		return err
+	}
+
+	if err := n.publish(ctx, message.FileDeletedSubject, event); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to publish message")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// publish encodes the event in a message carrying the context of ctx, and sends it to core NATS.
+func (n *FileNats) publish(ctx context.Context, subject string, event cloudevents.Event) error {
+	msg := newMsg(ctx, subject, nil)
+	if err := n.encoder.Encode(msg, event); err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		semconv.MessagingMessageID(event.ID),
+		semconv.MessagingMessageBodySize(len(msg.Data)),
+		attribute.String("cloudevents.event_dataschema", event.DataSchema),
+		semconv.CloudEventsEventID(event.ID),
+		semconv.CloudEventsEventType(event.Type),
+		semconv.CloudEventsEventSpecVersion(cloudevents.SpecVersion),
+	)
+
+	if err := n.conn.PublishMsg(msg); err != nil {
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+	return nil
+}
+
+func fileAttributes(file *model.File, subject string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("file.id", file.Id.String()),
+		attribute.String("file.mime_type", file.MimeType),
+		attribute.Int64("file.size", file.Size),
+		attribute.String("file.status", string(file.Status)),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(subject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
+	}
+}
+
+func NewFileNats(conn *nats.Conn, encoder *cloudevents.Encoder) *FileNats {
+	return &FileNats{conn: conn, encoder: encoder}
+}
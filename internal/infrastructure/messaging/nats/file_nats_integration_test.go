@@ -0,0 +1,50 @@
+package nats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging/messagingtest"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
+	"github.com/nats-io/nats.go"
+)
+
+// TestIntegrationFileNats_Contract runs the IFileMessaging conformance suite against core NATS, in both CloudEvents
+// modes.
+func TestIntegrationFileNats_Contract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateNatsContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nc, err := NewNats(container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{cloudevents.ModeStructured, cloudevents.ModeBinary} {
+		t.Run(mode, func(t *testing.T) {
+			messagingtest.RunFileMessagingSuite(t, func(t *testing.T) (messaging.IFileMessaging, <-chan messagingtest.Received) {
+				received := make(chan messagingtest.Received, 10)
+				sub, err := nc.Subscribe("file.*", func(msg *nats.Msg) {
+					event, err := cloudevents.Decode(msg.Header, msg.Data)
+					if err != nil {
+						t.Error("failed to decode event:", err)
+						return
+					}
+					received <- messagingtest.Received{Subject: msg.Subject, Event: *event}
+				})
+				if err != nil {
+					t.Fatal("failed to subscribe:", err)
+				}
+				t.Cleanup(func() { _ = sub.Unsubscribe() })
+
+				return NewFileNats(nc, newTestEncoder(t, mode)), received
+			})
+		})
+	}
+}
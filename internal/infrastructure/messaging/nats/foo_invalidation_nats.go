@@ -0,0 +1,120 @@
+package nats
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/cache"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"go.uber.org/zap"
+)
+
+const fooInvalidationSubject = "cache.foo.invalidate"
+
+var (
+	_ cache.IFooInvalidator = (*FooInvalidationNats)(nil)
+)
+
+// FooInvalidationNats broadcasts Foo cache invalidations to every instance over NATS.
+// It uses a plain subscription instead of a queue group so that each replica receives all invalidations,
+// and ignores the invalidations it published itself.
+type FooInvalidationNats struct {
+	logger       *zap.Logger
+	conn         *nats.Conn
+	instance     string
+	subscription *nats.Subscription
+}
+
+func (n *FooInvalidationNats) PublishInvalidation(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("FooInvalidationNats")
+	ctx, span := tracer.Start(ctx, "FooInvalidationNats.PublishInvalidation", trace.WithSpanKind(trace.SpanKindProducer))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("foo.id", id.String()),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(fooInvalidationSubject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
+	)
+
+	data, err := json.Marshal(message.FooInvalidationMessage{Id: id, Origin: n.instance})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to serialize invalidation")
+		return fmt.Errorf("failed to serialize invalidation: %w", err)
+	}
+
+	if err := n.conn.PublishMsg(newMsg(ctx, fooInvalidationSubject, data)); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to publish message")
+		return fmt.Errorf("failed to publish to NATS: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Subscribe calls handler with the id of every Foo invalidated by another instance.
+func (n *FooInvalidationNats) Subscribe(handler func(ctx context.Context, id uuid.UUID)) error {
+	sub, err := n.conn.Subscribe(fooInvalidationSubject, func(msg *nats.Msg) {
+		var invalidation message.FooInvalidationMessage
+		if err := json.Unmarshal(msg.Data, &invalidation); err != nil {
+			n.logger.Warn("fail to decode foo cache invalidation", zap.Error(err))
+			return
+		}
+		if invalidation.Origin == n.instance {
+			return
+		}
+
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), correlation.NatsHeaderCarrier(msg.Header))
+		tracer := otel.Tracer("FooInvalidationNats")
+		ctx, span := tracer.Start(ctx, "FooInvalidationNats.OnInvalidation",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("foo.id", invalidation.Id.String()),
+				semconv.MessagingSystemKey.String(messagingSystem),
+				semconv.MessagingDestinationName(msg.Subject),
+				semconv.MessagingOperationName("process"),
+				semconv.MessagingOperationTypeProcess,
+			),
+		)
+		defer span.End()
+
+		handler(ctx, invalidation.Id)
+		span.SetStatus(codes.Ok, "")
+	})
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to %s: %w", fooInvalidationSubject, err)
+	}
+	n.subscription = sub
+
+	return nil
+}
+
+func (n *FooInvalidationNats) Close() error {
+	if n.subscription == nil {
+		return nil
+	}
+
+	return n.subscription.Unsubscribe()
+}
+
+// NewFooInvalidationNats creates a FooInvalidationNats identified by a random instance id.
+func NewFooInvalidationNats(logger *zap.Logger, conn *nats.Conn) *FooInvalidationNats {
+	return &FooInvalidationNats{
+		logger:   logger,
+		conn:     conn,
+		instance: uuid.NewString(),
+	}
+}
@@ -2,24 +2,31 @@ package nats
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
 	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 const (
-	fooCreatedSubject = "foo.created"
-	fooUpdatedSubject = "foo.updated"
-	fooDeletedSubject = "foo.deleted"
+	fooCreatedSubject = message.FooCreatedSubject
+	fooUpdatedSubject = message.FooUpdatedSubject
+	fooDeletedSubject = message.FooDeletedSubject
+
+	messagingSystem = "nats"
 )
 
 var (
@@ -27,14 +34,19 @@ var (
 )
 
 // FooNats wraps a NATS connection and implements the IFooMessaging interface for publishing Foo-related messages.
+// Every message is a CloudEvent whose dataschema identifies the version of its data in the events catalog, and whose
+// id is derived from the event, so that publishing the same change twice yields the same id. With a JetStream context,
+// messages are persisted in the Foo stream, which drops the duplicates of a retried publication by this id.
 type FooNats struct {
-	conn *nats.Conn
+	conn    *nats.Conn
+	js      jetstream.JetStream
+	encoder *cloudevents.Encoder
 }
 
 // PublishFooCreated publishes a "foo.created" message to the NATS server using the provided Foo data.
 func (n *FooNats) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
 	tracer := otel.Tracer("FooNats")
-	_, span := tracer.Start(ctx, "FooNats.PublishFooCreated")
+	ctx, span := tracer.Start(ctx, "FooNats.PublishFooCreated", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -42,20 +54,27 @@ func (n *FooNats) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
 		attribute.String("foo.label", foo.Label),
 		attribute.Int("foo.value", foo.Value),
 		attribute.Float64("foo.weight", float64(foo.Weight)),
-		attribute.String("nats.subject", fooCreatedSubject),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(fooCreatedSubject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
 	)
 
-	msg := message.NewFooMessage(foo)
-	data, err := json.Marshal(msg)
+	event, err := message.NewFooCreatedEvent(foo)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to serialize foo")
-		return fmt.Errorf("failed to serialize Foo: %w", err)
+		return err
 	}
 
-	span.SetAttributes(attribute.Int("message.size", len(data)))
+	msg, err := n.newEvent(ctx, fooCreatedSubject, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to encode event")
+		return err
+	}
 
-	if err := n.conn.Publish(fooCreatedSubject, data); err != nil {
+	if err := n.publish(ctx, msg, event.ID); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish message")
 		return fmt.Errorf("failed to publish to NATS: %w", err)
@@ -68,7 +87,7 @@ func (n *FooNats) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
 
 func (n *FooNats) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
 	tracer := otel.Tracer("FooNats")
-	_, span := tracer.Start(ctx, "FooNats.PublishFooUpdated")
+	ctx, span := tracer.Start(ctx, "FooNats.PublishFooUpdated", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
@@ -76,20 +95,27 @@ func (n *FooNats) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
 		attribute.String("foo.label", foo.Label),
 		attribute.Int("foo.value", foo.Value),
 		attribute.Float64("foo.weight", float64(foo.Weight)),
-		attribute.String("nats.subject", fooUpdatedSubject),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(fooUpdatedSubject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
 	)
 
-	msg := message.NewFooMessage(foo)
-	data, err := json.Marshal(msg)
+	event, err := message.NewFooUpdatedEvent(foo)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to serialize foo")
-		return fmt.Errorf("failed to serialize Foo: %w", err)
+		return err
 	}
 
-	span.SetAttributes(attribute.Int("message.size", len(data)))
+	msg, err := n.newEvent(ctx, fooUpdatedSubject, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to encode event")
+		return err
+	}
 
-	if err := n.conn.Publish(fooUpdatedSubject, data); err != nil {
+	if err := n.publish(ctx, msg, event.ID); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish message")
 		return fmt.Errorf("failed to publish to NATS: %w", err)
@@ -101,24 +127,32 @@ func (n *FooNats) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
 
 func (n *FooNats) PublishFooDeleted(ctx context.Context, id uuid.UUID) error {
 	tracer := otel.Tracer("FooNats")
-	_, span := tracer.Start(ctx, "FooNats.PublishFooDeleted")
+	ctx, span := tracer.Start(ctx, "FooNats.PublishFooDeleted", trace.WithSpanKind(trace.SpanKindProducer))
 	defer span.End()
 
 	span.SetAttributes(
 		attribute.String("foo.id", id.String()),
-		attribute.String("nats.subject", fooDeletedSubject),
+		semconv.MessagingSystemKey.String(messagingSystem),
+		semconv.MessagingDestinationName(fooDeletedSubject),
+		semconv.MessagingOperationName("publish"),
+		semconv.MessagingOperationTypeSend,
 	)
 
-	data, err := json.Marshal(map[string]string{"id": id.String()})
+	event, err := message.NewFooDeletedEvent(id, time.Now())
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to serialize id")
-		return fmt.Errorf("failed to serialize ID: %w", err)
+		return err
 	}
 
-	span.SetAttributes(attribute.Int("message.size", len(data)))
+	msg, err := n.newEvent(ctx, fooDeletedSubject, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to encode event")
+		return err
+	}
 
-	if err := n.conn.Publish(fooDeletedSubject, data); err != nil {
+	if err := n.publish(ctx, msg, event.ID); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish message")
 		return fmt.Errorf("failed to publish to NATS: %w", err)
@@ -128,6 +162,49 @@ func (n *FooNats) PublishFooDeleted(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
-func NewFooNats(conn *nats.Conn) *FooNats {
-	return &FooNats{conn: conn}
+// newEvent builds the message of a Foo event wrapped in a CloudEvent, and records the event on the span of ctx.
+func (n *FooNats) newEvent(ctx context.Context, subject string, event cloudevents.Event) (*nats.Msg, error) {
+	msg := newMsg(ctx, subject, nil)
+	if err := n.encoder.Encode(msg, event); err != nil {
+		return nil, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		semconv.MessagingMessageID(event.ID),
+		semconv.MessagingMessageBodySize(len(msg.Data)),
+		attribute.String("cloudevents.event_dataschema", event.DataSchema),
+		semconv.CloudEventsEventID(event.ID),
+		semconv.CloudEventsEventType(event.Type),
+		semconv.CloudEventsEventSpecVersion(cloudevents.SpecVersion),
+	)
+	return msg, nil
+}
+
+// publish sends the message to JetStream, waiting for its acknowledgement, or to core NATS without JetStream.
+// The id of the event deduplicates the retried publications in JetStream.
+func (n *FooNats) publish(ctx context.Context, msg *nats.Msg, id string) error {
+	if n.js == nil {
+		return n.conn.PublishMsg(msg)
+	}
+
+	_, err := n.js.PublishMsg(ctx, msg, jetstream.WithMsgID(id))
+	return err
+}
+
+// newMsg builds a NATS message carrying the request id and the W3C trace context (traceparent, tracestate, baggage)
+// of ctx in its headers, so that consumers can correlate their logs and continue the trace.
+func newMsg(ctx context.Context, subject string, data []byte) *nats.Msg {
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	if id := correlation.RequestID(ctx); id != "" {
+		msg.Header.Set(correlation.HeaderRequestID, id)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, correlation.NatsHeaderCarrier(msg.Header))
+
+	return msg
+}
+
+// NewFooNats creates a FooNats publishing to JetStream when js is not nil, and to core NATS otherwise.
+func NewFooNats(conn *nats.Conn, js jetstream.JetStream, encoder *cloudevents.Encoder) *FooNats {
+	return &FooNats{conn: conn, js: js, encoder: encoder}
 }
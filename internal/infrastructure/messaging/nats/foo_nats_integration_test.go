@@ -7,7 +7,11 @@ import (
 	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging/messagingtest"
 	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
 
 	"github.com/google/uuid"
 	"github.com/nats-io/nats.go"
@@ -62,6 +66,9 @@ func TestIntegrationFooNats_PublishFooCreated(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			messageChan := make(chan message.FooMessage, 1)
 			sub, err := nc.Subscribe(fooCreatedSubject, func(msg *nats.Msg) {
+				assert.Equal(t, events.FooCreatedType, msg.Header.Get(cloudevents.HeaderType))
+				assert.Equal(t, events.FooCreatedV1.DataSchema(), msg.Header.Get(cloudevents.HeaderDataSchema))
+				assert.NoError(t, events.Default.Validate(events.FooCreatedType, 1, msg.Data))
 				var receivedDta message.FooMessage
 				err := json.Unmarshal(msg.Data, &receivedDta)
 				if err != nil {
@@ -75,7 +82,7 @@ func TestIntegrationFooNats_PublishFooCreated(t *testing.T) {
 			}
 			defer sub.Unsubscribe()
 
-			messaging := NewFooNats(nc)
+			messaging := NewFooNats(nc, nil, newTestEncoder(t, cloudevents.ModeBinary))
 
 			err = messaging.PublishFooCreated(ctx, testCase.foo)
 
@@ -152,6 +159,9 @@ func TestIntegrationFooNats_PublishFooUpdated(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			messageChan := make(chan message.FooMessage, 1)
 			sub, err := nc.Subscribe(fooUpdatedSubject, func(msg *nats.Msg) {
+				assert.Equal(t, events.FooUpdatedType, msg.Header.Get(cloudevents.HeaderType))
+				assert.Equal(t, events.FooUpdatedV1.DataSchema(), msg.Header.Get(cloudevents.HeaderDataSchema))
+				assert.NoError(t, events.Default.Validate(events.FooUpdatedType, 1, msg.Data))
 				var receivedFoo message.FooMessage
 				err := json.Unmarshal(msg.Data, &receivedFoo)
 				if err != nil {
@@ -165,7 +175,7 @@ func TestIntegrationFooNats_PublishFooUpdated(t *testing.T) {
 			}
 			defer sub.Unsubscribe()
 
-			messaging := NewFooNats(nc)
+			messaging := NewFooNats(nc, nil, newTestEncoder(t, cloudevents.ModeBinary))
 
 			err = messaging.PublishFooUpdated(ctx, testCase.foo)
 
@@ -224,9 +234,10 @@ func TestIntegrationFooNats_PublishFooDeleted(t *testing.T) {
 		t.Run(testCase.name, func(t *testing.T) {
 			messageChan := make(chan uuid.UUID, 1)
 			sub, err := nc.Subscribe(fooDeletedSubject, func(msg *nats.Msg) {
-				var receivedId struct {
-					Id uuid.UUID
-				}
+				assert.Equal(t, events.FooDeletedType, msg.Header.Get(cloudevents.HeaderType))
+				assert.Equal(t, events.FooDeletedV1.DataSchema(), msg.Header.Get(cloudevents.HeaderDataSchema))
+				assert.NoError(t, events.Default.Validate(events.FooDeletedType, 1, msg.Data))
+				var receivedId message.FooDeletedMessage
 				err := json.Unmarshal(msg.Data, &receivedId)
 				if err != nil {
 					t.Error("failed to unmarshal data:", err)
@@ -239,7 +250,7 @@ func TestIntegrationFooNats_PublishFooDeleted(t *testing.T) {
 			}
 			defer sub.Unsubscribe()
 
-			messaging := NewFooNats(nc)
+			messaging := NewFooNats(nc, nil, newTestEncoder(t, cloudevents.ModeBinary))
 
 			err = messaging.PublishFooDeleted(ctx, testCase.id)
 
@@ -259,3 +270,101 @@ func TestIntegrationFooNats_PublishFooDeleted(t *testing.T) {
 		})
 	}
 }
+
+// TestIntegrationFooNats_Structured validates that events published in structured mode carry the whole CloudEvent
+// envelope in their body.
+func TestIntegrationFooNats_Structured(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateNatsContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nc, err := NewNats(container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messageChan := make(chan *nats.Msg, 1)
+	sub, err := nc.ChanSubscribe(fooCreatedSubject, messageChan)
+	if err != nil {
+		t.Fatal("failed to subscribe:", err)
+	}
+	defer sub.Unsubscribe()
+
+	foo := &model.Foo{
+		Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+		Label:     "foo_create",
+		Value:     10,
+		Weight:    1.5,
+		CreatedAt: time.Now(),
+	}
+	messaging := NewFooNats(nc, nil, newTestEncoder(t, cloudevents.ModeStructured))
+	assert.NoError(t, messaging.PublishFooCreated(ctx, foo))
+
+	select {
+	case msg := <-messageChan:
+		event, err := cloudevents.Decode(msg.Header, msg.Data)
+		assert.NoError(t, err)
+		assert.Equal(t, events.FooCreatedType, event.Type)
+		assert.Equal(t, "/astigo", event.Source)
+		assert.Equal(t, foo.Id.String(), event.Subject)
+		assert.Equal(t, message.EventID(fooCreatedSubject, foo.Id, foo.CreatedAt.UnixNano()), event.ID)
+		eventType, version, err := events.ParseDataSchema(event.DataSchema)
+		assert.NoError(t, err)
+		assert.NoError(t, events.Default.Validate(eventType, version, event.Data))
+
+		var received message.FooMessage
+		assert.NoError(t, json.Unmarshal(event.Data, &received))
+		assert.Equal(t, foo.Id, received.Id)
+	case <-time.After(2 * time.Second):
+		t.Error("timeout: no message received")
+	}
+}
+
+// newTestEncoder creates the CloudEvents encoder used by the tests in the given mode.
+func newTestEncoder(t *testing.T, mode string) *cloudevents.Encoder {
+	encoder, err := cloudevents.NewEncoder(cloudevents.Config{Mode: mode, Source: "/astigo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoder
+}
+
+// TestIntegrationFooNats_Contract runs the IFooMessaging conformance suite against core NATS, in both CloudEvents modes.
+func TestIntegrationFooNats_Contract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateNatsContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nc, err := NewNats(container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mode := range []string{cloudevents.ModeStructured, cloudevents.ModeBinary} {
+		t.Run(mode, func(t *testing.T) {
+			messagingtest.RunFooMessagingSuite(t, func(t *testing.T) (messaging.IFooMessaging, <-chan messagingtest.Received) {
+				received := make(chan messagingtest.Received, 10)
+				sub, err := nc.Subscribe("foo.*", func(msg *nats.Msg) {
+					event, err := cloudevents.Decode(msg.Header, msg.Data)
+					if err != nil {
+						t.Error("failed to decode event:", err)
+						return
+					}
+					received <- messagingtest.Received{Subject: msg.Subject, Event: *event}
+				})
+				if err != nil {
+					t.Fatal("failed to subscribe:", err)
+				}
+				t.Cleanup(func() { _ = sub.Unsubscribe() })
+
+				return NewFooNats(nc, nil, newTestEncoder(t, mode)), received
+			})
+		})
+	}
+}
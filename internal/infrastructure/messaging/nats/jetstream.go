@@ -0,0 +1,65 @@
+package nats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	fooStreamSubjects           = "foo.*"
+	fooDeadLetterStreamSubjects = "dlq.foo.*"
+	deadLetterStreamSuffix      = "_DLQ"
+)
+
+// JetStreamConfig defines the streams persisting Foo events. When enabled, events are published to JetStream and
+// consumed by durable consumers, so that they survive the absence of consumers. The dead-letter stream keeps the
+// events that could not be processed.
+type JetStreamConfig struct {
+	Enabled         bool          `mapstructure:"enabled"`
+	Stream          string        `mapstructure:"stream"`
+	Replicas        int           `mapstructure:"replicas"`
+	MaxAge          time.Duration `mapstructure:"max_age"`
+	DuplicateWindow time.Duration `mapstructure:"duplicate_window"`
+}
+
+// DeadLetterStream returns the name of the stream keeping the dead-lettered Foo events.
+func (c JetStreamConfig) DeadLetterStream() string {
+	return c.Stream + deadLetterStreamSuffix
+}
+
+// NewJetStream creates a JetStream context on the connection and provisions the Foo event stream and its dead-letter
+// stream, updating their configuration when they already exist.
+func NewJetStream(ctx context.Context, conn *nats.Conn, config JetStreamConfig) (jetstream.JetStream, error) {
+	js, err := jetstream.New(conn)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create jetstream context: %w", err)
+	}
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:       config.Stream,
+		Subjects:   []string{fooStreamSubjects},
+		Retention:  jetstream.LimitsPolicy,
+		Storage:    jetstream.FileStorage,
+		Replicas:   config.Replicas,
+		MaxAge:     config.MaxAge,
+		Duplicates: config.DuplicateWindow,
+	}); err != nil {
+		return nil, fmt.Errorf("fail to provision stream %s: %w", config.Stream, err)
+	}
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      config.DeadLetterStream(),
+		Subjects:  []string{fooDeadLetterStreamSubjects},
+		Retention: jetstream.LimitsPolicy,
+		Storage:   jetstream.FileStorage,
+		Replicas:  config.Replicas,
+	}); err != nil {
+		return nil, fmt.Errorf("fail to provision stream %s: %w", config.DeadLetterStream(), err)
+	}
+
+	return js, nil
+}
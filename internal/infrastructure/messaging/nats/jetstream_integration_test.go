@@ -0,0 +1,61 @@
+package nats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIntegrationJetStream_Publish tests that Foo events are persisted in the provisioned stream and that publishing
+// the same event twice stores it once.
+func TestIntegrationJetStream_Publish(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreateNatsContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nc, err := NewNats(container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := JetStreamConfig{Enabled: true, Stream: "FOO", Replicas: 1, DuplicateWindow: time.Minute}
+	js, err := NewJetStream(ctx, nc, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Provisioning is idempotent.
+	if _, err := NewJetStream(ctx, nc, config); err != nil {
+		t.Fatal(err)
+	}
+
+	foo := &model.Foo{
+		Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+		Label:     "foo_create",
+		Value:     10,
+		Weight:    1.5,
+		CreatedAt: time.Now(),
+	}
+	messaging := NewFooNats(nc, js, newTestEncoder(t, cloudevents.ModeStructured))
+	assert.NoError(t, messaging.PublishFooCreated(ctx, foo))
+	assert.NoError(t, messaging.PublishFooCreated(ctx, foo))
+	assert.NoError(t, messaging.PublishFooDeleted(ctx, foo.Id))
+
+	stream, err := js.Stream(ctx, config.Stream)
+	assert.NoError(t, err)
+	info, err := stream.Info(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), info.State.Msgs)
+
+	_, err = js.Stream(ctx, config.DeadLetterStream())
+	assert.NoError(t, err)
+}
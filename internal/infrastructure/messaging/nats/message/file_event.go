@@ -0,0 +1,71 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FileCreatedSubject  = "file.created"
+	FileUploadedSubject = "file.uploaded"
+	FileDeletedSubject  = "file.deleted"
+)
+
+// NewFileCreatedEvent builds the CloudEvent published when a File is created, pending its upload.
+func NewFileCreatedEvent(file *model.File) (cloudevents.Event, error) {
+	data, err := json.Marshal(NewFileMessage(file))
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize File: %w", err)
+	}
+
+	return cloudevents.Event{
+		ID:         EventID(FileCreatedSubject, file.Id, file.CreatedAt.UnixNano()),
+		Type:       events.FileCreatedV1.Type,
+		DataSchema: events.FileCreatedV1.DataSchema(),
+		Subject:    file.Id.String(),
+		Time:       &file.CreatedAt,
+		Data:       data,
+	}, nil
+}
+
+// NewFileUploadedEvent builds the CloudEvent published when the upload of a File is completed, identified by its
+// upload time.
+func NewFileUploadedEvent(file *model.File) (cloudevents.Event, error) {
+	data, err := json.Marshal(NewFileMessage(file))
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize File: %w", err)
+	}
+
+	return cloudevents.Event{
+		ID:         EventID(FileUploadedSubject, file.Id, file.UploadedAt.UnixNano()),
+		Type:       events.FileUploadedV1.Type,
+		DataSchema: events.FileUploadedV1.DataSchema(),
+		Subject:    file.Id.String(),
+		Time:       &file.UploadedAt,
+		Data:       data,
+	}, nil
+}
+
+// NewFileDeletedEvent builds the CloudEvent published when a File is deleted at the given time.
+func NewFileDeletedEvent(id uuid.UUID, deletedAt time.Time) (cloudevents.Event, error) {
+	data, err := json.Marshal(FileDeletedMessage{Id: id})
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize ID: %w", err)
+	}
+
+	return cloudevents.Event{
+		ID:         EventID(FileDeletedSubject, id, 0),
+		Type:       events.FileDeletedV1.Type,
+		DataSchema: events.FileDeletedV1.DataSchema(),
+		Subject:    id.String(),
+		Time:       &deletedAt,
+		Data:       data,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package message
+
+import (
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// FileMessage represents a data transfer object for File, used for messaging or serialization purposes. The path of
+// the file in the object storage is left out.
+type FileMessage struct {
+	Id         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Size       int64      `json:"size"`
+	Extension  string     `json:"extension"`
+	MimeType   string     `json:"mime_type"`
+	Status     string     `json:"status"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UploadedAt *time.Time `json:"uploaded_at"`
+}
+
+// NewFileMessage transforms a model.File instance into a corresponding FileMessage instance for external usage or serialization.
+func NewFileMessage(file *model.File) *FileMessage {
+	msg := &FileMessage{
+		Id:        file.Id,
+		Name:      file.Name,
+		Size:      file.Size,
+		Extension: file.Extension,
+		MimeType:  file.MimeType,
+		Status:    string(file.Status),
+		CreatedAt: file.CreatedAt,
+	}
+	if !file.UploadedAt.IsZero() {
+		uploadedAt := file.UploadedAt
+		msg.UploadedAt = &uploadedAt
+	}
+	return msg
+}
+
+// FileDeletedMessage represents the data of the event published when a File is deleted.
+type FileDeletedMessage struct {
+	Id uuid.UUID `json:"id"`
+}
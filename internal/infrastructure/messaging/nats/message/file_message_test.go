@@ -0,0 +1,55 @@
+package message
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFileMessage_Schema checks that the published payloads match the current version of their event schema.
+func TestFileMessage_Schema(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	file := &model.File{
+		Id:        uuid.MustParse("30000000-0000-0000-0000-000000000001"),
+		Name:      "report.pdf",
+		Size:      1024,
+		Extension: ".pdf",
+		MimeType:  "application/pdf",
+		Path:      "files/30000000-0000-0000-0000-000000000001.pdf",
+		Status:    model.UploadStatusPending,
+		CreatedAt: now,
+	}
+	uploaded := *file
+	uploaded.Status = model.UploadStatusComplete
+	uploaded.UploadedAt = now
+
+	testCases := []struct {
+		name       string
+		definition events.Definition
+		payload    any
+	}{
+		{name: "Success Case - Created", definition: events.FileCreatedV1, payload: NewFileMessage(file)},
+		{name: "Success Case - Uploaded", definition: events.FileUploadedV1, payload: NewFileMessage(&uploaded)},
+		{name: "Success Case - Deleted", definition: events.FileDeletedV1, payload: FileDeletedMessage{Id: file.Id}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			current, ok := events.Default.Current(testCase.definition.Type)
+			assert.True(t, ok)
+			assert.Equal(t, current, testCase.definition, "the publisher must produce the current version")
+
+			data, err := json.Marshal(testCase.payload)
+			assert.NoError(t, err)
+			assert.NoError(t, events.Default.Validate(testCase.definition.Type, testCase.definition.Version, data))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FooCreatedSubject = "foo.created"
+	FooUpdatedSubject = "foo.updated"
+	FooDeletedSubject = "foo.deleted"
+)
+
+// NewFooCreatedEvent builds the CloudEvent published when a Foo is created, without its source and spec version
+// which are completed by the encoder.
+func NewFooCreatedEvent(foo *model.Foo) (cloudevents.Event, error) {
+	data, err := json.Marshal(NewFooMessage(foo))
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize Foo: %w", err)
+	}
+
+	return cloudevents.Event{
+		ID:         EventID(FooCreatedSubject, foo.Id, foo.CreatedAt.UnixNano()),
+		Type:       events.FooCreatedV1.Type,
+		DataSchema: events.FooCreatedV1.DataSchema(),
+		Subject:    foo.Id.String(),
+		Time:       &foo.CreatedAt,
+		Data:       data,
+	}, nil
+}
+
+// NewFooUpdatedEvent builds the CloudEvent published when a Foo is updated, identified by its update time.
+func NewFooUpdatedEvent(foo *model.Foo) (cloudevents.Event, error) {
+	data, err := json.Marshal(NewFooMessage(foo))
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize Foo: %w", err)
+	}
+
+	version := foo.CreatedAt
+	if foo.UpdatedAt != nil {
+		version = *foo.UpdatedAt
+	}
+	return cloudevents.Event{
+		ID:         EventID(FooUpdatedSubject, foo.Id, version.UnixNano()),
+		Type:       events.FooUpdatedV1.Type,
+		DataSchema: events.FooUpdatedV1.DataSchema(),
+		Subject:    foo.Id.String(),
+		Time:       &version,
+		Data:       data,
+	}, nil
+}
+
+// NewFooDeletedEvent builds the CloudEvent published when a Foo is deleted at the given time.
+func NewFooDeletedEvent(id uuid.UUID, deletedAt time.Time) (cloudevents.Event, error) {
+	data, err := json.Marshal(FooDeletedMessage{Id: id})
+	if err != nil {
+		return cloudevents.Event{}, fmt.Errorf("failed to serialize ID: %w", err)
+	}
+
+	return cloudevents.Event{
+		ID:         EventID(FooDeletedSubject, id, 0),
+		Type:       events.FooDeletedV1.Type,
+		DataSchema: events.FooDeletedV1.DataSchema(),
+		Subject:    id.String(),
+		Time:       &deletedAt,
+		Data:       data,
+	}, nil
+}
+
+// EventID identifies an event by the version of the resource it carries: publishing the same change twice yields the
+// same id.
+func EventID(subject string, id uuid.UUID, version int64) string {
+	return fmt.Sprintf("%s:%s:%d", subject, id, version)
+}
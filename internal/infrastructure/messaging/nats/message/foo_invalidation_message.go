@@ -0,0 +1,9 @@
+package message
+
+import "github.com/google/uuid"
+
+// FooInvalidationMessage asks every instance but the origin to drop its local copy of a Foo.
+type FooInvalidationMessage struct {
+	Id     uuid.UUID `json:"id"`
+	Origin string    `json:"origin"`
+}
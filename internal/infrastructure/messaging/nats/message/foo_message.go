@@ -29,3 +29,8 @@ func NewFooMessage(foo *model.Foo) *FooMessage {
 		UpdatedAt: foo.UpdatedAt,
 	}
 }
+
+// FooDeletedMessage represents the data of the event published when a Foo is deleted.
+type FooDeletedMessage struct {
+	Id uuid.UUID `json:"id"`
+}
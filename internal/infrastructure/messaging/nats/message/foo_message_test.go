@@ -0,0 +1,53 @@
+package message
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFooMessage_Schema checks that the published payloads match the current version of their event schema, so that a
+// change of their shape comes with a new schema version.
+func TestFooMessage_Schema(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	foo := &model.Foo{
+		Id:        uuid.MustParse("20000000-0000-0000-0000-000000000001"),
+		Label:     "foo",
+		Secret:    "secret",
+		Value:     10,
+		Weight:    1.5,
+		CreatedAt: now,
+	}
+	updated := *foo
+	updated.UpdatedAt = &now
+
+	testCases := []struct {
+		name       string
+		definition events.Definition
+		payload    any
+	}{
+		{name: "Success Case - Created", definition: events.FooCreatedV1, payload: NewFooMessage(foo)},
+		{name: "Success Case - Updated", definition: events.FooUpdatedV1, payload: NewFooMessage(&updated)},
+		{name: "Success Case - Deleted", definition: events.FooDeletedV1, payload: FooDeletedMessage{Id: foo.Id}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			current, ok := events.Default.Current(testCase.definition.Type)
+			assert.True(t, ok)
+			assert.Equal(t, current, testCase.definition, "the publisher must produce the current version")
+
+			data, err := json.Marshal(testCase.payload)
+			assert.NoError(t, err)
+			assert.NoError(t, events.Default.Validate(testCase.definition.Type, testCase.definition.Version, data))
+		})
+	}
+}
@@ -3,14 +3,18 @@ package nats
 import (
 	"fmt"
 
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
 	"github.com/nats-io/nats.go"
 )
 
 // Config represents the configuration settings required to connect to a NATS server.
 type Config struct {
-	URL      string `mapstructure:"url"`
-	Username string `mapstructure:"username"`
-	Password string `mapstructure:"password"`
+	URL         string             `mapstructure:"url"`
+	Username    string             `mapstructure:"username"`
+	Password    string             `mapstructure:"password"`
+	JetStream   JetStreamConfig    `mapstructure:"jetstream"`
+	CloudEvents cloudevents.Config `mapstructure:"cloudevents"`
 }
 
 // NewNats establishes a new NATS connection using the provided configuration and returns the connection instance or an error.
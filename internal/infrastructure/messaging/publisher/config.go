@@ -0,0 +1,49 @@
+package publisher
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	SinkNats      = "nats"
+	SinkJetStream = "jetstream"
+	SinkLogging   = "logging"
+	SinkWebhook   = "webhook"
+	SinkMemory    = "memory"
+)
+
+// Config lists the sinks the Foo events are published to.
+type Config struct {
+	Sinks []SinkConfig `mapstructure:"sinks"`
+}
+
+// SinkConfig selects a sink by its type and sets its failure policy. Name labels the sink in the logs and metrics and
+// defaults to its type.
+type SinkConfig struct {
+	Type     string        `mapstructure:"type"`
+	Name     string        `mapstructure:"name"`
+	Required bool          `mapstructure:"required"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+}
+
+// Options returns the options subscribing the sink to a FooPublisher.
+func (c SinkConfig) Options() []SinkOption {
+	name := c.Name
+	if name == "" {
+		name = c.Type
+	}
+
+	opts := []SinkOption{WithName(name), WithTimeout(c.Timeout)}
+	if !c.Required {
+		opts = append(opts, BestEffort())
+	}
+	return opts
+}
+
+// Has tells whether a sink of the given type is configured.
+func (c Config) Has(sinkType string) bool {
+	return slices.ContainsFunc(c.Sinks, func(sink SinkConfig) bool {
+		return sink.Type == sinkType
+	})
+}
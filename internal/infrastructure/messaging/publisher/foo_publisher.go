@@ -3,30 +3,85 @@ package publisher
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/tool/correlation"
+
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 )
 
+const (
+	eventCreated = "created"
+	eventUpdated = "updated"
+	eventDeleted = "deleted"
+)
+
 var _ messaging.IFooMessaging = (*FooPublisher)(nil)
 
+// Sink is a destination of the Foo events fanned out by a FooPublisher. The failure of a required sink fails the
+// publication, while the failure of a best-effort sink is only logged and counted. A positive Timeout bounds each
+// publication to the sink.
+type Sink struct {
+	Name      string
+	Messaging messaging.IFooMessaging
+	Required  bool
+	Timeout   time.Duration
+}
+
+// SinkOption customizes a sink subscribed to a FooPublisher.
+type SinkOption func(*Sink)
+
+// WithName names the sink in the logs and metrics.
+func WithName(name string) SinkOption {
+	return func(s *Sink) {
+		s.Name = name
+	}
+}
+
+// BestEffort keeps the failures of the sink from failing the publication.
+func BestEffort() SinkOption {
+	return func(s *Sink) {
+		s.Required = false
+	}
+}
+
+// WithTimeout bounds each publication to the sink.
+func WithTimeout(timeout time.Duration) SinkOption {
+	return func(s *Sink) {
+		s.Timeout = timeout
+	}
+}
+
+// FooPublisher fans out the Foo events to its sinks concurrently.
 type FooPublisher struct {
-	Subscribers []messaging.IFooMessaging
+	logger      *zap.Logger
+	Subscribers []Sink
 }
 
-// Subscribe to publisher
-func (p *FooPublisher) Subscribe(subscriber messaging.IFooMessaging) {
-	p.Subscribers = append(p.Subscribers, subscriber)
+// Subscribe to publisher. The sink is required unless BestEffort is given, and named after its position by default.
+func (p *FooPublisher) Subscribe(subscriber messaging.IFooMessaging, opts ...SinkOption) {
+	sink := Sink{
+		Name:      fmt.Sprintf("sink-%d", len(p.Subscribers)),
+		Messaging: subscriber,
+		Required:  true,
+	}
+	for _, opt := range opts {
+		opt(&sink)
+	}
+	p.Subscribers = append(p.Subscribers, sink)
 }
 
 // Unsubscribe to publisher
 func (p *FooPublisher) Unsubscribe(subscriber messaging.IFooMessaging) {
 	for i := len(p.Subscribers) - 1; i >= 0; i-- {
-		if p.Subscribers[i] == subscriber {
+		if p.Subscribers[i].Messaging == subscriber {
 			p.Subscribers = append(p.Subscribers[:i], p.Subscribers[i+1:]...)
 		}
 	}
@@ -34,18 +89,15 @@ func (p *FooPublisher) Unsubscribe(subscriber messaging.IFooMessaging) {
 
 func (p *FooPublisher) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
 	tracer := otel.Tracer("FooPublisher")
-	_, span := tracer.Start(ctx, "FooPublisher.PublishFooCreated")
+	ctx, span := tracer.Start(ctx, "FooPublisher.PublishFooCreated")
 	defer span.End()
 
-	g, ctx := errgroup.WithContext(ctx)
+	span.SetAttributes(attribute.String("foo.id", foo.Id.String()))
 
-	for _, subscriber := range p.Subscribers {
-		g.Go(func() error {
-			return subscriber.PublishFooCreated(ctx, foo)
-		})
-	}
-
-	if err := g.Wait(); err != nil {
+	err := p.publish(ctx, eventCreated, func(ctx context.Context, subscriber messaging.IFooMessaging) error {
+		return subscriber.PublishFooCreated(ctx, foo)
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish foo created")
 		return fmt.Errorf("failed to publish foo created: %w", err)
@@ -57,18 +109,15 @@ func (p *FooPublisher) PublishFooCreated(ctx context.Context, foo *model.Foo) er
 
 func (p *FooPublisher) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
 	tracer := otel.Tracer("FooPublisher")
-	_, span := tracer.Start(ctx, "FooPublisher.PublishFooCreated")
+	ctx, span := tracer.Start(ctx, "FooPublisher.PublishFooUpdated")
 	defer span.End()
 
-	g, ctx := errgroup.WithContext(ctx)
+	span.SetAttributes(attribute.String("foo.id", foo.Id.String()))
 
-	for _, subscriber := range p.Subscribers {
-		g.Go(func() error {
-			return subscriber.PublishFooUpdated(ctx, foo)
-		})
-	}
-
-	if err := g.Wait(); err != nil {
+	err := p.publish(ctx, eventUpdated, func(ctx context.Context, subscriber messaging.IFooMessaging) error {
+		return subscriber.PublishFooUpdated(ctx, foo)
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish foo updated")
 		return fmt.Errorf("failed to publish foo updated: %w", err)
@@ -80,18 +129,15 @@ func (p *FooPublisher) PublishFooUpdated(ctx context.Context, foo *model.Foo) er
 
 func (p *FooPublisher) PublishFooDeleted(ctx context.Context, id uuid.UUID) error {
 	tracer := otel.Tracer("FooPublisher")
-	_, span := tracer.Start(ctx, "FooPublisher.PublishFooCreated")
+	ctx, span := tracer.Start(ctx, "FooPublisher.PublishFooDeleted")
 	defer span.End()
 
-	g, ctx := errgroup.WithContext(ctx)
+	span.SetAttributes(attribute.String("foo.id", id.String()))
 
-	for _, subscriber := range p.Subscribers {
-		g.Go(func() error {
-			return subscriber.PublishFooDeleted(ctx, id)
-		})
-	}
-
-	if err := g.Wait(); err != nil {
+	err := p.publish(ctx, eventDeleted, func(ctx context.Context, subscriber messaging.IFooMessaging) error {
+		return subscriber.PublishFooDeleted(ctx, id)
+	})
+	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "failed to publish foo deleted")
 		return fmt.Errorf("failed to publish foo deleted: %w", err)
@@ -101,6 +147,41 @@ func (p *FooPublisher) PublishFooDeleted(ctx context.Context, id uuid.UUID) erro
 	return nil
 }
 
-func NewFooPublisher() *FooPublisher {
-	return &FooPublisher{Subscribers: make([]messaging.IFooMessaging, 0)}
+// publish sends an event to every sink and waits for all of them, so that a failing required sink does not cancel the
+// others. It returns the first failure of a required sink.
+func (p *FooPublisher) publish(ctx context.Context, event string, send func(context.Context, messaging.IFooMessaging) error) error {
+	var g errgroup.Group
+	for _, sink := range p.Subscribers {
+		g.Go(func() error {
+			sinkCtx := ctx
+			if sink.Timeout > 0 {
+				var cancel context.CancelFunc
+				sinkCtx, cancel = context.WithTimeout(ctx, sink.Timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			err := send(sinkCtx, sink.Messaging)
+			observe(sink.Name, event, err, start)
+			if err == nil {
+				return nil
+			}
+			if sink.Required {
+				return err
+			}
+
+			correlation.Logger(ctx, p.logger).Warn("fail to publish foo event to best-effort sink",
+				zap.String("sink", sink.Name),
+				zap.String("event", event),
+				zap.Error(err),
+			)
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+func NewFooPublisher(logger *zap.Logger) *FooPublisher {
+	return &FooPublisher{logger: logger, Subscribers: make([]Sink, 0)}
 }
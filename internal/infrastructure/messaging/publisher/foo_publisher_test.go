@@ -9,8 +9,10 @@ import (
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
 	messaging2 "github.com/TancelinMazzotti/astigo/mocks/domain/contract/messaging"
 	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
 )
 
 func TestFooPublisher_PublishFooCreated(t *testing.T) {
@@ -102,7 +104,7 @@ func TestFooPublisher_PublishFooCreated(t *testing.T) {
 			testCase.setupMockMessaging1(mockMessaging1)
 			testCase.setupMockMessaging2(mockMessaging2)
 
-			pub := NewFooPublisher()
+			pub := NewFooPublisher(zap.NewNop())
 			pub.Subscribe(mockMessaging1)
 			pub.Subscribe(mockMessaging2)
 
@@ -116,3 +118,93 @@ func TestFooPublisher_PublishFooCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestFooPublisher_SinkPolicy(t *testing.T) {
+	t.Parallel()
+	id := uuid.MustParse("20000000-0000-0000-0000-000000000001")
+
+	testCases := []struct {
+		name          string
+		sink          string
+		requiredErr   error
+		bestEffortErr error
+		expectedError error
+	}{
+		{
+			name: "Success Case",
+			sink: "policy-success",
+		},
+		{
+			name:          "Success Case - Best Effort Sink Error",
+			sink:          "policy-best-effort-error",
+			bestEffortErr: errors.New("webhook unavailable"),
+		},
+		{
+			name:          "Failure Case - Required Sink Error",
+			sink:          "policy-required-error",
+			requiredErr:   errors.New("nats unavailable"),
+			expectedError: errors.New("failed to publish foo deleted: nats unavailable"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			required := new(messaging2.MockFooMessaging)
+			required.On("PublishFooDeleted", mock.Anything, id).Return(testCase.requiredErr)
+			bestEffort := new(messaging2.MockFooMessaging)
+			bestEffort.On("PublishFooDeleted", mock.Anything, id).Return(testCase.bestEffortErr)
+
+			pub := NewFooPublisher(zap.NewNop())
+			pub.Subscribe(required, WithName(testCase.sink+"-required"))
+			pub.Subscribe(bestEffort, WithName(testCase.sink+"-best-effort"), BestEffort())
+
+			err := pub.PublishFooDeleted(context.Background(), id)
+
+			if testCase.expectedError != nil {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			// Every sink is published to, whatever the outcome of the others.
+			required.AssertExpectations(t)
+			bestEffort.AssertExpectations(t)
+
+			bestEffortResult := resultSuccess
+			if testCase.bestEffortErr != nil {
+				bestEffortResult = resultError
+			}
+			assert.Equal(t, float64(1), testutil.ToFloat64(Publications.WithLabelValues(testCase.sink+"-best-effort", eventDeleted, bestEffortResult)))
+		})
+	}
+}
+
+func TestFooPublisher_SinkTimeout(t *testing.T) {
+	t.Parallel()
+	foo := &model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001"), Label: "Foo1"}
+
+	slow := new(messaging2.MockFooMessaging)
+	slow.On("PublishFooUpdated", mock.Anything, foo).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.DeadlineExceeded)
+
+	pub := NewFooPublisher(zap.NewNop())
+	pub.Subscribe(slow, WithName("timeout"), WithTimeout(10*time.Millisecond))
+
+	err := pub.PublishFooUpdated(context.Background(), foo)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFooPublisher_Unsubscribe(t *testing.T) {
+	t.Parallel()
+	kept := new(messaging2.MockFooMessaging)
+	removed := new(messaging2.MockFooMessaging)
+
+	pub := NewFooPublisher(zap.NewNop())
+	pub.Subscribe(kept)
+	pub.Subscribe(removed, BestEffort())
+	pub.Unsubscribe(removed)
+
+	assert.Len(t, pub.Subscribers, 1)
+	assert.Equal(t, Sink{Name: "sink-0", Messaging: kept, Required: true}, pub.Subscribers[0])
+}
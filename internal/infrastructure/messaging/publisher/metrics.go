@@ -0,0 +1,45 @@
+package publisher
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	Publications = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "publisher_publications_total",
+			Help: "Total number of Foo events published, by sink, event and result (success, error)",
+		},
+		[]string{"sink", "event", "result"},
+	)
+
+	PublicationDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "publisher_publication_duration_seconds",
+			Help:    "Duration of the publication of the Foo events in seconds, by sink and result",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"sink", "result"},
+	)
+)
+
+func RegisterMetrics() {
+	prometheus.MustRegister(Publications)
+	prometheus.MustRegister(PublicationDuration)
+}
+
+func observe(sink, event string, err error, start time.Time) {
+	result := resultSuccess
+	if err != nil {
+		result = resultError
+	}
+	Publications.WithLabelValues(sink, event, result).Inc()
+	PublicationDuration.WithLabelValues(sink, result).Observe(time.Since(start).Seconds())
+}
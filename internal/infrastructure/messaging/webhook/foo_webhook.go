@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/messaging/nats/message"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+
+	"github.com/google/uuid"
+)
+
+var _ messaging.IFooMessaging = (*FooWebhook)(nil)
+
+// FooWebhook enqueues the Foo events for delivery to the webhook subscriptions as they are published, instead of
+// consuming them from the broker. Deliveries carry the same CloudEvent, with the same id, as the broker messages.
+type FooWebhook struct {
+	service service.IWebhookService
+	encoder *cloudevents.Encoder
+}
+
+func (w *FooWebhook) PublishFooCreated(ctx context.Context, foo *model.Foo) error {
+	event, err := message.NewFooCreatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	return w.enqueue(ctx, event)
+}
+
+func (w *FooWebhook) PublishFooUpdated(ctx context.Context, foo *model.Foo) error {
+	event, err := message.NewFooUpdatedEvent(foo)
+	if err != nil {
+		return err
+	}
+	return w.enqueue(ctx, event)
+}
+
+func (w *FooWebhook) PublishFooDeleted(ctx context.Context, id uuid.UUID) error {
+	event, err := message.NewFooDeletedEvent(id, time.Now())
+	if err != nil {
+		return err
+	}
+	return w.enqueue(ctx, event)
+}
+
+func (w *FooWebhook) enqueue(ctx context.Context, event cloudevents.Event) error {
+	payload, err := w.encoder.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if err := w.service.Enqueue(ctx, data.WebhookEventInput{
+		Id:      event.ID,
+		Type:    event.Type,
+		Payload: payload,
+	}); err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+	return nil
+}
+
+// NewFooWebhook creates a FooWebhook enqueuing the events through the webhook service.
+func NewFooWebhook(service service.IWebhookService, encoder *cloudevents.Encoder) *FooWebhook {
+	return &FooWebhook{service: service, encoder: encoder}
+}
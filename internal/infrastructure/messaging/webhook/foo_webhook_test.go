@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/tool/cloudevents"
+	"github.com/TancelinMazzotti/astigo/mocks/domain/contract/service"
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestFooWebhook_PublishFooCreated(t *testing.T) {
+	t.Parallel()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	foo := &model.Foo{Id: uuid.MustParse("20000000-0000-0000-0000-000000000001"), Label: "Foo1", Value: 1, CreatedAt: createdAt}
+	eventID := "foo.created:20000000-0000-0000-0000-000000000001:1735787045000000000"
+
+	testCases := []struct {
+		name          string
+		expectedError error
+
+		setupMockService func(*service.MockWebhookService)
+	}{
+		{
+			name: "Success Case",
+			setupMockService: func(mockService *service.MockWebhookService) {
+				mockService.On("Enqueue", mock.Anything, mock.MatchedBy(func(input data.WebhookEventInput) bool {
+					var event cloudevents.Event
+					if err := json.Unmarshal(input.Payload, &event); err != nil {
+						return false
+					}
+					return input.Id == eventID && input.Type == events.FooCreatedV1.Type &&
+						event.ID == eventID && event.Source == "/astigo" && event.SpecVersion == cloudevents.SpecVersion
+				})).Return(nil)
+			},
+		},
+		{
+			name:          "Failure Case - Enqueue Error",
+			expectedError: errors.New("failed to enqueue webhook deliveries: repository error"),
+			setupMockService: func(mockService *service.MockWebhookService) {
+				mockService.On("Enqueue", mock.Anything, mock.Anything).Return(errors.New("repository error"))
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			mockService := new(service.MockWebhookService)
+			testCase.setupMockService(mockService)
+
+			// The deliveries carry a structured CloudEvent whatever the mode of the broker messages.
+			encoder, err := cloudevents.NewEncoder(cloudevents.Config{Mode: cloudevents.ModeBinary, Source: "/astigo"})
+			assert.NoError(t, err)
+
+			err = NewFooWebhook(mockService, encoder).PublishFooCreated(context.Background(), foo)
+
+			if testCase.expectedError != nil {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			mockService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+)
+
+const sweepInterval = time.Minute
+
+var _ ratelimit.IRateLimiter = (*RateLimiterMemory)(nil)
+
+// RateLimiterMemory implements the GCRA algorithm in process memory.
+// Limits are only enforced per instance, which makes it suitable as a fallback when the shared backend is unavailable.
+type RateLimiterMemory struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func (r *RateLimiterMemory) Allow(_ context.Context, key string, limit model.RateLimit) (*model.RateLimitResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	r.sweep(now)
+
+	emission := limit.EmissionInterval()
+	tolerance := emission * time.Duration(limit.Capacity())
+
+	tat := r.tats[key]
+	if tat.Before(now) {
+		tat = now
+	}
+	newTat := tat.Add(emission)
+	diff := now.Sub(newTat.Add(-tolerance))
+
+	if diff < 0 {
+		return &model.RateLimitResult{
+			Allowed:    false,
+			Limit:      limit.Limit,
+			Remaining:  0,
+			RetryAfter: -diff,
+			ResetAfter: tat.Sub(now),
+		}, nil
+	}
+
+	r.tats[key] = newTat
+
+	return &model.RateLimitResult{
+		Allowed:    true,
+		Limit:      limit.Limit,
+		Remaining:  int(diff / emission),
+		ResetAfter: newTat.Sub(now),
+	}, nil
+}
+
+// sweep drops the keys whose theoretical arrival time is in the past, as they hold no state anymore.
+func (r *RateLimiterMemory) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < sweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, tat := range r.tats {
+		if !tat.After(now) {
+			delete(r.tats, key)
+		}
+	}
+}
+
+func NewRateLimiterMemory() *RateLimiterMemory {
+	return &RateLimiterMemory{
+		tats: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterMemory_Allow(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name               string
+		limit              model.RateLimit
+		requests           int
+		elapsed            time.Duration
+		expectedAllowed    bool
+		expectedRemaining  int
+		expectedRetryAfter time.Duration
+	}{
+		{
+			name:              "Success Case - First request",
+			limit:             model.RateLimit{Limit: 10, Period: time.Minute},
+			requests:          0,
+			expectedAllowed:   true,
+			expectedRemaining: 9,
+		},
+		{
+			name:              "Success Case - Last request of the limit",
+			limit:             model.RateLimit{Limit: 10, Period: time.Minute},
+			requests:          9,
+			expectedAllowed:   true,
+			expectedRemaining: 0,
+		},
+		{
+			name:               "Failure Case - Limit exceeded",
+			limit:              model.RateLimit{Limit: 10, Period: time.Minute},
+			requests:           10,
+			expectedAllowed:    false,
+			expectedRemaining:  0,
+			expectedRetryAfter: 6 * time.Second,
+		},
+		{
+			name:               "Failure Case - Burst exceeded",
+			limit:              model.RateLimit{Limit: 10, Period: time.Minute, Burst: 2},
+			requests:           2,
+			expectedAllowed:    false,
+			expectedRemaining:  0,
+			expectedRetryAfter: 6 * time.Second,
+		},
+		{
+			name:              "Success Case - Replenished",
+			limit:             model.RateLimit{Limit: 10, Period: time.Minute},
+			requests:          10,
+			elapsed:           12 * time.Second,
+			expectedAllowed:   true,
+			expectedRemaining: 1,
+		},
+		{
+			name:              "Success Case - Limit higher than the nanoseconds of the period",
+			limit:             model.RateLimit{Limit: 2_000_000_000, Period: time.Second},
+			requests:          1,
+			expectedAllowed:   true,
+			expectedRemaining: 1_999_999_998,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			limiter := NewRateLimiterMemory()
+			limiter.now = func() time.Time { return now }
+
+			for i := 0; i < testCase.requests; i++ {
+				_, err := limiter.Allow(context.Background(), "key", testCase.limit)
+				assert.NoError(t, err)
+			}
+			now = now.Add(testCase.elapsed)
+
+			result, err := limiter.Allow(context.Background(), "key", testCase.limit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedAllowed, result.Allowed)
+			assert.Equal(t, testCase.expectedRemaining, result.Remaining)
+			assert.Equal(t, testCase.expectedRetryAfter, result.RetryAfter)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+
+	"go.uber.org/zap"
+)
+
+var _ ratelimit.IRateLimiter = (*RateLimiterFallback)(nil)
+
+// RateLimiterFallback delegates to a primary limiter and switches to a secondary one, usually in memory,
+// when the primary fails. Requests keep being limited per instance instead of being rejected or let through unchecked.
+type RateLimiterFallback struct {
+	logger   *zap.Logger
+	primary  ratelimit.IRateLimiter
+	fallback ratelimit.IRateLimiter
+}
+
+func (r *RateLimiterFallback) Allow(ctx context.Context, key string, limit model.RateLimit) (*model.RateLimitResult, error) {
+	result, err := r.primary.Allow(ctx, key, limit)
+	if err == nil {
+		return result, nil
+	}
+
+	r.logger.Warn("rate limiter unavailable, using fallback", zap.Error(err))
+	return r.fallback.Allow(ctx, key, limit)
+}
+
+func NewRateLimiterFallback(logger *zap.Logger, primary ratelimit.IRateLimiter, fallback ratelimit.IRateLimiter) *RateLimiterFallback {
+	return &RateLimiterFallback{
+		logger:   logger,
+		primary:  primary,
+		fallback: fallback,
+	}
+}
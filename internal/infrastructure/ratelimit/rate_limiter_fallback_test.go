@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type stubRateLimiter struct {
+	result *model.RateLimitResult
+	err    error
+}
+
+func (s stubRateLimiter) Allow(context.Context, string, model.RateLimit) (*model.RateLimitResult, error) {
+	return s.result, s.err
+}
+
+func TestRateLimiterFallback_Allow(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name           string
+		primary        stubRateLimiter
+		fallback       stubRateLimiter
+		expectedResult *model.RateLimitResult
+		expectedError  error
+	}{
+		{
+			name:           "Success Case - Primary",
+			primary:        stubRateLimiter{result: &model.RateLimitResult{Allowed: true, Remaining: 9}},
+			fallback:       stubRateLimiter{result: &model.RateLimitResult{Allowed: true, Remaining: 1}},
+			expectedResult: &model.RateLimitResult{Allowed: true, Remaining: 9},
+		},
+		{
+			name:           "Success Case - Fallback",
+			primary:        stubRateLimiter{err: errors.New("connection refused")},
+			fallback:       stubRateLimiter{result: &model.RateLimitResult{Allowed: false, RetryAfter: time.Second}},
+			expectedResult: &model.RateLimitResult{Allowed: false, RetryAfter: time.Second},
+		},
+		{
+			name:          "Failure Case - Both unavailable",
+			primary:       stubRateLimiter{err: errors.New("connection refused")},
+			fallback:      stubRateLimiter{err: errors.New("fallback error")},
+			expectedError: errors.New("fallback error"),
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			limiter := NewRateLimiterFallback(zap.NewNop(), testCase.primary, testCase.fallback)
+
+			result, err := limiter.Allow(context.Background(), "key", model.RateLimit{Limit: 10, Period: time.Minute})
+
+			if testCase.expectedError != nil {
+				assert.EqualError(t, err, testCase.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedResult, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/ratelimit"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const keyPrefix = "ratelimit:"
+
+// gcraScript implements the GCRA algorithm atomically. Durations are expressed in microseconds
+// and the clock of the Redis server is used so that every instance shares the same time source.
+var gcraScript = redis.NewScript(`
+local emission = tonumber(ARGV[1])
+local tolerance = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000000 + tonumber(time[2])
+
+local tat = tonumber(redis.call('GET', KEYS[1]))
+if not tat or tat < now then
+  tat = now
+end
+
+local new_tat = tat + emission
+local diff = now - (new_tat - tolerance)
+if diff < 0 then
+  return {0, 0, -diff, tat - now}
+end
+
+redis.call('SET', KEYS[1], new_tat, 'PX', math.ceil((new_tat - now) / 1000))
+return {1, math.floor(diff / emission), 0, new_tat - now}
+`)
+
+var _ ratelimit.IRateLimiter = (*RateLimiterRedis)(nil)
+
+// RateLimiterRedis implements the GCRA algorithm on Redis so that limits are shared by every instance.
+type RateLimiterRedis struct {
+	db redis.Cmdable
+}
+
+func (r *RateLimiterRedis) Allow(ctx context.Context, key string, limit model.RateLimit) (*model.RateLimitResult, error) {
+	tracer := otel.Tracer("RateLimiterRedis")
+	ctx, span := tracer.Start(ctx, "RateLimiterRedis.Allow")
+	defer span.End()
+
+	// The script counts in microseconds: a finer emission interval is rounded up rather than down to zero.
+	emission := max(limit.EmissionInterval(), time.Microsecond)
+	tolerance := emission * time.Duration(limit.Capacity())
+	span.SetAttributes(
+		attribute.String("redis.key", keyPrefix+key),
+		attribute.Int("ratelimit.limit", limit.Limit),
+		attribute.Int("ratelimit.capacity", limit.Capacity()),
+	)
+
+	values, err := gcraScript.Run(ctx, r.db, []string{keyPrefix + key}, emission.Microseconds(), tolerance.Microseconds()).Int64Slice()
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to run rate limit script")
+		return nil, fmt.Errorf("fail to run rate limit script: %w", err)
+	}
+	if len(values) != 4 {
+		err := fmt.Errorf("unexpected rate limit script result: %v", values)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "unexpected rate limit script result")
+		return nil, err
+	}
+
+	result := &model.RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      limit.Limit,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Microsecond,
+		ResetAfter: time.Duration(values[3]) * time.Microsecond,
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(
+		attribute.Bool("ratelimit.allowed", result.Allowed),
+		attribute.Int("ratelimit.remaining", result.Remaining),
+	)
+	return result, nil
+}
+
+func NewRateLimiterRedis(db redis.Cmdable) *RateLimiterRedis {
+	return &RateLimiterRedis{
+		db: db,
+	}
+}
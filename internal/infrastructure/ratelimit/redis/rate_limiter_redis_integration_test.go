@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+func TestIntegrationRateLimiterRedis_Allow(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	container, err := tcredis.Run(ctx, "redis:6.2.6-alpine")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = container.Terminate(context.Background()) })
+
+	uri, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := redis.ParseURL(uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limiter := NewRateLimiterRedis(redis.NewClient(options))
+	limit := model.RateLimit{Limit: 3, Period: time.Minute}
+
+	for i := 2; i >= 0; i-- {
+		result, err := limiter.Allow(ctx, "GET /foos|ip:127.0.0.1", limit)
+		assert.NoError(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, i, result.Remaining)
+		assert.Equal(t, 3, result.Limit)
+	}
+
+	result, err := limiter.Allow(ctx, "GET /foos|ip:127.0.0.1", limit)
+	assert.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.InDelta(t, 20*time.Second, result.RetryAfter, float64(time.Second))
+
+	result, err = limiter.Allow(ctx, "GET /foos|ip:127.0.0.2", limit)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// A limit finer than the microsecond resolution of the script still has an emission interval.
+	result, err = limiter.Allow(ctx, "GET /foos|ip:127.0.0.3", model.RateLimit{Limit: 2_000_000_000, Period: time.Second})
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.IFileRepository = (*FileMemory)(nil)
+)
+
+// FileMemory is an implementation of the IFileRepository interface keeping the files in process memory.
+// Files are listed by creation time, and stored and returned as copies.
+type FileMemory struct {
+	mu    sync.RWMutex
+	files map[uuid.UUID]model.File
+	now   func() time.Time
+}
+
+// FindAll retrieves a list of files ordered by creation time, based on the provided pagination input.
+func (f *FileMemory) FindAll(_ context.Context, pagination data.PaginationOffset) ([]*model.File, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	files := make([]*model.File, 0, len(f.files))
+	for _, file := range f.files {
+		files = append(files, &file)
+	}
+	slices.SortFunc(files, compareFiles)
+
+	return paginate(files, pagination.Offset, pagination.Limit), nil
+}
+
+// FindIncompleteBefore retrieves up to limit pending or failed files created before the given time, the oldest first.
+func (f *FileMemory) FindIncompleteBefore(_ context.Context, before time.Time, limit int) ([]*model.File, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var files []*model.File
+	for _, file := range f.files {
+		if file.Status != model.UploadStatusComplete && file.CreatedAt.Before(before) {
+			files = append(files, &file)
+		}
+	}
+	slices.SortFunc(files, compareFiles)
+
+	return paginate(files, 0, limit), nil
+}
+
+// FindByID retrieves a file by its unique identifier.
+func (f *FileMemory) FindByID(_ context.Context, id uuid.UUID) (*model.File, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	file, ok := f.files[id]
+	if !ok {
+		return nil, port.NewErrNotFound("file", "id", id.String())
+	}
+	return &file, nil
+}
+
+// Create stores a new file, created now unless its creation time is set.
+func (f *FileMemory) Create(_ context.Context, file *model.File) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.files[file.Id]; ok {
+		return fmt.Errorf("error inserting file: duplicate id %s", file.Id)
+	}
+
+	if file.CreatedAt.IsZero() {
+		file.CreatedAt = f.now()
+	}
+	f.files[file.Id] = *file
+	return nil
+}
+
+// Update replaces an existing pending file and sets its update time.
+func (f *FileMemory) Update(_ context.Context, file *model.File) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	current, ok := f.files[file.Id]
+	if !ok {
+		return port.NewErrNotFound("file", "id", file.Id.String())
+	}
+	if current.Status != model.UploadStatusPending {
+		return port.NewErrNoAffectedData("file", file.Id.String())
+	}
+
+	file.UpdatedAt = f.now()
+	f.files[file.Id] = *file
+	return nil
+}
+
+// DeleteByID removes a file by its unique identifier.
+func (f *FileMemory) DeleteByID(_ context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.files[id]; !ok {
+		return port.NewErrNotFound("file", "id", id.String())
+	}
+	delete(f.files, id)
+	return nil
+}
+
+// compareFiles orders the files by creation time, then by id.
+func compareFiles(a, b *model.File) int {
+	if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.Id[:], b.Id[:])
+}
+
+func NewFileMemory() *FileMemory {
+	return &FileMemory{files: make(map[uuid.UUID]model.File), now: time.Now}
+}
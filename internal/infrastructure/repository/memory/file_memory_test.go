@@ -0,0 +1,15 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository/repositorytest"
+)
+
+func TestFileMemory_Contract(t *testing.T) {
+	t.Parallel()
+	repositorytest.RunFileRepositorySuite(t, func(t *testing.T) repository.IFileRepository {
+		return NewFileMemory()
+	})
+}
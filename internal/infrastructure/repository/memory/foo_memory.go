@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.IFooRepository = (*FooMemory)(nil)
+)
+
+// FooMemory is an implementation of the IFooRepository interface keeping the Foo entities in process memory.
+// It behaves like FooPostgres: entities are listed by id, and stored and returned as copies.
+type FooMemory struct {
+	mu   sync.RWMutex
+	foos map[uuid.UUID]model.Foo
+	now  func() time.Time
+}
+
+// FindAll retrieves a list of Foo entities ordered by id, based on the provided pagination input (limit and offset).
+func (f *FooMemory) FindAll(_ context.Context, input data.FooReadListInput) ([]*model.Foo, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	foos := make([]*model.Foo, 0, len(f.foos))
+	for _, foo := range f.foos {
+		foos = append(foos, &foo)
+	}
+	slices.SortFunc(foos, func(a, b *model.Foo) int {
+		return bytes.Compare(a.Id[:], b.Id[:])
+	})
+
+	return paginate(foos, input.Offset, input.Limit), nil
+}
+
+// FindByID retrieves a Foo entity by its unique identifier.
+func (f *FooMemory) FindByID(_ context.Context, id uuid.UUID) (*model.Foo, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	foo, ok := f.foos[id]
+	if !ok {
+		return nil, port.NewErrNotFound("foo", "id", id.String())
+	}
+	return &foo, nil
+}
+
+// Create stores a new Foo entity, created now.
+func (f *FooMemory) Create(_ context.Context, foo *model.Foo) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.foos[foo.Id]; ok {
+		return fmt.Errorf("error inserting foo: duplicate id %s", foo.Id)
+	}
+
+	foo.CreatedAt = f.now()
+	foo.UpdatedAt = nil
+	f.foos[foo.Id] = *foo
+	return nil
+}
+
+func (f *FooMemory) Update(_ context.Context, foo *model.Foo) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	stored, ok := f.foos[foo.Id]
+	if !ok {
+		return port.NewErrNotFound("foo", "id", foo.Id.String())
+	}
+
+	now := f.now()
+	stored.Label = foo.Label
+	stored.Secret = foo.Secret
+	stored.Value = foo.Value
+	stored.Weight = foo.Weight
+	stored.UpdatedAt = &now
+	f.foos[foo.Id] = stored
+
+	foo.UpdatedAt = &now
+	return nil
+}
+
+func (f *FooMemory) DeleteByID(_ context.Context, id uuid.UUID) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.foos[id]; !ok {
+		return port.NewErrNotFound("foo", "id", id.String())
+	}
+	delete(f.foos, id)
+	return nil
+}
+
+// paginate returns the page of items at offset, of at most limit items.
+func paginate[T any](items []T, offset, limit int) []T {
+	offset = max(offset, 0)
+	if offset >= len(items) || limit <= 0 {
+		return nil
+	}
+	return items[offset:min(offset+limit, len(items))]
+}
+
+func NewFooMemory() *FooMemory {
+	return &FooMemory{foos: make(map[uuid.UUID]model.Foo), now: time.Now}
+}
@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.IWebhookRepository = (*WebhookMemory)(nil)
+)
+
+// WebhookMemory is an implementation of the IWebhookRepository interface keeping the webhook subscriptions and their
+// deliveries in process memory. It behaves like WebhookPostgres, a single lock standing in for its transactions.
+type WebhookMemory struct {
+	mu            sync.Mutex
+	subscriptions map[uuid.UUID]model.WebhookSubscription
+	deliveries    map[uuid.UUID]model.WebhookDelivery
+	now           func() time.Time
+}
+
+// FindAll retrieves a list of webhook subscriptions ordered by creation time, based on the provided pagination input.
+func (w *WebhookMemory) FindAll(_ context.Context, pagination data.PaginationOffset) ([]*model.WebhookSubscription, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscriptions := w.filterSubscriptions(func(*model.WebhookSubscription) bool { return true })
+	return paginate(subscriptions, pagination.Offset, pagination.Limit), nil
+}
+
+// FindByID retrieves a webhook subscription by its unique identifier.
+func (w *WebhookMemory) FindByID(_ context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscription, ok := w.subscriptions[id]
+	if !ok {
+		return nil, port.NewErrNotFound("webhook", "id", id.String())
+	}
+	return copySubscription(subscription), nil
+}
+
+// FindByEventType retrieves the enabled webhook subscriptions receiving the events of a type.
+func (w *WebhookMemory) FindByEventType(_ context.Context, eventType string) ([]*model.WebhookSubscription, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.filterSubscriptions(func(subscription *model.WebhookSubscription) bool {
+		return subscription.Enabled && slices.Contains(subscription.EventTypes, eventType)
+	}), nil
+}
+
+// Create stores a new webhook subscription, created now.
+func (w *WebhookMemory) Create(_ context.Context, subscription *model.WebhookSubscription) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscription.CreatedAt = w.now()
+	w.subscriptions[subscription.Id] = *copySubscription(*subscription)
+	return nil
+}
+
+// Update replaces an existing webhook subscription and sets its update time.
+func (w *WebhookMemory) Update(_ context.Context, subscription *model.WebhookSubscription) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stored, ok := w.subscriptions[subscription.Id]
+	if !ok {
+		return port.NewErrNotFound("webhook", "id", subscription.Id.String())
+	}
+
+	now := w.now()
+	updated := *copySubscription(*subscription)
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = &now
+	w.subscriptions[subscription.Id] = updated
+
+	subscription.UpdatedAt = &now
+	return nil
+}
+
+// DeleteByID removes a webhook subscription along with its deliveries.
+func (w *WebhookMemory) DeleteByID(_ context.Context, id uuid.UUID) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.subscriptions[id]; !ok {
+		return port.NewErrNotFound("webhook", "id", id.String())
+	}
+
+	delete(w.subscriptions, id)
+	for deliveryId, delivery := range w.deliveries {
+		if delivery.SubscriptionId == id {
+			delete(w.deliveries, deliveryId)
+		}
+	}
+	return nil
+}
+
+// RecordSuccess resets the consecutive failures of a webhook subscription.
+func (w *WebhookMemory) RecordSuccess(_ context.Context, id uuid.UUID) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if subscription, ok := w.subscriptions[id]; ok {
+		subscription.ConsecutiveFailures = 0
+		w.subscriptions[id] = subscription
+	}
+	return nil
+}
+
+// RecordFailure counts a failure of a webhook subscription and disables it once its consecutive failures reach threshold.
+func (w *WebhookMemory) RecordFailure(_ context.Context, id uuid.UUID, threshold int) (bool, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	subscription, ok := w.subscriptions[id]
+	if !ok {
+		return false, port.NewErrNotFound("webhook", "id", id.String())
+	}
+
+	subscription.ConsecutiveFailures++
+	if subscription.Enabled && subscription.ConsecutiveFailures >= threshold {
+		now := w.now()
+		subscription.Enabled = false
+		subscription.DisabledAt = &now
+	}
+	w.subscriptions[id] = subscription
+
+	return !subscription.Enabled, nil
+}
+
+// CreateDeliveries stores deliveries, skipping the events already delivered to the same subscription.
+func (w *WebhookMemory) CreateDeliveries(_ context.Context, deliveries ...*model.WebhookDelivery) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, delivery := range deliveries {
+		if w.hasDelivery(delivery.SubscriptionId, delivery.EventId) {
+			continue
+		}
+
+		stored := *delivery
+		stored.Payload = bytes.Clone(delivery.Payload)
+		stored.CreatedAt = w.now()
+		w.deliveries[delivery.Id] = stored
+	}
+	return nil
+}
+
+// FindDeliveries retrieves the deliveries of a webhook subscription, most recent first.
+func (w *WebhookMemory) FindDeliveries(_ context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	deliveries := make([]*model.WebhookDelivery, 0)
+	for _, delivery := range w.deliveries {
+		if delivery.SubscriptionId == input.SubscriptionId {
+			deliveries = append(deliveries, &delivery)
+		}
+	}
+	slices.SortFunc(deliveries, func(a, b *model.WebhookDelivery) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Id[:], b.Id[:])
+	})
+
+	return paginate(deliveries, input.Offset, input.Limit), nil
+}
+
+// ClaimDeliveries leases the pending deliveries due at now by postponing their next attempt until the end of the lease.
+func (w *WebhookMemory) ClaimDeliveries(_ context.Context, now time.Time, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	due := make([]*model.WebhookDelivery, 0)
+	for _, delivery := range w.deliveries {
+		if delivery.Status == model.WebhookDeliveryPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, &delivery)
+		}
+	}
+	slices.SortFunc(due, func(a, b *model.WebhookDelivery) int {
+		return a.NextAttemptAt.Compare(b.NextAttemptAt)
+	})
+
+	claimed := paginate(due, 0, limit)
+	for _, delivery := range claimed {
+		delivery.NextAttemptAt = now.Add(lease)
+		w.deliveries[delivery.Id] = *delivery
+	}
+	return claimed, nil
+}
+
+// UpdateDelivery stores the outcome of the last attempt of a delivery.
+func (w *WebhookMemory) UpdateDelivery(_ context.Context, delivery *model.WebhookDelivery) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stored, ok := w.deliveries[delivery.Id]
+	if !ok {
+		return port.NewErrNotFound("webhook delivery", "id", delivery.Id.String())
+	}
+
+	now := w.now()
+	stored.Status = delivery.Status
+	stored.Attempts = delivery.Attempts
+	stored.StatusCode = delivery.StatusCode
+	stored.Error = delivery.Error
+	stored.Duration = delivery.Duration.Truncate(time.Millisecond)
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.UpdatedAt = &now
+	w.deliveries[delivery.Id] = stored
+
+	delivery.UpdatedAt = &now
+	return nil
+}
+
+func (w *WebhookMemory) filterSubscriptions(keep func(*model.WebhookSubscription) bool) []*model.WebhookSubscription {
+	subscriptions := make([]*model.WebhookSubscription, 0)
+	for _, subscription := range w.subscriptions {
+		if keep(&subscription) {
+			subscriptions = append(subscriptions, copySubscription(subscription))
+		}
+	}
+	slices.SortFunc(subscriptions, func(a, b *model.WebhookSubscription) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return bytes.Compare(a.Id[:], b.Id[:])
+	})
+	return subscriptions
+}
+
+func (w *WebhookMemory) hasDelivery(subscriptionId uuid.UUID, eventId string) bool {
+	for _, delivery := range w.deliveries {
+		if delivery.SubscriptionId == subscriptionId && delivery.EventId == eventId {
+			return true
+		}
+	}
+	return false
+}
+
+// copySubscription copies a subscription along with its event types, so that callers never share them with the store.
+func copySubscription(subscription model.WebhookSubscription) *model.WebhookSubscription {
+	subscription.EventTypes = slices.Clone(subscription.EventTypes)
+	return &subscription
+}
+
+func NewWebhookMemory() *WebhookMemory {
+	return &WebhookMemory{
+		subscriptions: make(map[uuid.UUID]model.WebhookSubscription),
+		deliveries:    make(map[uuid.UUID]model.WebhookDelivery),
+		now:           time.Now,
+	}
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"database/sql"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// File represents a database entity of a file with nullable fields.
+type File struct {
+	FileId     sql.Null[uuid.UUID] `db:"file_id"`
+	Name       sql.NullString      `db:"name"`
+	Size       sql.NullInt64       `db:"size"`
+	Extension  sql.NullString      `db:"extension"`
+	MimeType   sql.NullString      `db:"mime_type"`
+	Path       sql.NullString      `db:"path"`
+	Status     sql.NullString      `db:"status"`
+	CreatedAt  sql.NullTime        `db:"created_at"`
+	UploadedAt sql.NullTime        `db:"uploaded_at"`
+	UpdatedAt  sql.NullTime        `db:"updated_at"`
+}
+
+// ToModel converts a database model of File into a domain-level model.File instance, with zero times for the null ones.
+func (f *File) ToModel() *model.File {
+	file := model.File{}
+	if f.FileId.Valid {
+		file.Id = f.FileId.V
+	}
+	if f.Name.Valid {
+		file.Name = f.Name.String
+	}
+	if f.Size.Valid {
+		file.Size = f.Size.Int64
+	}
+	if f.Extension.Valid {
+		file.Extension = f.Extension.String
+	}
+	if f.MimeType.Valid {
+		file.MimeType = f.MimeType.String
+	}
+	if f.Path.Valid {
+		file.Path = f.Path.String
+	}
+	if f.Status.Valid {
+		file.Status = model.UploadStatus(f.Status.String)
+	}
+	if f.CreatedAt.Valid {
+		file.CreatedAt = f.CreatedAt.Time
+	}
+	if f.UploadedAt.Valid {
+		file.UploadedAt = f.UploadedAt.Time
+	}
+	if f.UpdatedAt.Valid {
+		file.UpdatedAt = f.UpdatedAt.Time
+	}
+
+	return &file
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+
+	"github.com/google/uuid"
+)
+
+// WebhookSubscription represents a database entity of a webhook subscription with nullable fields.
+type WebhookSubscription struct {
+	SubscriptionId      sql.Null[uuid.UUID] `db:"subscription_id"`
+	Url                 sql.NullString      `db:"url"`
+	EventTypes          EventTypes          `db:"event_types"`
+	Secret              sql.NullString      `db:"secret"`
+	Enabled             sql.NullBool        `db:"enabled"`
+	ConsecutiveFailures sql.NullInt32       `db:"consecutive_failures"`
+	DisabledAt          sql.NullTime        `db:"disabled_at"`
+	CreatedAt           sql.NullTime        `db:"created_at"`
+	UpdatedAt           sql.NullTime        `db:"updated_at"`
+}
+
+// EventTypes scans the event types of a subscription, selected as a JSON array.
+type EventTypes []string
+
+// Scan implements the sql.Scanner interface.
+func (e *EventTypes) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*e = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, e)
+	case string:
+		return json.Unmarshal([]byte(v), e)
+	default:
+		return fmt.Errorf("unsupported event types %T", src)
+	}
+}
+
+// ToModel converts a database model of WebhookSubscription into a domain-level model.WebhookSubscription instance.
+func (w *WebhookSubscription) ToModel() *model.WebhookSubscription {
+	subscription := model.WebhookSubscription{EventTypes: w.EventTypes}
+	if w.SubscriptionId.Valid {
+		subscription.Id = w.SubscriptionId.V
+	}
+	if w.Url.Valid {
+		subscription.Url = w.Url.String
+	}
+	if w.Secret.Valid {
+		subscription.Secret = w.Secret.String
+	}
+	if w.Enabled.Valid {
+		subscription.Enabled = w.Enabled.Bool
+	}
+	if w.ConsecutiveFailures.Valid {
+		subscription.ConsecutiveFailures = int(w.ConsecutiveFailures.Int32)
+	}
+	if w.DisabledAt.Valid {
+		subscription.DisabledAt = &w.DisabledAt.Time
+	}
+	if w.CreatedAt.Valid {
+		subscription.CreatedAt = w.CreatedAt.Time
+	}
+	if w.UpdatedAt.Valid {
+		subscription.UpdatedAt = &w.UpdatedAt.Time
+	}
+
+	return &subscription
+}
+
+// WebhookDelivery represents a database entity of a webhook delivery with nullable fields.
+type WebhookDelivery struct {
+	DeliveryId     sql.Null[uuid.UUID] `db:"delivery_id"`
+	SubscriptionId sql.Null[uuid.UUID] `db:"subscription_id"`
+	EventId        sql.NullString      `db:"event_id"`
+	EventType      sql.NullString      `db:"event_type"`
+	Payload        []byte              `db:"payload"`
+	Status         sql.NullString      `db:"status"`
+	Attempts       sql.NullInt32       `db:"attempts"`
+	StatusCode     sql.NullInt32       `db:"status_code"`
+	Error          sql.NullString      `db:"error"`
+	DurationMs     sql.NullInt64       `db:"duration_ms"`
+	NextAttemptAt  sql.NullTime        `db:"next_attempt_at"`
+	CreatedAt      sql.NullTime        `db:"created_at"`
+	UpdatedAt      sql.NullTime        `db:"updated_at"`
+}
+
+// ToModel converts a database model of WebhookDelivery into a domain-level model.WebhookDelivery instance.
+func (w *WebhookDelivery) ToModel() *model.WebhookDelivery {
+	delivery := model.WebhookDelivery{Payload: w.Payload}
+	if w.DeliveryId.Valid {
+		delivery.Id = w.DeliveryId.V
+	}
+	if w.SubscriptionId.Valid {
+		delivery.SubscriptionId = w.SubscriptionId.V
+	}
+	if w.EventId.Valid {
+		delivery.EventId = w.EventId.String
+	}
+	if w.EventType.Valid {
+		delivery.EventType = w.EventType.String
+	}
+	if w.Status.Valid {
+		delivery.Status = model.WebhookDeliveryStatus(w.Status.String)
+	}
+	if w.Attempts.Valid {
+		delivery.Attempts = int(w.Attempts.Int32)
+	}
+	if w.StatusCode.Valid {
+		delivery.StatusCode = int(w.StatusCode.Int32)
+	}
+	if w.Error.Valid {
+		delivery.Error = w.Error.String
+	}
+	if w.DurationMs.Valid {
+		delivery.Duration = time.Duration(w.DurationMs.Int64) * time.Millisecond
+	}
+	if w.NextAttemptAt.Valid {
+		delivery.NextAttemptAt = w.NextAttemptAt.Time
+	}
+	if w.CreatedAt.Valid {
+		delivery.CreatedAt = w.CreatedAt.Time
+	}
+	if w.UpdatedAt.Valid {
+		delivery.UpdatedAt = &w.UpdatedAt.Time
+	}
+
+	return &delivery
+}
@@ -0,0 +1,312 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/repository/postgres/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.IFileRepository = (*FilePostgres)(nil)
+)
+
+const fileColumns = `
+            file.file_id,
+            file.name,
+            file.size,
+            file.extension,
+            file.mime_type,
+            file.path,
+            file.status,
+            file.created_at,
+            file.uploaded_at,
+            file.updated_at`
+
+// FilePostgres is a concrete implementation of the IFileRepository interface that interacts with a PostgreSQL database.
+type FilePostgres struct {
+	db *sql.DB
+}
+
+// FindAll retrieves a list of files ordered by creation time, based on the provided pagination input.
+func (f FilePostgres) FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.File, error) {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.FindAll")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("offset", pagination.Offset),
+		attribute.Int("limit", pagination.Limit),
+	)
+
+	query := `
+        SELECT` + fileColumns + `
+        FROM file
+        ORDER BY file.created_at, file.file_id
+        LIMIT $1 OFFSET $2`
+
+	rows, err := f.db.QueryContext(ctx, query, pagination.Limit, pagination.Offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error querying files")
+		return nil, fmt.Errorf("error querying files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "error scanning file row")
+			return nil, fmt.Errorf("error scanning file row: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error iterating file rows")
+		return nil, fmt.Errorf("error iterating file rows: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(files)))
+	return files, nil
+}
+
+// FindByID retrieves a file by its unique identifier from the database.
+func (f FilePostgres) FindByID(ctx context.Context, id uuid.UUID) (*model.File, error) {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.FindByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("file.id", id.String()))
+
+	query := `
+        SELECT` + fileColumns + `
+        FROM file
+        WHERE file.file_id = $1`
+
+	file, err := scanFile(f.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetStatus(codes.Error, "file not found")
+			return nil, port.NewErrNotFound("file", "id", id.String())
+		}
+		span.SetStatus(codes.Error, "error scanning file row")
+		return nil, fmt.Errorf("error scanning file row: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return file, nil
+}
+
+// FindIncompleteBefore retrieves up to limit pending or failed files created before the given time, the oldest first.
+func (f FilePostgres) FindIncompleteBefore(ctx context.Context, before time.Time, limit int) ([]*model.File, error) {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.FindIncompleteBefore")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("before", before.Format(time.RFC3339)),
+		attribute.Int("limit", limit),
+	)
+
+	query := `
+        SELECT` + fileColumns + `
+        FROM file
+        WHERE file.status <> $1 AND file.created_at < $2
+        ORDER BY file.created_at, file.file_id
+        LIMIT $3`
+
+	rows, err := f.db.QueryContext(ctx, query, model.UploadStatusComplete, before, limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error querying incomplete files")
+		return nil, fmt.Errorf("error querying incomplete files: %w", err)
+	}
+	defer rows.Close()
+
+	var files []*model.File
+	for rows.Next() {
+		file, err := scanFile(rows)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "error scanning file row")
+			return nil, fmt.Errorf("error scanning file row: %w", err)
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error iterating file rows")
+		return nil, fmt.Errorf("error iterating file rows: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(files)))
+	return files, nil
+}
+
+// Create inserts a new file into the database, created now unless its creation time is set.
+func (f FilePostgres) Create(ctx context.Context, file *model.File) error {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("file.id", file.Id.String()),
+		attribute.String("file.path", file.Path),
+	)
+
+	query := `
+    INSERT INTO file (file_id, name, size, extension, mime_type, path, status, created_at, uploaded_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE($8, now()), $9)
+    RETURNING created_at
+    `
+
+	if err := f.db.QueryRowContext(ctx, query,
+		file.Id, file.Name, file.Size, file.Extension, file.MimeType, file.Path, file.Status,
+		nullTime(file.CreatedAt), nullTime(file.UploadedAt),
+	).Scan(&file.CreatedAt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error inserting file")
+		return fmt.Errorf("error inserting file: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Update modifies an existing pending file in the database and sets its update time. The status is checked by the
+// update itself, so that a single one of concurrent updates succeeds.
+func (f FilePostgres) Update(ctx context.Context, file *model.File) error {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.Update")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("file.id", file.Id.String()),
+		attribute.String("file.status", string(file.Status)),
+	)
+
+	now := time.Now()
+	query := `
+    UPDATE file
+    SET name = $1,
+        size = $2,
+        extension = $3,
+        mime_type = $4,
+        path = $5,
+        status = $6,
+        uploaded_at = $7,
+        updated_at = $8
+    WHERE file_id = $9 AND status = $10
+    `
+
+	result, err := f.db.ExecContext(ctx, query,
+		file.Name, file.Size, file.Extension, file.MimeType, file.Path, file.Status,
+		nullTime(file.UploadedAt), now, file.Id, model.UploadStatusPending,
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error updating file")
+		return fmt.Errorf("error updating file: %w", err)
+	}
+
+	if affectedRow, err := result.RowsAffected(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error getting affected rows")
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affectedRow == 0 {
+		var exists bool
+		if err := f.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM file WHERE file_id = $1)`, file.Id).Scan(&exists); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "error checking file existence")
+			return fmt.Errorf("error checking file existence: %w", err)
+		}
+		if !exists {
+			span.SetStatus(codes.Error, "file not found")
+			return port.NewErrNotFound("file", "id", file.Id.String())
+		}
+		span.SetStatus(codes.Error, "file not pending")
+		return port.NewErrNoAffectedData("file", file.Id.String())
+	}
+
+	file.UpdatedAt = now
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteByID removes a file from the database.
+func (f FilePostgres) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("FilePostgres")
+	ctx, span := tracer.Start(ctx, "FilePostgres.DeleteByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("file.id", id.String()))
+
+	query := `DELETE FROM file WHERE file_id = $1`
+
+	result, err := f.db.ExecContext(ctx, query, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error deleting file")
+		return fmt.Errorf("error deleting file: %w", err)
+	}
+
+	if affectedRow, err := result.RowsAffected(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error getting affected rows")
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affectedRow == 0 {
+		span.SetStatus(codes.Error, "file not found")
+		return port.NewErrNotFound("file", "id", id.String())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func scanFile(row scanner) (*model.File, error) {
+	fileEntity := entity.File{}
+	if err := row.Scan(
+		&fileEntity.FileId,
+		&fileEntity.Name,
+		&fileEntity.Size,
+		&fileEntity.Extension,
+		&fileEntity.MimeType,
+		&fileEntity.Path,
+		&fileEntity.Status,
+		&fileEntity.CreatedAt,
+		&fileEntity.UploadedAt,
+		&fileEntity.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return fileEntity.ToModel(), nil
+}
+
+// nullTime stores a zero time as NULL.
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+func NewFilePostgres(db *sql.DB) *FilePostgres {
+	return &FilePostgres{db: db}
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository/repositorytest"
+)
+
+// TestIntegrationFilePostgres_Contract runs the IFileRepository conformance suite against Postgres, emptying the file
+// table before each test.
+func TestIntegrationFilePostgres_Contract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreatePostgresContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pg, err := NewPostgres(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repositorytest.RunFileRepositorySuite(t, func(t *testing.T) repository.IFileRepository {
+		if _, err := pg.ExecContext(ctx, `TRUNCATE file`); err != nil {
+			t.Fatal(err)
+		}
+		return NewFilePostgres(pg)
+	})
+}
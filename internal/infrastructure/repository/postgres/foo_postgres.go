@@ -159,23 +159,16 @@ func (f FooPostgres) Create(ctx context.Context, foo *model.Foo) error {
 	query := `
     INSERT INTO foo (foo_id,label, secret, value, weight)
     VALUES ($1, $2, $3, $4, $5)
+    RETURNING created_at
     `
 
-	result, err := f.db.ExecContext(ctx, query, foo.Id, foo.Label, foo.Secret, foo.Value, foo.Weight)
-	if err != nil {
+	if err := f.db.QueryRowContext(ctx, query, foo.Id, foo.Label, foo.Secret, foo.Value, foo.Weight).
+		Scan(&foo.CreatedAt); err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "error inserting foo")
 		return fmt.Errorf("error inserting foo: %w", err)
 	}
-
-	if affectedRow, err := result.RowsAffected(); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "error getting affected rows")
-		return fmt.Errorf("error getting affected rows: %w", err)
-	} else if affectedRow == 0 {
-		span.SetStatus(codes.Error, "no row affected")
-		return fmt.Errorf("no row affected")
-	}
+	foo.UpdatedAt = nil
 
 	span.SetStatus(codes.Ok, "")
 	return nil
@@ -216,8 +209,8 @@ func (f FooPostgres) Update(ctx context.Context, foo *model.Foo) error {
 		span.SetStatus(codes.Error, "error getting affected rows")
 		return fmt.Errorf("error getting affected rows: %w", err)
 	} else if affectedRow == 0 {
-		span.SetStatus(codes.Error, "no row affected")
-		return fmt.Errorf("no row affected")
+		span.SetStatus(codes.Error, "foo not found")
+		return port.NewErrNotFound("foo", "id", foo.Id.String())
 	}
 
 	foo.UpdatedAt = &now
@@ -252,8 +245,8 @@ func (f FooPostgres) DeleteByID(ctx context.Context, id uuid.UUID) error {
 		span.SetStatus(codes.Error, "error getting affected rows")
 		return fmt.Errorf("error getting affected rows: %w", err)
 	} else if affectedRow == 0 {
-		span.SetStatus(codes.Error, "no row affected")
-		return fmt.Errorf("no row affected")
+		span.SetStatus(codes.Error, "foo not found")
+		return port.NewErrNotFound("foo", "id", id.String())
 	}
 
 	span.SetStatus(codes.Ok, "")
@@ -2,7 +2,10 @@ package postgres
 
 import (
 	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
 	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository/repositorytest"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -255,7 +258,7 @@ func TestIntegrationFooPostgres_Update(t *testing.T) {
 				Value:  50,
 				Weight: 1.5,
 			},
-			expectedError: fmt.Errorf("no row affected"),
+			expectedError: port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"),
 		},
 	}
 
@@ -308,7 +311,7 @@ func TestIntegrationFooPostgres_DeleteByID(t *testing.T) {
 		{
 			name:          "Fail Case - Not exist",
 			id:            uuid.MustParse("40400000-0000-0000-0000-000000000000"),
-			expectedError: fmt.Errorf("no row affected"),
+			expectedError: port.NewErrNotFound("foo", "id", "40400000-0000-0000-0000-000000000000"),
 		},
 	}
 
@@ -341,3 +344,26 @@ func TestIntegrationFooPostgres_DeleteByID(t *testing.T) {
 		})
 	}
 }
+
+// TestIntegrationFooPostgres_Contract runs the IFooRepository conformance suite against Postgres, emptying the foo
+// table before each test.
+func TestIntegrationFooPostgres_Contract(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreatePostgresContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pg, err := NewPostgres(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repositorytest.RunFooRepositorySuite(t, func(t *testing.T) repository.IFooRepository {
+		if _, err := pg.ExecContext(ctx, `TRUNCATE bar, foo`); err != nil {
+			t.Fatal(err)
+		}
+		return NewFooPostgres(pg)
+	})
+}
@@ -0,0 +1,539 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/TancelinMazzotti/astigo/internal/infrastructure/repository/postgres/entity"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ repository.IWebhookRepository = (*WebhookPostgres)(nil)
+)
+
+const (
+	webhookSubscriptionColumns = `
+            webhook_subscription.subscription_id,
+            webhook_subscription.url,
+            array_to_json(webhook_subscription.event_types),
+            webhook_subscription.secret,
+            webhook_subscription.enabled,
+            webhook_subscription.consecutive_failures,
+            webhook_subscription.disabled_at,
+            webhook_subscription.created_at,
+            webhook_subscription.updated_at`
+
+	webhookDeliveryColumns = `
+            webhook_delivery.delivery_id,
+            webhook_delivery.subscription_id,
+            webhook_delivery.event_id,
+            webhook_delivery.event_type,
+            webhook_delivery.payload,
+            webhook_delivery.status,
+            webhook_delivery.attempts,
+            webhook_delivery.status_code,
+            webhook_delivery.error,
+            webhook_delivery.duration_ms,
+            webhook_delivery.next_attempt_at,
+            webhook_delivery.created_at,
+            webhook_delivery.updated_at`
+)
+
+// scanner is implemented by both sql.Row and sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// WebhookPostgres is a concrete implementation of the IWebhookRepository interface that interacts with a PostgreSQL database.
+type WebhookPostgres struct {
+	db *sql.DB
+}
+
+// FindAll retrieves a list of webhook subscriptions from the database based on the provided pagination input.
+func (w WebhookPostgres) FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.FindAll")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("offset", pagination.Offset),
+		attribute.Int("limit", pagination.Limit),
+	)
+
+	query := `
+        SELECT` + webhookSubscriptionColumns + `
+        FROM webhook_subscription
+        ORDER BY webhook_subscription.created_at, webhook_subscription.subscription_id
+        LIMIT $1 OFFSET $2`
+
+	subscriptions, err := w.querySubscriptions(ctx, query, pagination.Limit, pagination.Offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error querying webhooks")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(subscriptions)))
+	return subscriptions, nil
+}
+
+// FindByID retrieves a webhook subscription by its unique identifier from the database.
+func (w WebhookPostgres) FindByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.FindByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	query := `
+        SELECT` + webhookSubscriptionColumns + `
+        FROM webhook_subscription
+        WHERE webhook_subscription.subscription_id = $1`
+
+	subscription, err := w.scanSubscription(w.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetStatus(codes.Error, "webhook not found")
+			return nil, port.NewErrNotFound("webhook", "id", id.String())
+		}
+		span.SetStatus(codes.Error, "error scanning webhook row")
+		return nil, fmt.Errorf("error scanning webhook row: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return subscription, nil
+}
+
+// FindByEventType retrieves the enabled webhook subscriptions receiving the events of the given type.
+func (w WebhookPostgres) FindByEventType(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.FindByEventType")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event.type", eventType))
+
+	query := `
+        SELECT` + webhookSubscriptionColumns + `
+        FROM webhook_subscription
+        WHERE webhook_subscription.enabled
+          AND webhook_subscription.event_types @> ARRAY[$1]::text[]
+        ORDER BY webhook_subscription.subscription_id`
+
+	subscriptions, err := w.querySubscriptions(ctx, query, eventType)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error querying webhooks")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(subscriptions)))
+	return subscriptions, nil
+}
+
+// Create inserts a new webhook subscription into the database.
+func (w WebhookPostgres) Create(ctx context.Context, subscription *model.WebhookSubscription) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.Create")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", subscription.Id.String()),
+		attribute.String("webhook.url", subscription.Url),
+	)
+
+	query := `
+    INSERT INTO webhook_subscription (subscription_id, url, event_types, secret, enabled)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING created_at
+    `
+
+	if err := w.db.QueryRowContext(ctx, query,
+		subscription.Id, subscription.Url, subscription.EventTypes, subscription.Secret, subscription.Enabled,
+	).Scan(&subscription.CreatedAt); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error inserting webhook")
+		return fmt.Errorf("error inserting webhook: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// Update modifies an existing webhook subscription in the database.
+func (w WebhookPostgres) Update(ctx context.Context, subscription *model.WebhookSubscription) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.Update")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", subscription.Id.String()))
+
+	now := time.Now()
+	query := `
+    UPDATE webhook_subscription
+    SET url = $1,
+        event_types = $2,
+        secret = $3,
+        enabled = $4,
+        consecutive_failures = $5,
+        disabled_at = $6,
+        updated_at = $7
+    WHERE subscription_id = $8
+    `
+
+	result, err := w.db.ExecContext(ctx, query,
+		subscription.Url, subscription.EventTypes, subscription.Secret, subscription.Enabled,
+		subscription.ConsecutiveFailures, subscription.DisabledAt, now, subscription.Id,
+	)
+	if err := w.affected(result, err, "webhook", subscription.Id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error updating webhook")
+		return err
+	}
+
+	subscription.UpdatedAt = &now
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// DeleteByID removes a webhook subscription from the database, its deliveries being removed in cascade.
+func (w WebhookPostgres) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.DeleteByID")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	query := `DELETE FROM webhook_subscription WHERE subscription_id = $1`
+
+	result, err := w.db.ExecContext(ctx, query, id)
+	if err := w.affected(result, err, "webhook", id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error deleting webhook")
+		return err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RecordSuccess resets the consecutive failures of a webhook subscription.
+func (w WebhookPostgres) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.RecordSuccess")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("webhook.id", id.String()))
+
+	query := `
+    UPDATE webhook_subscription
+    SET consecutive_failures = 0
+    WHERE subscription_id = $1 AND consecutive_failures <> 0
+    `
+
+	if _, err := w.db.ExecContext(ctx, query, id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error resetting webhook failures")
+		return fmt.Errorf("error resetting webhook failures: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// RecordFailure counts a failure of a webhook subscription in a single statement, so that concurrent deliveries do not
+// lose any, and disables the subscription once its consecutive failures reach threshold.
+func (w WebhookPostgres) RecordFailure(ctx context.Context, id uuid.UUID, threshold int) (bool, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.RecordFailure")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", id.String()),
+		attribute.Int("webhook.failure_threshold", threshold),
+	)
+
+	query := `
+    UPDATE webhook_subscription
+    SET consecutive_failures = consecutive_failures + 1,
+        enabled = enabled AND consecutive_failures + 1 < $2,
+        disabled_at = CASE
+            WHEN enabled AND consecutive_failures + 1 >= $2 THEN now()
+            ELSE disabled_at
+        END
+    WHERE subscription_id = $1
+    RETURNING NOT enabled
+    `
+
+	var disabled bool
+	if err := w.db.QueryRowContext(ctx, query, id, threshold).Scan(&disabled); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, sql.ErrNoRows) {
+			span.SetStatus(codes.Error, "webhook not found")
+			return false, port.NewErrNotFound("webhook", "id", id.String())
+		}
+		span.SetStatus(codes.Error, "error counting webhook failure")
+		return false, fmt.Errorf("error counting webhook failure: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Bool("webhook.disabled", disabled))
+	return disabled, nil
+}
+
+// CreateDeliveries inserts deliveries in a single transaction, skipping the events already delivered to the same subscription.
+func (w WebhookPostgres) CreateDeliveries(ctx context.Context, deliveries ...*model.WebhookDelivery) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.CreateDeliveries")
+	defer span.End()
+
+	span.SetAttributes(attribute.Int("webhook.delivery.count", len(deliveries)))
+
+	tx, err := w.db.BeginTx(ctx, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error beginning transaction")
+		return fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+    INSERT INTO webhook_delivery (delivery_id, subscription_id, event_id, event_type, payload, status, next_attempt_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (subscription_id, event_id) DO NOTHING
+    `
+
+	for _, delivery := range deliveries {
+		if _, err := tx.ExecContext(ctx, query,
+			delivery.Id, delivery.SubscriptionId, delivery.EventId, delivery.EventType,
+			delivery.Payload, string(delivery.Status), delivery.NextAttemptAt,
+		); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "error inserting webhook delivery")
+			return fmt.Errorf("error inserting webhook delivery: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error committing transaction")
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+// FindDeliveries retrieves the deliveries of a webhook subscription, most recent first.
+func (w WebhookPostgres) FindDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.FindDeliveries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", input.SubscriptionId.String()),
+		attribute.Int("offset", input.Offset),
+		attribute.Int("limit", input.Limit),
+	)
+
+	query := `
+        SELECT` + webhookDeliveryColumns + `
+        FROM webhook_delivery
+        WHERE webhook_delivery.subscription_id = $1
+        ORDER BY webhook_delivery.created_at DESC, webhook_delivery.delivery_id
+        LIMIT $2 OFFSET $3`
+
+	deliveries, err := w.queryDeliveries(ctx, query, input.SubscriptionId, input.Limit, input.Offset)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error querying webhook deliveries")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(deliveries)))
+	return deliveries, nil
+}
+
+// ClaimDeliveries leases the pending deliveries due at now by postponing their next attempt until the end of the
+// lease. Rows locked by another replica claiming concurrently are skipped.
+func (w WebhookPostgres) ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.ClaimDeliveries")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.Int("limit", limit),
+		attribute.String("lease", lease.String()),
+	)
+
+	query := `
+        UPDATE webhook_delivery
+        SET next_attempt_at = $2
+        WHERE webhook_delivery.delivery_id IN (
+            SELECT due.delivery_id
+            FROM webhook_delivery AS due
+            WHERE due.status = 'pending' AND due.next_attempt_at <= $1
+            ORDER BY due.next_attempt_at
+            LIMIT $3
+            FOR UPDATE SKIP LOCKED
+        )
+        RETURNING` + webhookDeliveryColumns
+
+	deliveries, err := w.queryDeliveries(ctx, query, now, now.Add(lease), limit)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error claiming webhook deliveries")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "")
+	span.SetAttributes(attribute.Int("result.count", len(deliveries)))
+	return deliveries, nil
+}
+
+// UpdateDelivery stores the outcome of the last attempt of a delivery.
+func (w WebhookPostgres) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	tracer := otel.Tracer("WebhookPostgres")
+	ctx, span := tracer.Start(ctx, "WebhookPostgres.UpdateDelivery")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.delivery.id", delivery.Id.String()),
+		attribute.String("webhook.delivery.status", string(delivery.Status)),
+	)
+
+	now := time.Now()
+	query := `
+    UPDATE webhook_delivery
+    SET status = $1,
+        attempts = $2,
+        status_code = $3,
+        error = $4,
+        duration_ms = $5,
+        next_attempt_at = $6,
+        updated_at = $7
+    WHERE delivery_id = $8
+    `
+
+	result, err := w.db.ExecContext(ctx, query,
+		string(delivery.Status), delivery.Attempts, delivery.StatusCode, delivery.Error,
+		delivery.Duration.Milliseconds(), delivery.NextAttemptAt, now, delivery.Id,
+	)
+	if err := w.affected(result, err, "webhook delivery", delivery.Id); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "error updating webhook delivery")
+		return err
+	}
+
+	delivery.UpdatedAt = &now
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func (w WebhookPostgres) querySubscriptions(ctx context.Context, query string, args ...any) ([]*model.WebhookSubscription, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*model.WebhookSubscription, 0)
+	for rows.Next() {
+		subscription, err := w.scanSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook row: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook rows: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (w WebhookPostgres) scanSubscription(row scanner) (*model.WebhookSubscription, error) {
+	subscriptionEntity := entity.WebhookSubscription{}
+	if err := row.Scan(
+		&subscriptionEntity.SubscriptionId,
+		&subscriptionEntity.Url,
+		&subscriptionEntity.EventTypes,
+		&subscriptionEntity.Secret,
+		&subscriptionEntity.Enabled,
+		&subscriptionEntity.ConsecutiveFailures,
+		&subscriptionEntity.DisabledAt,
+		&subscriptionEntity.CreatedAt,
+		&subscriptionEntity.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return subscriptionEntity.ToModel(), nil
+}
+
+func (w WebhookPostgres) queryDeliveries(ctx context.Context, query string, args ...any) ([]*model.WebhookDelivery, error) {
+	rows, err := w.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*model.WebhookDelivery, 0)
+	for rows.Next() {
+		deliveryEntity := entity.WebhookDelivery{}
+		if err := rows.Scan(
+			&deliveryEntity.DeliveryId,
+			&deliveryEntity.SubscriptionId,
+			&deliveryEntity.EventId,
+			&deliveryEntity.EventType,
+			&deliveryEntity.Payload,
+			&deliveryEntity.Status,
+			&deliveryEntity.Attempts,
+			&deliveryEntity.StatusCode,
+			&deliveryEntity.Error,
+			&deliveryEntity.DurationMs,
+			&deliveryEntity.NextAttemptAt,
+			&deliveryEntity.CreatedAt,
+			&deliveryEntity.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, deliveryEntity.ToModel())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating webhook delivery rows: %w", err)
+	}
+	return deliveries, nil
+}
+
+// affected checks the result of a statement modifying the row of the resource identified by id.
+func (w WebhookPostgres) affected(result sql.Result, err error, resource string, id uuid.UUID) error {
+	if err != nil {
+		return fmt.Errorf("error executing statement: %w", err)
+	}
+	if affectedRow, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("error getting affected rows: %w", err)
+	} else if affectedRow == 0 {
+		return port.NewErrNotFound(resource, "id", id.String())
+	}
+	return nil
+}
+
+func NewWebhookPostgres(db *sql.DB) *WebhookPostgres {
+	return &WebhookPostgres{db: db}
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIntegrationWebhookPostgres covers the lifecycle of a webhook subscription and of its deliveries.
+func TestIntegrationWebhookPostgres(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	container, err := CreatePostgresContainer(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pg, err := NewPostgres(ctx, container.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := NewWebhookPostgres(pg)
+
+	subscription := &model.WebhookSubscription{
+		Id:         uuid.New(),
+		Url:        "https://partner.example.com/hooks",
+		EventTypes: []string{"com.astigo.foo.created", "com.astigo.foo.deleted"},
+		Secret:     "0123456789abcdef",
+		Enabled:    true,
+	}
+	assert.NoError(t, repo.Create(ctx, subscription))
+	assert.NotZero(t, subscription.CreatedAt)
+
+	found, err := repo.FindByID(ctx, subscription.Id)
+	assert.NoError(t, err)
+	assert.Equal(t, subscription.EventTypes, found.EventTypes)
+
+	matching, err := repo.FindByEventType(ctx, "com.astigo.foo.deleted")
+	assert.NoError(t, err)
+	assert.Len(t, matching, 1)
+	matching, err = repo.FindByEventType(ctx, "com.astigo.foo.updated")
+	assert.NoError(t, err)
+	assert.Empty(t, matching)
+
+	now := time.Now().Truncate(time.Millisecond)
+	delivery := &model.WebhookDelivery{
+		Id:             uuid.New(),
+		SubscriptionId: subscription.Id,
+		EventId:        "foo.created:1",
+		EventType:      "com.astigo.foo.created",
+		Payload:        []byte(`{"specversion":"1.0"}`),
+		Status:         model.WebhookDeliveryPending,
+		NextAttemptAt:  now,
+	}
+	duplicate := *delivery
+	duplicate.Id = uuid.New()
+	assert.NoError(t, repo.CreateDeliveries(ctx, delivery, &duplicate))
+
+	claimed, err := repo.ClaimDeliveries(ctx, now, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, delivery.Id, claimed[0].Id)
+	// Leased deliveries are not claimed again before the end of the lease.
+	claimed, err = repo.ClaimDeliveries(ctx, now, 10, time.Minute)
+	assert.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	delivery.Attempts = 1
+	delivery.StatusCode = 500
+	delivery.Error = "unexpected status code 500"
+	delivery.Duration = 150 * time.Millisecond
+	assert.NoError(t, repo.UpdateDelivery(ctx, delivery))
+
+	deliveries, err := repo.FindDeliveries(ctx, data.WebhookDeliveryListInput{SubscriptionId: subscription.Id, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+	assert.Equal(t, 500, deliveries[0].StatusCode)
+	assert.Equal(t, 150*time.Millisecond, deliveries[0].Duration)
+
+	disabled, err := repo.RecordFailure(ctx, subscription.Id, 2)
+	assert.NoError(t, err)
+	assert.False(t, disabled)
+	disabled, err = repo.RecordFailure(ctx, subscription.Id, 2)
+	assert.NoError(t, err)
+	assert.True(t, disabled)
+
+	found, err = repo.FindByID(ctx, subscription.Id)
+	assert.NoError(t, err)
+	assert.False(t, found.Enabled)
+	assert.NotNil(t, found.DisabledAt)
+	assert.Equal(t, 2, found.ConsecutiveFailures)
+
+	assert.NoError(t, repo.DeleteByID(ctx, subscription.Id))
+	_, err = repo.FindByID(ctx, subscription.Id)
+	assert.ErrorAs(t, err, &port.ErrorNotFound)
+	deliveries, err = repo.FindDeliveries(ctx, data.WebhookDeliveryListInput{SubscriptionId: subscription.Id, Limit: 10})
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+)
+
+const (
+	// maxObjectSize bounds the objects uploaded to a FileMemory.
+	maxObjectSize = 64 << 20
+
+	// urlExpiration is the time during which a presigned URL is accepted, like the default of the S3 client.
+	urlExpiration = 15 * time.Minute
+)
+
+var _ storage.IFileStorage = (*FileMemory)(nil)
+var _ http.Handler = (*FileMemory)(nil)
+
+type object struct {
+	data        []byte
+	contentType string
+	modifiedAt  time.Time
+}
+
+// FileMemory is an implementation of the IFileStorage interface keeping the objects in process memory. It serves the
+// URLs it presigns itself: mounted under baseURL, it accepts the uploads with PUT and the downloads with GET and HEAD.
+// Like S3, the URLs are signed with HMAC-SHA256, by a key drawn at creation, and expire after urlExpiration.
+type FileMemory struct {
+	mu      sync.RWMutex
+	baseURL string
+	key     []byte
+	objects map[string]object
+	now     func() time.Time
+}
+
+// PresignedDownloadURL returns the URL serving the object at path.
+func (f *FileMemory) PresignedDownloadURL(_ context.Context, path string) (string, http.Header, error) {
+	return f.url(http.MethodGet, path, url.Values{}), http.Header{}, nil
+}
+
+// PresignedUploadURL returns the URL accepting the upload of the object at key, of the given size, along with its
+// content type header.
+func (f *FileMemory) PresignedUploadURL(_ context.Context, key, contentType string, size int64) (string, http.Header, error) {
+	header := http.Header{}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return f.url(http.MethodPut, key, url.Values{"size": {strconv.FormatInt(size, 10)}}), header, nil
+}
+
+// Head describes the object at path, or returns a port.ErrNotFound when it does not exist.
+func (f *FileMemory) Head(_ context.Context, path string) (*model.FileObject, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	obj, ok := f.objects[path]
+	if !ok {
+		return nil, port.NewErrNotFound("file object", "path", path)
+	}
+	return &model.FileObject{Size: int64(len(obj.data)), ContentType: obj.contentType, ModifiedAt: obj.modifiedAt}, nil
+}
+
+// Delete removes the object at path. Like S3, deleting a missing object succeeds.
+func (f *FileMemory) Delete(_ context.Context, path string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.objects, path)
+	return nil
+}
+
+// ServeHTTP stores the body of PUT requests and serves the objects to GET and HEAD requests, the request path
+// being the key of the object. Like S3, a request whose URL is not signed for its method, or has expired, is forbidden,
+// and so is an upload whose body does not have the size of its URL.
+func (f *FileMemory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+
+	switch r.Method {
+	case http.MethodPut, http.MethodGet, http.MethodHead:
+		if err := f.verify(r, key); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		size, err := strconv.ParseInt(r.URL.Query().Get("size"), 10, 64)
+		if err != nil || size < 0 || size > maxObjectSize {
+			http.Error(w, "invalid upload size", http.StatusForbidden)
+			return
+		}
+		if r.ContentLength >= 0 && r.ContentLength != size {
+			http.Error(w, "content length does not match the upload size", http.StatusForbidden)
+			return
+		}
+
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, size))
+		if err != nil || int64(len(data)) != size {
+			http.Error(w, "body does not match the upload size", http.StatusForbidden)
+			return
+		}
+
+		f.mu.Lock()
+		f.objects[key] = object{data: data, contentType: r.Header.Get("Content-Type"), modifiedAt: f.now()}
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		f.mu.RLock()
+		obj, ok := f.objects[key]
+		f.mu.RUnlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		if obj.contentType != "" {
+			w.Header().Set("Content-Type", obj.contentType)
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("Last-Modified", obj.modifiedAt.UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.data)
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, PUT")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	}
+}
+
+// url presigns the URL of the object at key for the method, along with the given query parameters.
+func (f *FileMemory) url(method, key string, query url.Values) string {
+	expires := strconv.FormatInt(f.now().Add(urlExpiration).Unix(), 10)
+	query.Set("expires", expires)
+	query.Set("signature", f.sign(method, key, query.Get("size"), expires))
+	return f.baseURL + (&url.URL{Path: "/" + key}).EscapedPath() + "?" + query.Encode()
+}
+
+// verify checks that the URL of the request is signed for its method and key, and has not expired. A HEAD request is
+// accepted with the URL of a download.
+func (f *FileMemory) verify(r *http.Request, key string) error {
+	query := r.URL.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return errors.New("invalid signature")
+	}
+
+	method := r.Method
+	if method == http.MethodHead {
+		method = http.MethodGet
+	}
+	signature, err := hex.DecodeString(query.Get("signature"))
+	expected, _ := hex.DecodeString(f.sign(method, key, query.Get("size"), query.Get("expires")))
+	if err != nil || !hmac.Equal(signature, expected) {
+		return errors.New("invalid signature")
+	}
+	if f.now().Unix() > expires {
+		return errors.New("expired url")
+	}
+	return nil
+}
+
+// sign returns the hex encoded HMAC of a presigned URL.
+func (f *FileMemory) sign(method, key, size, expires string) string {
+	mac := hmac.New(sha256.New, f.key)
+	mac.Write([]byte(method + "\n" + key + "\n" + size + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewFileMemory creates a FileMemory whose presigned URLs start with baseURL, where it must be mounted.
+func NewFileMemory(baseURL string) *FileMemory {
+	key := make([]byte, sha256.Size)
+	_, _ = rand.Read(key)
+
+	return &FileMemory{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		key:     key,
+		objects: make(map[string]object),
+		now:     time.Now,
+	}
+}
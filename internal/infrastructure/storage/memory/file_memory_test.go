@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage/storagetest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileMemory_PresignedURLs(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	storage := NewFileMemory(server.URL + "/storage/")
+	mux.Handle("/storage/", http.StripPrefix("/storage", storage))
+
+	uploadURL, header, err := storage.PresignedUploadURL(ctx, "files/a b.txt", "text/plain", 5)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(uploadURL, server.URL+"/storage/files/a%20b.txt?"), uploadURL)
+	assert.Equal(t, "text/plain", header.Get("Content-Type"))
+
+	req, err := http.NewRequest(http.MethodPut, uploadURL, strings.NewReader("hello"))
+	assert.NoError(t, err)
+	req.Header = header
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	downloadURL, _, err := storage.PresignedDownloadURL(ctx, "files/a b.txt")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(downloadURL, server.URL+"/storage/files/a%20b.txt?"), downloadURL)
+	resp, err = http.Get(downloadURL)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+	assert.NotEmpty(t, resp.Header.Get("Last-Modified"))
+
+	// Like S3, deleting a missing object succeeds.
+	assert.NoError(t, storage.Delete(ctx, "files/a b.txt"))
+	assert.NoError(t, storage.Delete(ctx, "files/a b.txt"))
+	resp, err = http.Get(downloadURL)
+	assert.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestFileMemory_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+	storage := NewFileMemory("http://localhost/storage")
+
+	rec := httptest.NewRecorder()
+	storage.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/key", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(t, "GET, HEAD, PUT", rec.Header().Get("Allow"))
+}
+
+func TestFileMemory_Signature(t *testing.T) {
+	t.Parallel()
+	const baseURL = "http://localhost/storage"
+
+	testCases := []struct {
+		name           string
+		method         string
+		url            func(*FileMemory) string
+		elapsed        time.Duration
+		expectedStatus int
+	}{
+		{
+			name:   "Success Case - Upload",
+			method: http.MethodPut,
+			url: func(f *FileMemory) string {
+				url, _, _ := f.PresignedUploadURL(context.Background(), "key", "text/plain", 5)
+				return url
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Failure Case - Tampered size",
+			method: http.MethodPut,
+			url: func(f *FileMemory) string {
+				url, _, _ := f.PresignedUploadURL(context.Background(), "key", "text/plain", 1)
+				return strings.Replace(url, "size=1", "size=5", 1)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Failure Case - Other key",
+			method: http.MethodPut,
+			url: func(f *FileMemory) string {
+				url, _, _ := f.PresignedUploadURL(context.Background(), "key", "text/plain", 5)
+				return strings.Replace(url, "/key?", "/other?", 1)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Failure Case - Download url used to upload",
+			method: http.MethodPut,
+			url: func(f *FileMemory) string {
+				url, _, _ := f.PresignedDownloadURL(context.Background(), "key")
+				return url + "&size=5"
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Failure Case - Expired",
+			method: http.MethodPut,
+			url: func(f *FileMemory) string {
+				url, _, _ := f.PresignedUploadURL(context.Background(), "key", "text/plain", 5)
+				return url
+			},
+			elapsed:        urlExpiration + time.Second,
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Failure Case - Not signed",
+			method:         http.MethodGet,
+			url:            func(*FileMemory) string { return baseURL + "/key" },
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			storage := NewFileMemory(baseURL)
+			url := testCase.url(storage)
+			storage.now = func() time.Time { return time.Now().Add(testCase.elapsed) }
+
+			rec := httptest.NewRecorder()
+			storage.ServeHTTP(rec, httptest.NewRequest(testCase.method, strings.TrimPrefix(url, baseURL), strings.NewReader("hello")))
+
+			assert.Equal(t, testCase.expectedStatus, rec.Code)
+		})
+	}
+}
+
+func TestFileMemory_Contract(t *testing.T) {
+	t.Parallel()
+	storagetest.RunFileStorageSuite(t, func(t *testing.T) storage.IFileStorage {
+		mux := http.NewServeMux()
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+		fileStorage := NewFileMemory(server.URL + "/storage")
+		mux.Handle("/storage/", http.StripPrefix("/storage", fileStorage))
+		return fileStorage
+	})
+}
@@ -0,0 +1,122 @@
+package s3storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var _ storage.IFileStorage = (*FileS3)(nil)
+
+// FileS3 is an implementation of the IFileStorage interface storing the files in the bucket configured on the S3 client.
+// The presigned URLs expire after the default expiration of the client.
+type FileS3 struct {
+	client *Client
+}
+
+// PresignedDownloadURL returns a URL downloading the object at path, along with the headers the request must carry.
+func (f *FileS3) PresignedDownloadURL(ctx context.Context, path string) (string, http.Header, error) {
+	tracer := otel.Tracer("FileS3")
+	ctx, span := tracer.Start(ctx, "FileS3.PresignedDownloadURL")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("s3.key", path))
+
+	url, header, err := f.client.PresignGet(ctx, "", path, 0)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to presign download")
+		return "", nil, fmt.Errorf("fail to presign download of %s: %w", path, err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return url, header, nil
+}
+
+// PresignedUploadURL returns a URL uploading the object at key with the given content type and size, along with the
+// headers the request must carry. The size is signed, so that S3 rejects an upload of another size.
+func (f *FileS3) PresignedUploadURL(ctx context.Context, key, contentType string, size int64) (string, http.Header, error) {
+	tracer := otel.Tracer("FileS3")
+	ctx, span := tracer.Start(ctx, "FileS3.PresignedUploadURL")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("s3.key", key),
+		attribute.String("file.content_type", contentType),
+		attribute.Int64("file.size", size),
+	)
+
+	url, header, err := f.client.PresignPut(ctx, "", key, contentType, size, 0)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to presign upload")
+		return "", nil, fmt.Errorf("fail to presign upload of %s: %w", key, err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return url, header, nil
+}
+
+// Head describes the object at path, or returns a port.ErrNotFound when it does not exist.
+func (f *FileS3) Head(ctx context.Context, path string) (*model.FileObject, error) {
+	tracer := otel.Tracer("FileS3")
+	ctx, span := tracer.Start(ctx, "FileS3.Head")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("s3.key", path))
+
+	head, err := f.client.Head(ctx, "", path)
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			span.SetStatus(codes.Error, "object not found")
+			return nil, port.NewErrNotFound("file object", "path", path)
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to head object")
+		return nil, fmt.Errorf("fail to head %s: %w", path, err)
+	}
+
+	object := &model.FileObject{
+		Size:        aws.ToInt64(head.ContentLength),
+		ContentType: aws.ToString(head.ContentType),
+		ModifiedAt:  aws.ToTime(head.LastModified),
+	}
+	span.SetAttributes(attribute.Int64("file.size", object.Size))
+	span.SetStatus(codes.Ok, "")
+	return object, nil
+}
+
+// Delete removes the object at path. Deleting a missing object succeeds.
+func (f *FileS3) Delete(ctx context.Context, path string) error {
+	tracer := otel.Tracer("FileS3")
+	ctx, span := tracer.Start(ctx, "FileS3.Delete")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("s3.key", path))
+
+	if err := f.client.Delete(ctx, "", path); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "failed to delete object")
+		return fmt.Errorf("fail to delete %s: %w", path, err)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return nil
+}
+
+func NewFileS3(client *Client) *FileS3 {
+	return &FileS3{client: client}
+}
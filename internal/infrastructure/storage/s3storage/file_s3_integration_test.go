@@ -0,0 +1,22 @@
+package s3storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage/storagetest"
+)
+
+// TestIntegrationFileS3_Contract runs the IFileStorage conformance suite against the bucket of the MinIO container.
+func TestIntegrationFileS3_Contract(t *testing.T) {
+	t.Parallel()
+	client, err := NewS3(context.Background(), globalContainer.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storagetest.RunFileStorageSuite(t, func(t *testing.T) storage.IFileStorage {
+		return NewFileS3(client)
+	})
+}
@@ -237,8 +237,9 @@ func (c *Client) PresignGet(ctx context.Context, bucket, key string, expires tim
 }
 
 // PresignPut generates a presigned URL for uploading an object to the specified S3 bucket with a given key and content type.
-// It also generates the signed headers required for the PUT request. The URL expires after the specified duration.
-func (c *Client) PresignPut(ctx context.Context, bucket, key, contentType string, expires time.Duration) (string, http.Header, error) {
+// It also generates the signed headers required for the PUT request. A positive size is signed as the content length,
+// so that S3 rejects a body of another size. The URL expires after the specified duration.
+func (c *Client) PresignPut(ctx context.Context, bucket, key, contentType string, size int64, expires time.Duration) (string, http.Header, error) {
 	if bucket == "" {
 		bucket = c.config.Bucket
 	}
@@ -259,6 +260,10 @@ func (c *Client) PresignPut(ctx context.Context, bucket, key, contentType string
 		in.ContentType = aws.String(contentType)
 	}
 
+	if size > 0 {
+		in.ContentLength = aws.Int64(size)
+	}
+
 	if c.config.ServerSideEncryption != "" {
 		in.ServerSideEncryption = types.ServerSideEncryption(c.config.ServerSideEncryption)
 	}
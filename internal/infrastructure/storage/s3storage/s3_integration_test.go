@@ -268,6 +268,7 @@ func TestIntegrationClient_PresignPut(t *testing.T) {
 		bucket        string
 		key           string
 		contentType   string
+		size          int64
 		expires       time.Duration
 		expectedError error
 	}{
@@ -276,6 +277,7 @@ func TestIntegrationClient_PresignPut(t *testing.T) {
 			bucket:        "default",
 			key:           "test.txt",
 			contentType:   "text/plain",
+			size:          5,
 			expires:       time.Hour,
 			expectedError: nil,
 		},
@@ -295,7 +297,7 @@ func TestIntegrationClient_PresignPut(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			t.Parallel()
-			url, header, err := s3.PresignPut(ctx, testCase.bucket, testCase.key, testCase.contentType, testCase.expires)
+			url, header, err := s3.PresignPut(ctx, testCase.bucket, testCase.key, testCase.contentType, testCase.size, testCase.expires)
 
 			if testCase.expectedError != nil {
 				assert.Error(t, err)
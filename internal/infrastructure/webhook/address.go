@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+)
+
+// ErrPrivateAddress is returned when a webhook targets an address which is not reachable from the public network,
+// such as a loopback, private, link-local or cloud metadata address.
+var ErrPrivateAddress = errors.New("private address")
+
+// nonPublicPrefixes lists the special-purpose ranges which the standard library does not classify.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// isPublic reports whether the address is reachable from the public network.
+func isPublic(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAddress returns ErrPrivateAddress when the address is not public.
+func checkAddress(addr netip.Addr) error {
+	if !isPublic(addr) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addr)
+	}
+	return nil
+}
+
+// controlPublic is the control function of the dialer, which refuses to connect to a non-public address. It checks
+// the address actually dialled, once resolved, so that a host resolving to another address than when its url was
+// checked cannot reach the private networks.
+func controlPublic(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %w", address, err)
+	}
+	return checkAddress(addr)
+}
+
+// checkPublicURL returns an error when the url is not an absolute http or https url, or when its host is, or resolves
+// to, a non-public address.
+func checkPublicURL(ctx context.Context, resolver *net.Resolver, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("invalid url scheme %q", u.Scheme)
+	}
+	host := u.Hostname()
+	if host == "" {
+		return errors.New("invalid url: missing host")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddress(addr)
+	}
+
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("fail to resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := checkAddress(addr); err != nil {
+			return fmt.Errorf("%s resolves to a %w", host, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/webhook"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// maxResponseSize bounds the part of the response body read to reuse the connection.
+	maxResponseSize = 64 << 10
+)
+
+var (
+	_ webhook.IWebhookSender = (*WebhookHTTP)(nil)
+)
+
+// Config holds the settings of the HTTP client posting the webhooks. AllowPrivateNetworks lets the webhooks target
+// loopback, private and link-local addresses, which is only meant for local development.
+type Config struct {
+	Timeout              time.Duration `mapstructure:"timeout"`
+	AllowPrivateNetworks bool          `mapstructure:"allow_private_networks"`
+}
+
+// WebhookHTTP is a concrete implementation of the IWebhookSender interface posting the webhooks over HTTP.
+// Redirects are not followed: the endpoint of a subscription must answer by itself. Unless the private networks are
+// allowed, the webhooks only reach public addresses, checked once resolved when dialling, and do not go through the
+// proxy of the environment.
+type WebhookHTTP struct {
+	client       *http.Client
+	resolver     *net.Resolver
+	allowPrivate bool
+}
+
+// CheckURL returns an error when the url is not an http or https url, or, unless the private networks are allowed,
+// when its host is or resolves to a non-public address.
+func (w *WebhookHTTP) CheckURL(ctx context.Context, url string) error {
+	if w.allowPrivate {
+		return nil
+	}
+	return checkPublicURL(ctx, w.resolver, url)
+}
+
+// Send posts the body to the url and returns the status code of the response.
+func (w *WebhookHTTP) Send(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("fail to create webhook request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("fail to post webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	return resp.StatusCode, nil
+}
+
+// NewWebhookHTTP initializes a new WebhookHTTP whose requests time out after the configured timeout, restricted to the
+// public addresses unless the private networks are allowed.
+func NewWebhookHTTP(config Config) *WebhookHTTP {
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !config.AllowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   controlPublic,
+		}
+		transport.DialContext = dialer.DialContext
+		transport.Proxy = nil
+	}
+
+	return &WebhookHTTP{
+		client: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: otelhttp.NewTransport(transport),
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		resolver:     net.DefaultResolver,
+		allowPrivate: config.AllowPrivateNetworks,
+	}
+}
@@ -0,0 +1,121 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookHTTP_Send(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name               string
+		handler            http.HandlerFunc
+		denyPrivate        bool
+		expectedStatusCode int
+		expectedError      bool
+	}{
+		{
+			name: "Success Case - Accepted",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodPost || r.Header.Get("X-Astigo-Event") != "com.astigo.foo.created" || string(body) != `{"id":"1"}` {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				w.WriteHeader(http.StatusAccepted)
+			},
+			expectedStatusCode: http.StatusAccepted,
+		},
+		{
+			name: "Success Case - Redirect Not Followed",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Redirect(w, r, "/elsewhere", http.StatusFound)
+			},
+			expectedStatusCode: http.StatusFound,
+		},
+		{
+			name: "Failure Case - Timeout",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			},
+			expectedError: true,
+		},
+		{
+			name: "Failure Case - Private Address",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			},
+			denyPrivate:   true,
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			server := httptest.NewServer(testCase.handler)
+			defer server.Close()
+			sender := NewWebhookHTTP(Config{Timeout: 100 * time.Millisecond, AllowPrivateNetworks: !testCase.denyPrivate})
+
+			header := http.Header{}
+			header.Set("X-Astigo-Event", "com.astigo.foo.created")
+			statusCode, err := sender.Send(context.Background(), server.URL, header, []byte(`{"id":"1"}`))
+
+			if testCase.expectedError {
+				assert.Error(t, err)
+				if testCase.denyPrivate {
+					assert.ErrorIs(t, err, ErrPrivateAddress)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedStatusCode, statusCode)
+		})
+	}
+}
+
+func TestWebhookHTTP_CheckURL(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name          string
+		url           string
+		allowPrivate  bool
+		expectedError error
+	}{
+		{name: "Success Case - Public Address", url: "https://93.184.215.14/hooks"},
+		{name: "Success Case - Public IPv6 Address", url: "https://[2606:2800:21f:cb07:6820:80da:af6b:8b2c]/hooks"},
+		{name: "Success Case - Private Networks Allowed", url: "http://127.0.0.1:8080/hooks", allowPrivate: true},
+		{name: "Failure Case - Loopback", url: "http://127.0.0.1:8080/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - Localhost", url: "http://localhost/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - Private Network", url: "https://10.0.0.1/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - Metadata Service", url: "http://169.254.169.254/latest/meta-data", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - Unspecified", url: "http://0.0.0.0/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - IPv6 Loopback", url: "http://[::1]/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - IPv4-Mapped Private", url: "http://[::ffff:192.168.1.1]/hooks", expectedError: ErrPrivateAddress},
+		{name: "Failure Case - Shared Address Space", url: "http://100.64.0.1/hooks", expectedError: ErrPrivateAddress},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			sender := NewWebhookHTTP(Config{AllowPrivateNetworks: testCase.allowPrivate})
+
+			err := sender.CheckURL(context.Background(), testCase.url)
+
+			if testCase.expectedError != nil {
+				assert.ErrorIs(t, err, testCase.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
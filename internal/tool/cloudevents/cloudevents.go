@@ -0,0 +1,184 @@
+// Package cloudevents wraps the events published over NATS in CloudEvents 1.0 envelopes, following the NATS protocol
+// binding: in structured mode the message body is the JSON envelope, in binary mode the body is the event data and
+// the attributes travel in ce- prefixed headers.
+package cloudevents
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/nats-io/nats.go"
+)
+
+const (
+	SpecVersion = "1.0"
+
+	ModeStructured = "structured"
+	ModeBinary     = "binary"
+
+	// ContentTypeStructured is the content type of a message carrying a JSON envelope in structured mode.
+	ContentTypeStructured = "application/cloudevents+json"
+	ContentTypeJSON       = "application/json"
+
+	HeaderContentType = "Content-Type"
+	HeaderPrefix      = "ce-"
+	HeaderSpecVersion = HeaderPrefix + "specversion"
+	HeaderID          = HeaderPrefix + "id"
+	HeaderSource      = HeaderPrefix + "source"
+	HeaderType        = HeaderPrefix + "type"
+	HeaderSubject     = HeaderPrefix + "subject"
+	HeaderTime        = HeaderPrefix + "time"
+	HeaderDataSchema  = HeaderPrefix + "dataschema"
+)
+
+var (
+	// ErrNotCloudEvent is returned when decoding a message which is not a CloudEvent, such as one published before the
+	// envelope was introduced.
+	ErrNotCloudEvent = errors.New("message is not a cloudevent")
+	// ErrInvalid is returned when decoding a CloudEvent lacking a required attribute or of an unsupported version.
+	ErrInvalid = errors.New("invalid cloudevent")
+)
+
+// Event is a CloudEvents 1.0 envelope whose data is JSON.
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            *time.Time      `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	DataSchema      string          `json:"dataschema,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// Config defines how the events are wrapped: Mode is either structured or binary, and Source identifies the
+// publishing application in the source attribute of every event.
+type Config struct {
+	Mode   string `mapstructure:"mode"`
+	Source string `mapstructure:"source"`
+}
+
+// Encoder writes events into NATS messages in the configured mode.
+type Encoder struct {
+	mode   string
+	source string
+}
+
+// Mode returns the content mode of the encoded messages.
+func (e *Encoder) Mode() string {
+	return e.mode
+}
+
+// Encode completes the event with the spec version, the source and a JSON data content type, then writes it into msg.
+func (e *Encoder) Encode(msg *nats.Msg, event Event) error {
+	event = e.complete(event)
+	if msg.Header == nil {
+		msg.Header = nats.Header{}
+	}
+
+	if e.mode == ModeBinary {
+		msg.Header.Set(HeaderSpecVersion, event.SpecVersion)
+		msg.Header.Set(HeaderID, event.ID)
+		msg.Header.Set(HeaderSource, event.Source)
+		msg.Header.Set(HeaderType, event.Type)
+		if event.Subject != "" {
+			msg.Header.Set(HeaderSubject, event.Subject)
+		}
+		if event.Time != nil {
+			msg.Header.Set(HeaderTime, event.Time.UTC().Format(time.RFC3339Nano))
+		}
+		if event.DataSchema != "" {
+			msg.Header.Set(HeaderDataSchema, event.DataSchema)
+		}
+		msg.Header.Set(HeaderContentType, event.DataContentType)
+		msg.Data = event.Data
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("fail to marshal cloudevent: %w", err)
+	}
+	msg.Header.Set(HeaderContentType, ContentTypeStructured)
+	msg.Data = data
+	return nil
+}
+
+// Marshal completes the event like Encode and returns its JSON envelope, as sent in structured mode whatever the
+// configured mode. It serves the transports other than NATS, such as webhooks.
+func (e *Encoder) Marshal(event Event) ([]byte, error) {
+	data, err := json.Marshal(e.complete(event))
+	if err != nil {
+		return nil, fmt.Errorf("fail to marshal cloudevent: %w", err)
+	}
+	return data, nil
+}
+
+func (e *Encoder) complete(event Event) Event {
+	event.SpecVersion = SpecVersion
+	event.Source = e.source
+	if event.DataContentType == "" {
+		event.DataContentType = ContentTypeJSON
+	}
+	return event
+}
+
+// Decode reads the CloudEvent carried by a message in either mode. It returns ErrNotCloudEvent when the message is
+// not a CloudEvent.
+func Decode(header nats.Header, data []byte) (*Event, error) {
+	var event Event
+	switch {
+	case header.Get(HeaderSpecVersion) != "":
+		event = Event{
+			SpecVersion:     header.Get(HeaderSpecVersion),
+			ID:              header.Get(HeaderID),
+			Source:          header.Get(HeaderSource),
+			Type:            header.Get(HeaderType),
+			Subject:         header.Get(HeaderSubject),
+			DataContentType: header.Get(HeaderContentType),
+			DataSchema:      header.Get(HeaderDataSchema),
+			Data:            data,
+		}
+		if value := header.Get(HeaderTime); value != "" {
+			t, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: time: %w", ErrInvalid, err)
+			}
+			event.Time = &t
+		}
+	case strings.HasPrefix(header.Get(HeaderContentType), ContentTypeStructured):
+		if err := json.Unmarshal(data, &event); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+	default:
+		return nil, ErrNotCloudEvent
+	}
+
+	if event.SpecVersion != SpecVersion {
+		return nil, fmt.Errorf("%w: unsupported spec version %q", ErrInvalid, event.SpecVersion)
+	}
+	if event.ID == "" || event.Source == "" || event.Type == "" {
+		return nil, fmt.Errorf("%w: missing id, source or type", ErrInvalid)
+	}
+	return &event, nil
+}
+
+// NewEncoder creates an Encoder in the configured mode, structured by default.
+func NewEncoder(config Config) (*Encoder, error) {
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeStructured
+	}
+	if mode != ModeStructured && mode != ModeBinary {
+		return nil, fmt.Errorf("unknown cloudevents mode %q", config.Mode)
+	}
+	if config.Source == "" {
+		return nil, errors.New("cloudevents source is required")
+	}
+
+	return &Encoder{mode: mode, source: config.Source}, nil
+}
@@ -0,0 +1,136 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncoder_RoundTrip(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC)
+	event := Event{
+		ID:      "foo.created:20000000-0000-0000-0000-000000000001:1",
+		Type:    "com.astigo.foo.created",
+		Subject: "20000000-0000-0000-0000-000000000001",
+		Time:    &now,
+		Data:    json.RawMessage(`{"id":"20000000-0000-0000-0000-000000000001"}`),
+	}
+
+	testCases := []struct {
+		name                string
+		mode                string
+		expectedContentType string
+		expectedBody        bool
+	}{
+		{name: "Success Case - Structured", mode: ModeStructured, expectedContentType: ContentTypeStructured},
+		{name: "Success Case - Binary", mode: ModeBinary, expectedContentType: ContentTypeJSON, expectedBody: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			encoder, err := NewEncoder(Config{Mode: testCase.mode, Source: "/astigo"})
+			assert.NoError(t, err)
+
+			msg := nats.NewMsg("foo.created")
+			assert.NoError(t, encoder.Encode(msg, event))
+
+			assert.Equal(t, testCase.expectedContentType, msg.Header.Get(HeaderContentType))
+			assert.Equal(t, testCase.expectedBody, string(msg.Data) == string(event.Data))
+
+			decoded, err := Decode(msg.Header, msg.Data)
+			assert.NoError(t, err)
+			assert.Equal(t, SpecVersion, decoded.SpecVersion)
+			assert.Equal(t, event.ID, decoded.ID)
+			assert.Equal(t, "/astigo", decoded.Source)
+			assert.Equal(t, event.Type, decoded.Type)
+			assert.Equal(t, event.Subject, decoded.Subject)
+			assert.Equal(t, ContentTypeJSON, decoded.DataContentType)
+			assert.True(t, now.Equal(*decoded.Time))
+			assert.JSONEq(t, string(event.Data), string(decoded.Data))
+		})
+	}
+}
+
+func TestEncoder_Marshal(t *testing.T) {
+	t.Parallel()
+	encoder, err := NewEncoder(Config{Mode: ModeBinary, Source: "/astigo"})
+	assert.NoError(t, err)
+
+	data, err := encoder.Marshal(Event{
+		ID:   "foo.deleted:20000000-0000-0000-0000-000000000001:0",
+		Type: "com.astigo.foo.deleted",
+		Data: json.RawMessage(`{"id":"20000000-0000-0000-0000-000000000001"}`),
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"specversion":"1.0","id":"foo.deleted:20000000-0000-0000-0000-000000000001:0","source":"/astigo",
+		"type":"com.astigo.foo.deleted","datacontenttype":"application/json","data":{"id":"20000000-0000-0000-0000-000000000001"}}`,
+		string(data))
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		header      nats.Header
+		data        string
+		expectedErr error
+	}{
+		{
+			name:        "Failure Case - Plain Message",
+			header:      nats.Header{},
+			data:        `{"id":"20000000-0000-0000-0000-000000000001"}`,
+			expectedErr: ErrNotCloudEvent,
+		},
+		{
+			name:        "Failure Case - Unsupported Version",
+			header:      nats.Header{HeaderContentType: {ContentTypeStructured}},
+			data:        `{"specversion":"0.3","id":"1","source":"/astigo","type":"com.astigo.foo.created"}`,
+			expectedErr: ErrInvalid,
+		},
+		{
+			name:        "Failure Case - Missing Type",
+			header:      nats.Header{HeaderSpecVersion: {SpecVersion}, HeaderID: {"1"}, HeaderSource: {"/astigo"}},
+			expectedErr: ErrInvalid,
+		},
+		{
+			name:        "Failure Case - Malformed Envelope",
+			header:      nats.Header{HeaderContentType: {ContentTypeStructured}},
+			data:        `{"specversion":`,
+			expectedErr: ErrInvalid,
+		},
+		{
+			name: "Failure Case - Malformed Time",
+			header: nats.Header{
+				HeaderSpecVersion: {SpecVersion}, HeaderID: {"1"}, HeaderSource: {"/astigo"},
+				HeaderType: {"com.astigo.foo.created"}, HeaderTime: {"yesterday"},
+			},
+			expectedErr: ErrInvalid,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Decode(testCase.header, []byte(testCase.data))
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestNewEncoder(t *testing.T) {
+	t.Parallel()
+	encoder, err := NewEncoder(Config{Source: "/astigo"})
+	assert.NoError(t, err)
+	assert.Equal(t, ModeStructured, encoder.Mode())
+
+	_, err = NewEncoder(Config{Mode: "batch", Source: "/astigo"})
+	assert.Error(t, err)
+
+	_, err = NewEncoder(Config{Mode: ModeBinary})
+	assert.Error(t, err)
+}
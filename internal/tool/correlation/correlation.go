@@ -0,0 +1,114 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// HeaderRequestID is the HTTP header and NATS message header carrying the request id.
+	HeaderRequestID = "X-Request-ID"
+	// MetadataRequestID is the gRPC metadata key carrying the request id.
+	MetadataRequestID = "x-request-id"
+
+	maxRequestIDLength = 128
+)
+
+type requestIDKey struct{}
+
+type loggerKey struct{}
+
+// NewRequestID generates a new request id.
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether an id received from a client can be reused: it must be short printable ASCII
+// so that it cannot be used to inject content into logs or headers.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a copy of ctx carrying the request id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id carried by ctx, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// WithLogger returns a copy of ctx carrying a request-scoped logger. Logger adds the trace id and span id to it.
+func WithLogger(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// Logger returns the request-scoped logger carried by ctx, enriched with the trace id and span id of the current span
+// of ctx rather than of the span the request started with. Without one, the fallback logger is enriched with the
+// request id, trace id and span id found in ctx.
+func Logger(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		if fields := spanFields(ctx); len(fields) > 0 {
+			return logger.With(fields...)
+		}
+		return logger
+	}
+	if fallback == nil {
+		fallback = zap.NewNop()
+	}
+
+	return fallback.With(Fields(ctx)...)
+}
+
+// Fields returns the correlation fields found in ctx: request id, trace id and span id.
+func Fields(ctx context.Context) []zap.Field {
+	var fields []zap.Field
+	if id := RequestID(ctx); id != "" {
+		fields = append(fields, zap.String("request_id", id))
+	}
+
+	return append(fields, spanFields(ctx)...)
+}
+
+// spanFields returns the trace id and span id of the span of ctx, if any.
+func spanFields(ctx context.Context) []zap.Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("trace_id", spanContext.TraceID().String()),
+		zap.String("span_id", spanContext.SpanID().String()),
+	}
+}
+
+// Start attaches the request id to ctx along with a logger derived from base and enriched with it. The trace id and
+// span id are added by Logger, since the span changes during the request.
+func Start(ctx context.Context, base *zap.Logger, id string) context.Context {
+	ctx = WithRequestID(ctx, id)
+	return WithLogger(ctx, base.With(zap.String("request_id", id)))
+}
+
+// WithSubject enriches the request-scoped logger with the subject of the authenticated caller.
+func WithSubject(ctx context.Context, subject string) context.Context {
+	logger, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok {
+		return ctx
+	}
+
+	return WithLogger(ctx, logger.With(zap.String("subject", subject)))
+}
@@ -0,0 +1,99 @@
+package correlation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestValidRequestID(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		id       string
+		expected bool
+	}{
+		{name: "Success Case - Uuid", id: "0e4f3c1a-3b6f-4c47-9d39-4b5f0b5f6a11", expected: true},
+		{name: "Success Case - Printable", id: "req_42:abc", expected: true},
+		{name: "Failure Case - Empty", id: "", expected: false},
+		{name: "Failure Case - Too long", id: strings.Repeat("a", maxRequestIDLength+1), expected: false},
+		{name: "Failure Case - Space", id: "req 42", expected: false},
+		{name: "Failure Case - Line break", id: "req\n42", expected: false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.expected, ValidRequestID(testCase.id))
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zap.InfoLevel)
+	base := zap.New(core)
+
+	ctx := Start(context.Background(), base, "req-1")
+	ctx = WithSubject(ctx, "user-1")
+	Logger(ctx, zap.NewNop()).Info("scoped")
+	Logger(WithRequestID(context.Background(), "req-2"), base).Info("fallback")
+
+	entries := logs.All()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "req-1", entries[0].ContextMap()["request_id"])
+	assert.Equal(t, "user-1", entries[0].ContextMap()["subject"])
+	assert.Equal(t, "req-2", entries[1].ContextMap()["request_id"])
+	assert.Equal(t, "req-1", RequestID(ctx))
+}
+
+func TestLogger_CurrentSpan(t *testing.T) {
+	t.Parallel()
+	core, logs := observer.New(zap.InfoLevel)
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	newSpan := func(ctx context.Context, spanID trace.SpanID) context.Context {
+		return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+	}
+
+	ctx := newSpan(context.Background(), trace.SpanID{0, 0, 0, 0, 0, 0, 0, 1})
+	ctx = Start(ctx, zap.New(core), "req-1")
+	Logger(ctx, nil).Info("request span")
+	Logger(newSpan(ctx, trace.SpanID{0, 0, 0, 0, 0, 0, 0, 2}), nil).Info("child span")
+
+	entries := logs.All()
+	assert.Len(t, entries, 2)
+	for i, spanID := range []string{"0000000000000001", "0000000000000002"} {
+		assert.Equal(t, "req-1", entries[i].ContextMap()["request_id"])
+		assert.Equal(t, traceID.String(), entries[i].ContextMap()["trace_id"])
+		assert.Equal(t, spanID, entries[i].ContextMap()["span_id"])
+	}
+}
+
+func TestNatsHeaderCarrier(t *testing.T) {
+	t.Parallel()
+	propagator := propagation.TraceContext{}
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c},
+		SpanID:     trace.SpanID{0xb7, 0xad, 0x6b, 0x71, 0x69, 0x20, 0x33, 0x31},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	msg := nats.NewMsg("foo.created")
+	propagator.Inject(trace.ContextWithSpanContext(context.Background(), spanContext), NatsHeaderCarrier(msg.Header))
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", msg.Header.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(propagator.Extract(context.Background(), NatsHeaderCarrier(msg.Header)))
+	assert.Equal(t, spanContext.TraceID(), extracted.TraceID())
+	assert.Equal(t, spanContext.SpanID(), extracted.SpanID())
+	assert.True(t, extracted.IsRemote())
+}
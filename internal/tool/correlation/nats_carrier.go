@@ -0,0 +1,30 @@
+package correlation
+
+import (
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+var _ propagation.TextMapCarrier = (*NatsHeaderCarrier)(nil)
+
+// NatsHeaderCarrier adapts NATS message headers to the OpenTelemetry TextMapCarrier interface.
+// Unlike propagation.HeaderCarrier, keys are not canonicalized: NATS headers are case-sensitive and the W3C
+// traceparent and baggage headers are expected in lower case by consumers written in other languages.
+type NatsHeaderCarrier nats.Header
+
+func (c NatsHeaderCarrier) Get(key string) string {
+	return nats.Header(c).Get(key)
+}
+
+func (c NatsHeaderCarrier) Set(key, value string) {
+	nats.Header(c).Set(key, value)
+}
+
+func (c NatsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,36 @@
+-- CREATE TABLE
+CREATE TABLE IF NOT EXISTS webhook_subscription
+(
+    subscription_id      UUID PRIMARY KEY,
+    url                  varchar(2048) NOT NULL,
+    event_types          text[] NOT NULL,
+    secret               varchar(256) NOT NULL,
+    enabled              boolean NOT NULL DEFAULT true,
+    consecutive_failures int NOT NULL DEFAULT 0,
+    disabled_at          timestamptz,
+    created_at           timestamptz NOT NULL DEFAULT now(),
+    updated_at           timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery
+(
+    delivery_id     UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL REFERENCES webhook_subscription (subscription_id) ON DELETE CASCADE,
+    event_id        varchar(255) NOT NULL,
+    event_type      varchar(255) NOT NULL,
+    payload         bytea NOT NULL,
+    status          varchar(16) NOT NULL,
+    attempts        int NOT NULL DEFAULT 0,
+    status_code     int NOT NULL DEFAULT 0,
+    error           text NOT NULL DEFAULT '',
+    duration_ms     bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    created_at      timestamptz NOT NULL DEFAULT now(),
+    updated_at      timestamptz,
+    UNIQUE (subscription_id, event_id)
+);
+
+-- CREATE INDEX
+CREATE INDEX IF NOT EXISTS webhook_subscription_event_types_idx ON webhook_subscription USING gin (event_types);
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS webhook_delivery_subscription_idx ON webhook_delivery (subscription_id, created_at DESC);
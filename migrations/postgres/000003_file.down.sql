@@ -0,0 +1,2 @@
+-- DROP TABLE
+DROP TABLE IF EXISTS file;
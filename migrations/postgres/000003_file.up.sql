@@ -0,0 +1,17 @@
+-- CREATE TABLE
+CREATE TABLE IF NOT EXISTS file
+(
+    file_id     UUID PRIMARY KEY,
+    name        varchar(255) NOT NULL,
+    size        bigint NOT NULL,
+    extension   varchar(32) NOT NULL DEFAULT '',
+    mime_type   varchar(255) NOT NULL,
+    path        varchar(1024) NOT NULL,
+    status      varchar(16) NOT NULL,
+    created_at  timestamptz NOT NULL DEFAULT now(),
+    uploaded_at timestamptz,
+    updated_at  timestamptz
+);
+
+-- CREATE INDEX
+CREATE INDEX IF NOT EXISTS file_created_at_idx ON file (created_at, file_id);
@@ -19,13 +19,18 @@ type MockFooCache struct {
 	mock.Mock
 }
 
-func (m *MockFooCache) GetByID(ctx context.Context, id uuid.UUID) (*model.Foo, error) {
+func (m *MockFooCache) GetByID(ctx context.Context, id uuid.UUID) (*model.FooCacheEntry, error) {
 	args := m.Called(ctx, id)
-	return args.Get(0).(*model.Foo), args.Error(1)
+	return args.Get(0).(*model.FooCacheEntry), args.Error(1)
 }
 
-func (m *MockFooCache) Set(ctx context.Context, foo *model.Foo, expiration time.Duration) error {
-	args := m.Called(ctx, foo, expiration)
+func (m *MockFooCache) Set(ctx context.Context, foo *model.Foo, ttl model.CacheTTL) error {
+	args := m.Called(ctx, foo, ttl)
+	return args.Error(0)
+}
+
+func (m *MockFooCache) SetMissing(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	args := m.Called(ctx, id, ttl)
 	return args.Error(0)
 }
 
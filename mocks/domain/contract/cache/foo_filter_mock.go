@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ cache.IFooFilter = (*MockFooFilter)(nil)
+)
+
+type MockFooFilter struct {
+	mock.Mock
+}
+
+func (m *MockFooFilter) Add(ctx context.Context, ids ...uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockFooFilter) MightContain(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFooFilter) MarkPopulated(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/cache"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ cache.IFooListCache = (*MockFooListCache)(nil)
+)
+
+type MockFooListCache struct {
+	mock.Mock
+}
+
+func (m *MockFooListCache) GetList(ctx context.Context, input data.FooReadListInput) ([]*model.Foo, bool, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]*model.Foo), args.Bool(1), args.Error(2)
+}
+
+func (m *MockFooListCache) SetList(ctx context.Context, input data.FooReadListInput, foos []*model.Foo, ttl time.Duration) error {
+	args := m.Called(ctx, input, foos, ttl)
+	return args.Error(0)
+}
+
+func (m *MockFooListCache) InvalidateByIDs(ctx context.Context, ids ...uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockFooListCache) InvalidateAll(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
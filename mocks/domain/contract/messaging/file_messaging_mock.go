@@ -0,0 +1,34 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/messaging"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ messaging.IFileMessaging = (*MockFileMessaging)(nil)
+)
+
+type MockFileMessaging struct {
+	mock.Mock
+}
+
+func (m *MockFileMessaging) PublishFileCreated(ctx context.Context, file *model.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockFileMessaging) PublishFileUploaded(ctx context.Context, file *model.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockFileMessaging) PublishFileDeleted(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ repository.IFileRepository = (*MockFileRepository)(nil)
+)
+
+type MockFileRepository struct {
+	mock.Mock
+}
+
+func (m *MockFileRepository) FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.File, error) {
+	args := m.Called(ctx, pagination)
+	return args.Get(0).([]*model.File), args.Error(1)
+}
+
+func (m *MockFileRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.File), args.Error(1)
+}
+
+func (m *MockFileRepository) FindIncompleteBefore(ctx context.Context, before time.Time, limit int) ([]*model.File, error) {
+	args := m.Called(ctx, before, limit)
+	return args.Get(0).([]*model.File), args.Error(1)
+}
+
+func (m *MockFileRepository) Create(ctx context.Context, file *model.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) Update(ctx context.Context, file *model.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *MockFileRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ repository.IWebhookRepository = (*MockWebhookRepository)(nil)
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) FindAll(ctx context.Context, pagination data.PaginationOffset) ([]*model.WebhookSubscription, error) {
+	args := m.Called(ctx, pagination)
+	return args.Get(0).([]*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByEventType(ctx context.Context, eventType string) ([]*model.WebhookSubscription, error) {
+	args := m.Called(ctx, eventType)
+	return args.Get(0).([]*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, subscription *model.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, subscription *model.WebhookSubscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, threshold int) (bool, error) {
+	args := m.Called(ctx, id, threshold)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWebhookRepository) CreateDeliveries(ctx context.Context, deliveries ...*model.WebhookDelivery) error {
+	args := m.Called(ctx, deliveries)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) ClaimDeliveries(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]*model.WebhookDelivery, error) {
+	args := m.Called(ctx, now, limit, lease)
+	return args.Get(0).([]*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *model.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ service.IFileService = (*MockFileService)(nil)
+)
+
+type MockFileService struct {
+	mock.Mock
+}
+
+func (m *MockFileService) Create(ctx context.Context, input data.FileCreateInput) (*model.File, *model.PresignedRequest, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*model.File), args.Get(1).(*model.PresignedRequest), args.Error(2)
+}
+
+func (m *MockFileService) Complete(ctx context.Context, id uuid.UUID) (*model.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.File), args.Error(1)
+}
+
+func (m *MockFileService) GetByID(ctx context.Context, id uuid.UUID) (*model.File, *model.PresignedRequest, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.File), args.Get(1).(*model.PresignedRequest), args.Error(2)
+}
+
+func (m *MockFileService) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockFileService) ExpireUploads(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -33,9 +33,9 @@ func (m *MockFooService) Create(ctx context.Context, input data.FooCreateInput)
 	return args.Get(0).(*model.Foo), args.Error(1)
 }
 
-func (m *MockFooService) Update(ctx context.Context, input data.IFooUpdateMerger) error {
+func (m *MockFooService) Update(ctx context.Context, input data.IFooUpdateMerger) (*model.Foo, error) {
 	args := m.Called(ctx, input)
-	return args.Error(0)
+	return args.Get(0).(*model.Foo), args.Error(1)
 }
 
 func (m *MockFooService) DeleteByID(ctx context.Context, id uuid.UUID) error {
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/data"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/in/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ service.IWebhookService = (*MockWebhookService)(nil)
+)
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) GetAll(ctx context.Context, input data.PaginationOffset) ([]*model.WebhookSubscription, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) GetByID(ctx context.Context, id uuid.UUID) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) Create(ctx context.Context, input data.WebhookCreateInput) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) Update(ctx context.Context, input data.WebhookUpdateInput) (*model.WebhookSubscription, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(*model.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteByID(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, input data.WebhookDeliveryListInput) ([]*model.WebhookDelivery, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]*model.WebhookDelivery), args.Error(1)
+}
+
+func (m *MockWebhookService) Enqueue(ctx context.Context, input data.WebhookEventInput) error {
+	args := m.Called(ctx, input)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) DeliverPending(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/model"
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/storage"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ storage.IFileStorage = (*MockFileStorage)(nil)
+)
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) PresignedDownloadURL(ctx context.Context, path string) (string, http.Header, error) {
+	args := m.Called(ctx, path)
+	return args.String(0), args.Get(1).(http.Header), args.Error(2)
+}
+
+func (m *MockFileStorage) PresignedUploadURL(ctx context.Context, key, contentType string, size int64) (string, http.Header, error) {
+	args := m.Called(ctx, key, contentType, size)
+	return args.String(0), args.Get(1).(http.Header), args.Error(2)
+}
+
+func (m *MockFileStorage) Head(ctx context.Context, path string) (*model.FileObject, error) {
+	args := m.Called(ctx, path)
+	return args.Get(0).(*model.FileObject), args.Error(1)
+}
+
+func (m *MockFileStorage) Delete(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/TancelinMazzotti/astigo/internal/domain/port/out/webhook"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	_ webhook.IWebhookSender = (*MockWebhookSender)(nil)
+)
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) CheckURL(ctx context.Context, url string) error {
+	args := m.Called(ctx, url)
+	return args.Error(0)
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, url string, header http.Header, body []byte) (int, error) {
+	args := m.Called(ctx, url, header, body)
+	return args.Int(0), args.Error(1)
+}
@@ -0,0 +1,150 @@
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const asyncAPIVersion = "3.0.0"
+
+type ref struct {
+	Ref string `yaml:"$ref"`
+}
+
+type asyncAPIDocument struct {
+	AsyncAPI           string                       `yaml:"asyncapi"`
+	Info               asyncAPIInfo                 `yaml:"info"`
+	DefaultContentType string                       `yaml:"defaultContentType"`
+	Channels           map[string]asyncAPIChannel   `yaml:"channels"`
+	Operations         map[string]asyncAPIOperation `yaml:"operations"`
+	Components         asyncAPIComponents           `yaml:"components"`
+}
+
+type asyncAPIInfo struct {
+	Title       string `yaml:"title"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+}
+
+type asyncAPIChannel struct {
+	Address  string         `yaml:"address"`
+	Messages map[string]ref `yaml:"messages"`
+}
+
+type asyncAPIOperation struct {
+	Action   string `yaml:"action"`
+	Channel  ref    `yaml:"channel"`
+	Messages []ref  `yaml:"messages"`
+}
+
+type asyncAPIComponents struct {
+	Messages      map[string]asyncAPIMessage `yaml:"messages"`
+	MessageTraits map[string]asyncAPITrait   `yaml:"messageTraits"`
+}
+
+type asyncAPIMessage struct {
+	Name        string `yaml:"name"`
+	Title       string `yaml:"title"`
+	Summary     string `yaml:"summary"`
+	ContentType string `yaml:"contentType"`
+	Payload     ref    `yaml:"payload"`
+	Traits      []ref  `yaml:"traits"`
+}
+
+type asyncAPITrait struct {
+	Description string         `yaml:"description"`
+	Headers     map[string]any `yaml:"headers"`
+}
+
+// AsyncAPI renders the AsyncAPI document describing the subject, the payload and the versions of every event of the
+// catalog. Payloads reference the schema files relative to the document.
+func (c *Catalog) AsyncAPI() ([]byte, error) {
+	document := asyncAPIDocument{
+		AsyncAPI: asyncAPIVersion,
+		Info: asyncAPIInfo{
+			Title:   "Astigo events",
+			Version: "1.0.0",
+			Description: "Events published by Astigo over NATS as CloudEvents 1.0. The dataschema attribute of each " +
+				"event identifies the version of its data.",
+		},
+		DefaultContentType: "application/json",
+		Channels:           make(map[string]asyncAPIChannel),
+		Operations:         make(map[string]asyncAPIOperation),
+		Components: asyncAPIComponents{
+			Messages: make(map[string]asyncAPIMessage),
+			MessageTraits: map[string]asyncAPITrait{
+				"cloudEvent": {
+					Description: "CloudEvents attributes, carried by ce- headers in binary mode or by the " +
+						"application/cloudevents+json envelope in structured mode.",
+					Headers: map[string]any{
+						"type": "object",
+						"properties": map[string]any{
+							"ce-specversion": map[string]any{"type": "string", "const": "1.0"},
+							"ce-id":          map[string]any{"type": "string"},
+							"ce-source":      map[string]any{"type": "string"},
+							"ce-type":        map[string]any{"type": "string"},
+							"ce-subject":     map[string]any{"type": "string"},
+							"ce-time":        map[string]any{"type": "string", "format": "date-time"},
+							"ce-dataschema":  map[string]any{"type": "string"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, definition := range c.definitions {
+		channel := camelCase(definition.Subject)
+		message := fmt.Sprintf("%sV%d", channel, definition.Version)
+
+		document.Components.Messages[message] = asyncAPIMessage{
+			Name:        definition.Type,
+			Title:       fmt.Sprintf("%s v%d", definition.Type, definition.Version),
+			Summary:     definition.Summary,
+			ContentType: "application/json",
+			Payload:     ref{Ref: "./" + definition.Schema},
+			Traits:      []ref{{Ref: "#/components/messageTraits/cloudEvent"}},
+		}
+
+		ch, ok := document.Channels[channel]
+		if !ok {
+			ch = asyncAPIChannel{Address: definition.Subject, Messages: make(map[string]ref)}
+		}
+		ch.Messages[message] = ref{Ref: "#/components/messages/" + message}
+		document.Channels[channel] = ch
+
+		operation := "publish" + strings.ToUpper(channel[:1]) + channel[1:]
+		op, ok := document.Operations[operation]
+		if !ok {
+			op = asyncAPIOperation{Action: "send", Channel: ref{Ref: "#/channels/" + channel}}
+		}
+		op.Messages = append(op.Messages, ref{Ref: fmt.Sprintf("#/channels/%s/messages/%s", channel, message)})
+		document.Operations[operation] = op
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by go generate ./pkg/events; DO NOT EDIT.\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, fmt.Errorf("fail to encode asyncapi document: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("fail to encode asyncapi document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// camelCase turns a subject such as foo.created into fooCreated.
+func camelCase(subject string) string {
+	parts := strings.Split(subject, ".")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,254 @@
+// Package events describes the events published by Astigo: the JSON Schema of every version of their data, the
+// upcasters migrating the data of an older version to the current one, and the AsyncAPI document generated from them.
+// The data of an event carries its schema in the dataschema attribute of its CloudEvent.
+package events
+
+//go:generate go run gen/main.go
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const (
+	FooCreatedType = "com.astigo.foo.created"
+	FooUpdatedType = "com.astigo.foo.updated"
+	FooDeletedType = "com.astigo.foo.deleted"
+
+	FileCreatedType  = "com.astigo.file.created"
+	FileUploadedType = "com.astigo.file.uploaded"
+	FileDeletedType  = "com.astigo.file.deleted"
+
+	// dataSchemaPrefix prefixes the URN identifying the schema of a version of an event, followed by <type>:v<version>.
+	dataSchemaPrefix = "urn:astigo:schema:"
+)
+
+var (
+	// ErrUnknownEvent is returned for an event type or a version that the catalog does not define.
+	ErrUnknownEvent = errors.New("unknown event")
+	// ErrUnsupportedVersion is returned when upcasting data of a version newer than the current one, published by a
+	// more recent release.
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+	// ErrInvalidData is returned when the data of an event does not match its schema.
+	ErrInvalidData = errors.New("invalid event data")
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var (
+	FooCreatedV1 = Definition{
+		Type:    FooCreatedType,
+		Subject: "foo.created",
+		Version: 1,
+		Schema:  "schemas/foo.created.v1.json",
+		Summary: "A Foo was created.",
+	}
+	FooUpdatedV1 = Definition{
+		Type:    FooUpdatedType,
+		Subject: "foo.updated",
+		Version: 1,
+		Schema:  "schemas/foo.updated.v1.json",
+		Summary: "A Foo was updated.",
+	}
+	FooDeletedV1 = Definition{
+		Type:    FooDeletedType,
+		Subject: "foo.deleted",
+		Version: 1,
+		Schema:  "schemas/foo.deleted.v1.json",
+		Summary: "A Foo was deleted.",
+	}
+
+	FileCreatedV1 = Definition{
+		Type:    FileCreatedType,
+		Subject: "file.created",
+		Version: 1,
+		Schema:  "schemas/file.created.v1.json",
+		Summary: "A file was registered and awaits its upload.",
+	}
+	FileUploadedV1 = Definition{
+		Type:    FileUploadedType,
+		Subject: "file.uploaded",
+		Version: 1,
+		Schema:  "schemas/file.uploaded.v1.json",
+		Summary: "The upload of a file was completed.",
+	}
+	FileDeletedV1 = Definition{
+		Type:    FileDeletedType,
+		Subject: "file.deleted",
+		Version: 1,
+		Schema:  "schemas/file.deleted.v1.json",
+		Summary: "A file was deleted.",
+	}
+
+	// Default is the catalog of the events published by Astigo.
+	Default = mustCatalog(NewCatalog(schemaFS,
+		FooCreatedV1, FooUpdatedV1, FooDeletedV1,
+		FileCreatedV1, FileUploadedV1, FileDeletedV1,
+	))
+)
+
+// Definition describes a version of the data of an event, published on Subject.
+type Definition struct {
+	Type    string
+	Subject string
+	Version int
+	// Schema is the path of the JSON Schema of the data in the file system of the catalog.
+	Schema  string
+	Summary string
+}
+
+// DataSchema returns the URI identifying the schema, carried by the dataschema attribute of the CloudEvents.
+func (d Definition) DataSchema() string {
+	return fmt.Sprintf("%s%s:v%d", dataSchemaPrefix, d.Type, d.Version)
+}
+
+// ParseDataSchema returns the event type and the version identified by a dataschema URI.
+func ParseDataSchema(uri string) (string, int, error) {
+	name, ok := strings.CutPrefix(uri, dataSchemaPrefix)
+	if !ok {
+		return "", 0, fmt.Errorf("%w: dataschema %q", ErrUnknownEvent, uri)
+	}
+	eventType, version, ok := strings.Cut(name, ":v")
+	if !ok {
+		return "", 0, fmt.Errorf("%w: dataschema %q", ErrUnknownEvent, uri)
+	}
+	n, err := strconv.Atoi(version)
+	if err != nil || n < 1 {
+		return "", 0, fmt.Errorf("%w: dataschema %q", ErrUnknownEvent, uri)
+	}
+	return eventType, n, nil
+}
+
+// Upcaster migrates the data of an event from a version to the following one.
+type Upcaster func(data []byte) ([]byte, error)
+
+type versionKey struct {
+	eventType string
+	version   int
+}
+
+// Catalog holds the versions of the events, their compiled schemas and the upcasters between their versions.
+type Catalog struct {
+	fsys        fs.FS
+	definitions []Definition
+	current     map[string]Definition
+	schemas     map[versionKey]*jsonschema.Schema
+	upcasters   map[versionKey]Upcaster
+}
+
+// Definitions returns every version of every event, in registration order.
+func (c *Catalog) Definitions() []Definition {
+	return append([]Definition{}, c.definitions...)
+}
+
+// Current returns the latest version of an event.
+func (c *Catalog) Current(eventType string) (Definition, bool) {
+	definition, ok := c.current[eventType]
+	return definition, ok
+}
+
+// SchemaFile returns the JSON Schema of a version of an event.
+func (c *Catalog) SchemaFile(definition Definition) ([]byte, error) {
+	return fs.ReadFile(c.fsys, definition.Schema)
+}
+
+// Validate checks the data of an event against the schema of its version.
+func (c *Catalog) Validate(eventType string, version int, data []byte) error {
+	schema, ok := c.schemas[versionKey{eventType, version}]
+	if !ok {
+		return fmt.Errorf("%w: %s v%d", ErrUnknownEvent, eventType, version)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	if err := schema.Validate(instance); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	return nil
+}
+
+// AddUpcaster registers the migration of the data of an event from a version to the following one.
+func (c *Catalog) AddUpcaster(eventType string, from int, upcaster Upcaster) {
+	c.upcasters[versionKey{eventType, from}] = upcaster
+}
+
+// Upcast migrates the data of an event from its version to the current one, one version at a time. The data of an
+// event type that the catalog does not define is returned as is.
+func (c *Catalog) Upcast(eventType string, version int, data []byte) ([]byte, error) {
+	current, ok := c.current[eventType]
+	if !ok {
+		return data, nil
+	}
+	if version > current.Version {
+		return nil, fmt.Errorf("%w: %s v%d, latest known is v%d", ErrUnsupportedVersion, eventType, version, current.Version)
+	}
+
+	for ; version < current.Version; version++ {
+		upcaster, ok := c.upcasters[versionKey{eventType, version}]
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster of %s from v%d", ErrUnknownEvent, eventType, version)
+		}
+
+		var err error
+		if data, err = upcaster(data); err != nil {
+			return nil, fmt.Errorf("fail to upcast %s from v%d: %w", eventType, version, err)
+		}
+	}
+	return data, nil
+}
+
+// NewCatalog compiles the schema of every definition, read from fsys.
+func NewCatalog(fsys fs.FS, definitions ...Definition) (*Catalog, error) {
+	catalog := &Catalog{
+		fsys:        fsys,
+		definitions: definitions,
+		current:     make(map[string]Definition),
+		schemas:     make(map[versionKey]*jsonschema.Schema),
+		upcasters:   make(map[versionKey]Upcaster),
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	for _, definition := range definitions {
+		file, err := fsys.Open(definition.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("fail to open schema of %s v%d: %w", definition.Type, definition.Version, err)
+		}
+		document, err := jsonschema.UnmarshalJSON(file)
+		_ = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("fail to read schema of %s v%d: %w", definition.Type, definition.Version, err)
+		}
+		if err := compiler.AddResource(definition.DataSchema(), document); err != nil {
+			return nil, fmt.Errorf("fail to add schema of %s v%d: %w", definition.Type, definition.Version, err)
+		}
+		schema, err := compiler.Compile(definition.DataSchema())
+		if err != nil {
+			return nil, fmt.Errorf("fail to compile schema of %s v%d: %w", definition.Type, definition.Version, err)
+		}
+
+		catalog.schemas[versionKey{definition.Type, definition.Version}] = schema
+		if current, ok := catalog.current[definition.Type]; !ok || definition.Version > current.Version {
+			catalog.current[definition.Type] = definition
+		}
+	}
+
+	return catalog, nil
+}
+
+func mustCatalog(catalog *Catalog, err error) *Catalog {
+	if err != nil {
+		panic(err)
+	}
+	return catalog
+}
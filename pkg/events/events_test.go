@@ -0,0 +1,187 @@
+package events
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDataSchema(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name            string
+		uri             string
+		expectedType    string
+		expectedVersion int
+		expectedErr     bool
+	}{
+		{name: "Success Case - Definition", uri: FooDeletedV1.DataSchema(), expectedType: FooDeletedType, expectedVersion: 1},
+		{name: "Success Case - Later Version", uri: "urn:astigo:schema:com.astigo.foo.created:v12", expectedType: FooCreatedType, expectedVersion: 12},
+		{name: "Failure Case - Foreign Schema", uri: "https://example.com/foo.json", expectedErr: true},
+		{name: "Failure Case - Missing Version", uri: "urn:astigo:schema:com.astigo.foo.created", expectedErr: true},
+		{name: "Failure Case - Invalid Version", uri: "urn:astigo:schema:com.astigo.foo.created:v0", expectedErr: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			eventType, version, err := ParseDataSchema(testCase.uri)
+			if testCase.expectedErr {
+				assert.ErrorIs(t, err, ErrUnknownEvent)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedType, eventType)
+			assert.Equal(t, testCase.expectedVersion, version)
+		})
+	}
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		eventType   string
+		version     int
+		data        string
+		expectedErr error
+	}{
+		{
+			name:      "Success Case - Created",
+			eventType: FooCreatedType,
+			version:   1,
+			data:      `{"id":"20000000-0000-0000-0000-000000000001","label":"foo","value":1,"weight":1.5,"created_at":"2025-01-02T03:04:05Z","updated_at":null}`,
+		},
+		{
+			name:      "Success Case - Deleted",
+			eventType: FooDeletedType,
+			version:   1,
+			data:      `{"id":"20000000-0000-0000-0000-000000000001"}`,
+		},
+		{
+			name:        "Failure Case - Missing Property",
+			eventType:   FooCreatedType,
+			version:     1,
+			data:        `{"id":"20000000-0000-0000-0000-000000000001","label":"foo"}`,
+			expectedErr: ErrInvalidData,
+		},
+		{
+			name:        "Failure Case - Undocumented Property",
+			eventType:   FooDeletedType,
+			version:     1,
+			data:        `{"id":"20000000-0000-0000-0000-000000000001","label":"foo"}`,
+			expectedErr: ErrInvalidData,
+		},
+		{
+			name:        "Failure Case - Invalid Format",
+			eventType:   FooDeletedType,
+			version:     1,
+			data:        `{"id":"42"}`,
+			expectedErr: ErrInvalidData,
+		},
+		{
+			name:        "Failure Case - Unknown Version",
+			eventType:   FooDeletedType,
+			version:     2,
+			data:        `{"id":"20000000-0000-0000-0000-000000000001"}`,
+			expectedErr: ErrUnknownEvent,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Default.Validate(testCase.eventType, testCase.version, []byte(testCase.data))
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}
+
+func TestCatalog_Upcast(t *testing.T) {
+	t.Parallel()
+	fsys := fstest.MapFS{
+		"v1.json": {Data: []byte(`{"type":"object","required":["name"]}`)},
+		"v2.json": {Data: []byte(`{"type":"object","required":["label"]}`)},
+		"v3.json": {Data: []byte(`{"type":"object","required":["label","weight"]}`)},
+	}
+	catalog, err := NewCatalog(fsys,
+		Definition{Type: "com.astigo.bar.created", Subject: "bar.created", Version: 1, Schema: "v1.json"},
+		Definition{Type: "com.astigo.bar.created", Subject: "bar.created", Version: 2, Schema: "v2.json"},
+		Definition{Type: "com.astigo.bar.created", Subject: "bar.created", Version: 3, Schema: "v3.json"},
+	)
+	assert.NoError(t, err)
+	// v2 renames name to label, v3 adds a weight defaulting to 1.
+	catalog.AddUpcaster("com.astigo.bar.created", 1, func(data []byte) ([]byte, error) {
+		var v1 map[string]any
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		v1["label"] = v1["name"]
+		delete(v1, "name")
+		return json.Marshal(v1)
+	})
+	catalog.AddUpcaster("com.astigo.bar.created", 2, func(data []byte) ([]byte, error) {
+		var v2 map[string]any
+		if err := json.Unmarshal(data, &v2); err != nil {
+			return nil, err
+		}
+		v2["weight"] = 1
+		return json.Marshal(v2)
+	})
+
+	current, ok := catalog.Current("com.astigo.bar.created")
+	assert.True(t, ok)
+	assert.Equal(t, 3, current.Version)
+
+	testCases := []struct {
+		name         string
+		eventType    string
+		version      int
+		data         string
+		expectedData string
+		expectedErr  error
+	}{
+		{name: "Success Case - From First Version", eventType: "com.astigo.bar.created", version: 1, data: `{"name":"bar"}`, expectedData: `{"label":"bar","weight":1}`},
+		{name: "Success Case - From Intermediate Version", eventType: "com.astigo.bar.created", version: 2, data: `{"label":"bar"}`, expectedData: `{"label":"bar","weight":1}`},
+		{name: "Success Case - Current Version", eventType: "com.astigo.bar.created", version: 3, data: `{"label":"bar","weight":2}`, expectedData: `{"label":"bar","weight":2}`},
+		{name: "Success Case - Unknown Type", eventType: "com.astigo.baz.created", version: 1, data: `{"name":"baz"}`, expectedData: `{"name":"baz"}`},
+		{name: "Failure Case - Newer Version", eventType: "com.astigo.bar.created", version: 4, data: `{}`, expectedErr: ErrUnsupportedVersion},
+		{name: "Failure Case - Malformed Data", eventType: "com.astigo.bar.created", version: 1, data: `{`},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			data, err := catalog.Upcast(testCase.eventType, testCase.version, []byte(testCase.data))
+			if testCase.expectedData == "" {
+				assert.Error(t, err)
+				if testCase.expectedErr != nil {
+					assert.ErrorIs(t, err, testCase.expectedErr)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.JSONEq(t, testCase.expectedData, string(data))
+			if _, ok := catalog.Current(testCase.eventType); ok {
+				assert.NoError(t, catalog.Validate(testCase.eventType, 3, data))
+			}
+		})
+	}
+}
+
+func TestCatalog_AsyncAPI(t *testing.T) {
+	t.Parallel()
+	document, err := Default.AsyncAPI()
+	assert.NoError(t, err)
+
+	generated, err := os.ReadFile("asyncapi.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, string(document), string(generated), "asyncapi.yaml is outdated, run go generate ./pkg/events")
+}
@@ -0,0 +1,21 @@
+//go:build ignore
+
+// Command gen writes the AsyncAPI document of the default catalog to asyncapi.yaml. Run it with go generate.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/TancelinMazzotti/astigo/pkg/events"
+)
+
+func main() {
+	document, err := events.Default.AsyncAPI()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("asyncapi.yaml", document, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
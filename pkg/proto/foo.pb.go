@@ -9,6 +9,8 @@ package proto
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -27,6 +29,8 @@ type Foo struct {
 	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
 	Value         int32                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
 	Weight        float32                `protobuf:"fixed32,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // unset if never updated
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -89,6 +93,20 @@ func (x *Foo) GetWeight() float32 {
 	return 0
 }
 
+func (x *Foo) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Foo) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
 type CreateFooRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
@@ -277,6 +295,136 @@ func (x *UpdateFooRequest) GetWeight() float32 {
 	return 0
 }
 
+// Fields that can be patched, selected with PatchFooRequest.update_mask
+type FooPatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Secret        string                 `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Value         int32                  `protobuf:"varint,3,opt,name=value,proto3" json:"value,omitempty"`
+	Weight        float32                `protobuf:"fixed32,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FooPatch) Reset() {
+	*x = FooPatch{}
+	mi := &file_foo_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FooPatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FooPatch) ProtoMessage() {}
+
+func (x *FooPatch) ProtoReflect() protoreflect.Message {
+	mi := &file_foo_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FooPatch.ProtoReflect.Descriptor instead.
+func (*FooPatch) Descriptor() ([]byte, []int) {
+	return file_foo_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FooPatch) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FooPatch) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *FooPatch) GetValue() int32 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *FooPatch) GetWeight() float32 {
+	if x != nil {
+		return x.Weight
+	}
+	return 0
+}
+
+// Only the fields listed in update_mask (label, secret, value, weight) are applied
+type PatchFooRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
+	Foo           *FooPatch              `protobuf:"bytes,2,opt,name=foo,proto3" json:"foo,omitempty"`
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,3,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchFooRequest) Reset() {
+	*x = PatchFooRequest{}
+	mi := &file_foo_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchFooRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchFooRequest) ProtoMessage() {}
+
+func (x *PatchFooRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_foo_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchFooRequest.ProtoReflect.Descriptor instead.
+func (*PatchFooRequest) Descriptor() ([]byte, []int) {
+	return file_foo_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PatchFooRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PatchFooRequest) GetFoo() *FooPatch {
+	if x != nil {
+		return x.Foo
+	}
+	return nil
+}
+
+func (x *PatchFooRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
 type DeleteFooRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // UUID
@@ -286,7 +434,7 @@ type DeleteFooRequest struct {
 
 func (x *DeleteFooRequest) Reset() {
 	*x = DeleteFooRequest{}
-	mi := &file_foo_proto_msgTypes[4]
+	mi := &file_foo_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -298,7 +446,7 @@ func (x *DeleteFooRequest) String() string {
 func (*DeleteFooRequest) ProtoMessage() {}
 
 func (x *DeleteFooRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_foo_proto_msgTypes[4]
+	mi := &file_foo_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -311,7 +459,7 @@ func (x *DeleteFooRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteFooRequest.ProtoReflect.Descriptor instead.
 func (*DeleteFooRequest) Descriptor() ([]byte, []int) {
-	return file_foo_proto_rawDescGZIP(), []int{4}
+	return file_foo_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *DeleteFooRequest) GetId() string {
@@ -330,7 +478,7 @@ type FooResponse struct {
 
 func (x *FooResponse) Reset() {
 	*x = FooResponse{}
-	mi := &file_foo_proto_msgTypes[5]
+	mi := &file_foo_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -342,7 +490,7 @@ func (x *FooResponse) String() string {
 func (*FooResponse) ProtoMessage() {}
 
 func (x *FooResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_foo_proto_msgTypes[5]
+	mi := &file_foo_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -355,7 +503,7 @@ func (x *FooResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FooResponse.ProtoReflect.Descriptor instead.
 func (*FooResponse) Descriptor() ([]byte, []int) {
-	return file_foo_proto_rawDescGZIP(), []int{5}
+	return file_foo_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *FooResponse) GetFoo() *Foo {
@@ -376,7 +524,7 @@ type ListFoosRequest struct {
 
 func (x *ListFoosRequest) Reset() {
 	*x = ListFoosRequest{}
-	mi := &file_foo_proto_msgTypes[6]
+	mi := &file_foo_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -388,7 +536,7 @@ func (x *ListFoosRequest) String() string {
 func (*ListFoosRequest) ProtoMessage() {}
 
 func (x *ListFoosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_foo_proto_msgTypes[6]
+	mi := &file_foo_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -401,7 +549,7 @@ func (x *ListFoosRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFoosRequest.ProtoReflect.Descriptor instead.
 func (*ListFoosRequest) Descriptor() ([]byte, []int) {
-	return file_foo_proto_rawDescGZIP(), []int{6}
+	return file_foo_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ListFoosRequest) GetOffset() int32 {
@@ -427,7 +575,7 @@ type ListFoosResponse struct {
 
 func (x *ListFoosResponse) Reset() {
 	*x = ListFoosResponse{}
-	mi := &file_foo_proto_msgTypes[7]
+	mi := &file_foo_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -439,7 +587,7 @@ func (x *ListFoosResponse) String() string {
 func (*ListFoosResponse) ProtoMessage() {}
 
 func (x *ListFoosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_foo_proto_msgTypes[7]
+	mi := &file_foo_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -452,7 +600,7 @@ func (x *ListFoosResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFoosResponse.ProtoReflect.Descriptor instead.
 func (*ListFoosResponse) Descriptor() ([]byte, []int) {
-	return file_foo_proto_rawDescGZIP(), []int{7}
+	return file_foo_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ListFoosResponse) GetFoos() []*Foo {
@@ -471,7 +619,7 @@ type DeleteFooResponse struct {
 
 func (x *DeleteFooResponse) Reset() {
 	*x = DeleteFooResponse{}
-	mi := &file_foo_proto_msgTypes[8]
+	mi := &file_foo_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -483,7 +631,7 @@ func (x *DeleteFooResponse) String() string {
 func (*DeleteFooResponse) ProtoMessage() {}
 
 func (x *DeleteFooResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_foo_proto_msgTypes[8]
+	mi := &file_foo_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -496,7 +644,7 @@ func (x *DeleteFooResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteFooResponse.ProtoReflect.Descriptor instead.
 func (*DeleteFooResponse) Descriptor() ([]byte, []int) {
-	return file_foo_proto_rawDescGZIP(), []int{8}
+	return file_foo_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeleteFooResponse) GetSuccess() bool {
@@ -510,12 +658,16 @@ var File_foo_proto protoreflect.FileDescriptor
 
 const file_foo_proto_rawDesc = "" +
 	"\n" +
-	"\tfoo.proto\x12\x05proto\"Y\n" +
+	"\tfoo.proto\x12\x05proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcf\x01\n" +
 	"\x03Foo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05label\x18\x02 \x01(\tR\x05label\x12\x14\n" +
 	"\x05value\x18\x03 \x01(\x05R\x05value\x12\x16\n" +
-	"\x06weight\x18\x04 \x01(\x02R\x06weight\"n\n" +
+	"\x06weight\x18\x04 \x01(\x02R\x06weight\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"n\n" +
 	"\x10CreateFooRequest\x12\x14\n" +
 	"\x05label\x18\x01 \x01(\tR\x05label\x12\x16\n" +
 	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x14\n" +
@@ -528,7 +680,17 @@ const file_foo_proto_rawDesc = "" +
 	"\x05label\x18\x02 \x01(\tR\x05label\x12\x16\n" +
 	"\x06secret\x18\x03 \x01(\tR\x06secret\x12\x14\n" +
 	"\x05value\x18\x04 \x01(\x05R\x05value\x12\x16\n" +
-	"\x06weight\x18\x05 \x01(\x02R\x06weight\"\"\n" +
+	"\x06weight\x18\x05 \x01(\x02R\x06weight\"f\n" +
+	"\bFooPatch\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\x05R\x05value\x12\x16\n" +
+	"\x06weight\x18\x04 \x01(\x02R\x06weight\"\x81\x01\n" +
+	"\x0fPatchFooRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\x03foo\x18\x02 \x01(\v2\x0f.proto.FooPatchR\x03foo\x12;\n" +
+	"\vupdate_mask\x18\x03 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\"\n" +
 	"\x10DeleteFooRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
 	"\vFooResponse\x12\x1c\n" +
@@ -541,14 +703,15 @@ const file_foo_proto_rawDesc = "" +
 	"\x04foos\x18\x01 \x03(\v2\n" +
 	".proto.FooR\x04foos\"-\n" +
 	"\x11DeleteFooResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\xa1\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xd6\x02\n" +
 	"\n" +
 	"FooService\x125\n" +
 	"\x06Create\x12\x17.proto.CreateFooRequest\x1a\x12.proto.FooResponse\x12/\n" +
 	"\x03Get\x12\x14.proto.GetFooRequest\x1a\x12.proto.FooResponse\x125\n" +
-	"\x06Update\x12\x17.proto.UpdateFooRequest\x1a\x12.proto.FooResponse\x12;\n" +
+	"\x06Update\x12\x17.proto.UpdateFooRequest\x1a\x12.proto.FooResponse\x123\n" +
+	"\x05Patch\x12\x16.proto.PatchFooRequest\x1a\x12.proto.FooResponse\x12;\n" +
 	"\x06Delete\x12\x17.proto.DeleteFooRequest\x1a\x18.proto.DeleteFooResponse\x127\n" +
-	"\x04List\x12\x16.proto.ListFoosRequest\x1a\x17.proto.ListFoosResponseB\x18Z\x16astigo/pkg/proto;protob\x06proto3"
+	"\x04List\x12\x16.proto.ListFoosRequest\x1a\x17.proto.ListFoosResponseB4Z2github.com/TancelinMazzotti/astigo/pkg/proto;protob\x06proto3"
 
 var (
 	file_foo_proto_rawDescOnce sync.Once
@@ -562,36 +725,46 @@ func file_foo_proto_rawDescGZIP() []byte {
 	return file_foo_proto_rawDescData
 }
 
-var file_foo_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_foo_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_foo_proto_goTypes = []any{
-	(*Foo)(nil),               // 0: proto.Foo
-	(*CreateFooRequest)(nil),  // 1: proto.CreateFooRequest
-	(*GetFooRequest)(nil),     // 2: proto.GetFooRequest
-	(*UpdateFooRequest)(nil),  // 3: proto.UpdateFooRequest
-	(*DeleteFooRequest)(nil),  // 4: proto.DeleteFooRequest
-	(*FooResponse)(nil),       // 5: proto.FooResponse
-	(*ListFoosRequest)(nil),   // 6: proto.ListFoosRequest
-	(*ListFoosResponse)(nil),  // 7: proto.ListFoosResponse
-	(*DeleteFooResponse)(nil), // 8: proto.DeleteFooResponse
+	(*Foo)(nil),                   // 0: proto.Foo
+	(*CreateFooRequest)(nil),      // 1: proto.CreateFooRequest
+	(*GetFooRequest)(nil),         // 2: proto.GetFooRequest
+	(*UpdateFooRequest)(nil),      // 3: proto.UpdateFooRequest
+	(*FooPatch)(nil),              // 4: proto.FooPatch
+	(*PatchFooRequest)(nil),       // 5: proto.PatchFooRequest
+	(*DeleteFooRequest)(nil),      // 6: proto.DeleteFooRequest
+	(*FooResponse)(nil),           // 7: proto.FooResponse
+	(*ListFoosRequest)(nil),       // 8: proto.ListFoosRequest
+	(*ListFoosResponse)(nil),      // 9: proto.ListFoosResponse
+	(*DeleteFooResponse)(nil),     // 10: proto.DeleteFooResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil), // 12: google.protobuf.FieldMask
 }
 var file_foo_proto_depIdxs = []int32{
-	0, // 0: proto.FooResponse.foo:type_name -> proto.Foo
-	0, // 1: proto.ListFoosResponse.foos:type_name -> proto.Foo
-	1, // 2: proto.FooService.Create:input_type -> proto.CreateFooRequest
-	2, // 3: proto.FooService.Get:input_type -> proto.GetFooRequest
-	3, // 4: proto.FooService.Update:input_type -> proto.UpdateFooRequest
-	4, // 5: proto.FooService.Delete:input_type -> proto.DeleteFooRequest
-	6, // 6: proto.FooService.List:input_type -> proto.ListFoosRequest
-	5, // 7: proto.FooService.Create:output_type -> proto.FooResponse
-	5, // 8: proto.FooService.Get:output_type -> proto.FooResponse
-	5, // 9: proto.FooService.Update:output_type -> proto.FooResponse
-	8, // 10: proto.FooService.Delete:output_type -> proto.DeleteFooResponse
-	7, // 11: proto.FooService.List:output_type -> proto.ListFoosResponse
-	7, // [7:12] is the sub-list for method output_type
-	2, // [2:7] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	11, // 0: proto.Foo.created_at:type_name -> google.protobuf.Timestamp
+	11, // 1: proto.Foo.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 2: proto.PatchFooRequest.foo:type_name -> proto.FooPatch
+	12, // 3: proto.PatchFooRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 4: proto.FooResponse.foo:type_name -> proto.Foo
+	0,  // 5: proto.ListFoosResponse.foos:type_name -> proto.Foo
+	1,  // 6: proto.FooService.Create:input_type -> proto.CreateFooRequest
+	2,  // 7: proto.FooService.Get:input_type -> proto.GetFooRequest
+	3,  // 8: proto.FooService.Update:input_type -> proto.UpdateFooRequest
+	5,  // 9: proto.FooService.Patch:input_type -> proto.PatchFooRequest
+	6,  // 10: proto.FooService.Delete:input_type -> proto.DeleteFooRequest
+	8,  // 11: proto.FooService.List:input_type -> proto.ListFoosRequest
+	7,  // 12: proto.FooService.Create:output_type -> proto.FooResponse
+	7,  // 13: proto.FooService.Get:output_type -> proto.FooResponse
+	7,  // 14: proto.FooService.Update:output_type -> proto.FooResponse
+	7,  // 15: proto.FooService.Patch:output_type -> proto.FooResponse
+	10, // 16: proto.FooService.Delete:output_type -> proto.DeleteFooResponse
+	9,  // 17: proto.FooService.List:output_type -> proto.ListFoosResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_foo_proto_init() }
@@ -605,7 +778,7 @@ func file_foo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_foo_proto_rawDesc), len(file_foo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const (
 	FooService_Create_FullMethodName = "/proto.FooService/Create"
 	FooService_Get_FullMethodName    = "/proto.FooService/Get"
 	FooService_Update_FullMethodName = "/proto.FooService/Update"
+	FooService_Patch_FullMethodName  = "/proto.FooService/Patch"
 	FooService_Delete_FullMethodName = "/proto.FooService/Delete"
 	FooService_List_FullMethodName   = "/proto.FooService/List"
 )
@@ -33,6 +34,7 @@ type FooServiceClient interface {
 	Create(ctx context.Context, in *CreateFooRequest, opts ...grpc.CallOption) (*FooResponse, error)
 	Get(ctx context.Context, in *GetFooRequest, opts ...grpc.CallOption) (*FooResponse, error)
 	Update(ctx context.Context, in *UpdateFooRequest, opts ...grpc.CallOption) (*FooResponse, error)
+	Patch(ctx context.Context, in *PatchFooRequest, opts ...grpc.CallOption) (*FooResponse, error)
 	Delete(ctx context.Context, in *DeleteFooRequest, opts ...grpc.CallOption) (*DeleteFooResponse, error)
 	List(ctx context.Context, in *ListFoosRequest, opts ...grpc.CallOption) (*ListFoosResponse, error)
 }
@@ -75,6 +77,16 @@ func (c *fooServiceClient) Update(ctx context.Context, in *UpdateFooRequest, opt
 	return out, nil
 }
 
+func (c *fooServiceClient) Patch(ctx context.Context, in *PatchFooRequest, opts ...grpc.CallOption) (*FooResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FooResponse)
+	err := c.cc.Invoke(ctx, FooService_Patch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *fooServiceClient) Delete(ctx context.Context, in *DeleteFooRequest, opts ...grpc.CallOption) (*DeleteFooResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(DeleteFooResponse)
@@ -102,6 +114,7 @@ type FooServiceServer interface {
 	Create(context.Context, *CreateFooRequest) (*FooResponse, error)
 	Get(context.Context, *GetFooRequest) (*FooResponse, error)
 	Update(context.Context, *UpdateFooRequest) (*FooResponse, error)
+	Patch(context.Context, *PatchFooRequest) (*FooResponse, error)
 	Delete(context.Context, *DeleteFooRequest) (*DeleteFooResponse, error)
 	List(context.Context, *ListFoosRequest) (*ListFoosResponse, error)
 	mustEmbedUnimplementedFooServiceServer()
@@ -123,6 +136,9 @@ func (UnimplementedFooServiceServer) Get(context.Context, *GetFooRequest) (*FooR
 func (UnimplementedFooServiceServer) Update(context.Context, *UpdateFooRequest) (*FooResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedFooServiceServer) Patch(context.Context, *PatchFooRequest) (*FooResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
 func (UnimplementedFooServiceServer) Delete(context.Context, *DeleteFooRequest) (*DeleteFooResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
@@ -204,6 +220,24 @@ func _FooService_Update_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FooService_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchFooRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FooServiceServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FooService_Patch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FooServiceServer).Patch(ctx, req.(*PatchFooRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _FooService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteFooRequest)
 	if err := dec(in); err != nil {
@@ -259,6 +293,10 @@ var FooService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _FooService_Update_Handler,
 		},
+		{
+			MethodName: "Patch",
+			Handler:    _FooService_Patch_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _FooService_Delete_Handler,
@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: foo.proto
+
+package protoconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	proto "github.com/TancelinMazzotti/astigo/pkg/proto"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// FooServiceName is the fully-qualified name of the FooService service.
+	FooServiceName = "proto.FooService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// FooServiceCreateProcedure is the fully-qualified name of the FooService's Create RPC.
+	FooServiceCreateProcedure = "/proto.FooService/Create"
+	// FooServiceGetProcedure is the fully-qualified name of the FooService's Get RPC.
+	FooServiceGetProcedure = "/proto.FooService/Get"
+	// FooServiceUpdateProcedure is the fully-qualified name of the FooService's Update RPC.
+	FooServiceUpdateProcedure = "/proto.FooService/Update"
+	// FooServicePatchProcedure is the fully-qualified name of the FooService's Patch RPC.
+	FooServicePatchProcedure = "/proto.FooService/Patch"
+	// FooServiceDeleteProcedure is the fully-qualified name of the FooService's Delete RPC.
+	FooServiceDeleteProcedure = "/proto.FooService/Delete"
+	// FooServiceListProcedure is the fully-qualified name of the FooService's List RPC.
+	FooServiceListProcedure = "/proto.FooService/List"
+)
+
+// FooServiceClient is a client for the proto.FooService service.
+type FooServiceClient interface {
+	Create(context.Context, *connect.Request[proto.CreateFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Get(context.Context, *connect.Request[proto.GetFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Update(context.Context, *connect.Request[proto.UpdateFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Patch(context.Context, *connect.Request[proto.PatchFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Delete(context.Context, *connect.Request[proto.DeleteFooRequest]) (*connect.Response[proto.DeleteFooResponse], error)
+	List(context.Context, *connect.Request[proto.ListFoosRequest]) (*connect.Response[proto.ListFoosResponse], error)
+}
+
+// NewFooServiceClient constructs a client for the proto.FooService service. By default, it uses the
+// Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewFooServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) FooServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	fooServiceMethods := proto.File_foo_proto.Services().ByName("FooService").Methods()
+	return &fooServiceClient{
+		create: connect.NewClient[proto.CreateFooRequest, proto.FooResponse](
+			httpClient,
+			baseURL+FooServiceCreateProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("Create")),
+			connect.WithClientOptions(opts...),
+		),
+		get: connect.NewClient[proto.GetFooRequest, proto.FooResponse](
+			httpClient,
+			baseURL+FooServiceGetProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("Get")),
+			connect.WithClientOptions(opts...),
+		),
+		update: connect.NewClient[proto.UpdateFooRequest, proto.FooResponse](
+			httpClient,
+			baseURL+FooServiceUpdateProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("Update")),
+			connect.WithClientOptions(opts...),
+		),
+		patch: connect.NewClient[proto.PatchFooRequest, proto.FooResponse](
+			httpClient,
+			baseURL+FooServicePatchProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("Patch")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[proto.DeleteFooRequest, proto.DeleteFooResponse](
+			httpClient,
+			baseURL+FooServiceDeleteProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
+		list: connect.NewClient[proto.ListFoosRequest, proto.ListFoosResponse](
+			httpClient,
+			baseURL+FooServiceListProcedure,
+			connect.WithSchema(fooServiceMethods.ByName("List")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// fooServiceClient implements FooServiceClient.
+type fooServiceClient struct {
+	create *connect.Client[proto.CreateFooRequest, proto.FooResponse]
+	get    *connect.Client[proto.GetFooRequest, proto.FooResponse]
+	update *connect.Client[proto.UpdateFooRequest, proto.FooResponse]
+	patch  *connect.Client[proto.PatchFooRequest, proto.FooResponse]
+	delete *connect.Client[proto.DeleteFooRequest, proto.DeleteFooResponse]
+	list   *connect.Client[proto.ListFoosRequest, proto.ListFoosResponse]
+}
+
+// Create calls proto.FooService.Create.
+func (c *fooServiceClient) Create(ctx context.Context, req *connect.Request[proto.CreateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return c.create.CallUnary(ctx, req)
+}
+
+// Get calls proto.FooService.Get.
+func (c *fooServiceClient) Get(ctx context.Context, req *connect.Request[proto.GetFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return c.get.CallUnary(ctx, req)
+}
+
+// Update calls proto.FooService.Update.
+func (c *fooServiceClient) Update(ctx context.Context, req *connect.Request[proto.UpdateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return c.update.CallUnary(ctx, req)
+}
+
+// Patch calls proto.FooService.Patch.
+func (c *fooServiceClient) Patch(ctx context.Context, req *connect.Request[proto.PatchFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return c.patch.CallUnary(ctx, req)
+}
+
+// Delete calls proto.FooService.Delete.
+func (c *fooServiceClient) Delete(ctx context.Context, req *connect.Request[proto.DeleteFooRequest]) (*connect.Response[proto.DeleteFooResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
+// List calls proto.FooService.List.
+func (c *fooServiceClient) List(ctx context.Context, req *connect.Request[proto.ListFoosRequest]) (*connect.Response[proto.ListFoosResponse], error) {
+	return c.list.CallUnary(ctx, req)
+}
+
+// FooServiceHandler is an implementation of the proto.FooService service.
+type FooServiceHandler interface {
+	Create(context.Context, *connect.Request[proto.CreateFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Get(context.Context, *connect.Request[proto.GetFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Update(context.Context, *connect.Request[proto.UpdateFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Patch(context.Context, *connect.Request[proto.PatchFooRequest]) (*connect.Response[proto.FooResponse], error)
+	Delete(context.Context, *connect.Request[proto.DeleteFooRequest]) (*connect.Response[proto.DeleteFooResponse], error)
+	List(context.Context, *connect.Request[proto.ListFoosRequest]) (*connect.Response[proto.ListFoosResponse], error)
+}
+
+// NewFooServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewFooServiceHandler(svc FooServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	fooServiceMethods := proto.File_foo_proto.Services().ByName("FooService").Methods()
+	fooServiceCreateHandler := connect.NewUnaryHandler(
+		FooServiceCreateProcedure,
+		svc.Create,
+		connect.WithSchema(fooServiceMethods.ByName("Create")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fooServiceGetHandler := connect.NewUnaryHandler(
+		FooServiceGetProcedure,
+		svc.Get,
+		connect.WithSchema(fooServiceMethods.ByName("Get")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fooServiceUpdateHandler := connect.NewUnaryHandler(
+		FooServiceUpdateProcedure,
+		svc.Update,
+		connect.WithSchema(fooServiceMethods.ByName("Update")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fooServicePatchHandler := connect.NewUnaryHandler(
+		FooServicePatchProcedure,
+		svc.Patch,
+		connect.WithSchema(fooServiceMethods.ByName("Patch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fooServiceDeleteHandler := connect.NewUnaryHandler(
+		FooServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(fooServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
+	fooServiceListHandler := connect.NewUnaryHandler(
+		FooServiceListProcedure,
+		svc.List,
+		connect.WithSchema(fooServiceMethods.ByName("List")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/proto.FooService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case FooServiceCreateProcedure:
+			fooServiceCreateHandler.ServeHTTP(w, r)
+		case FooServiceGetProcedure:
+			fooServiceGetHandler.ServeHTTP(w, r)
+		case FooServiceUpdateProcedure:
+			fooServiceUpdateHandler.ServeHTTP(w, r)
+		case FooServicePatchProcedure:
+			fooServicePatchHandler.ServeHTTP(w, r)
+		case FooServiceDeleteProcedure:
+			fooServiceDeleteHandler.ServeHTTP(w, r)
+		case FooServiceListProcedure:
+			fooServiceListHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedFooServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedFooServiceHandler struct{}
+
+func (UnimplementedFooServiceHandler) Create(context.Context, *connect.Request[proto.CreateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.Create is not implemented"))
+}
+
+func (UnimplementedFooServiceHandler) Get(context.Context, *connect.Request[proto.GetFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.Get is not implemented"))
+}
+
+func (UnimplementedFooServiceHandler) Update(context.Context, *connect.Request[proto.UpdateFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.Update is not implemented"))
+}
+
+func (UnimplementedFooServiceHandler) Patch(context.Context, *connect.Request[proto.PatchFooRequest]) (*connect.Response[proto.FooResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.Patch is not implemented"))
+}
+
+func (UnimplementedFooServiceHandler) Delete(context.Context, *connect.Request[proto.DeleteFooRequest]) (*connect.Response[proto.DeleteFooResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.Delete is not implemented"))
+}
+
+func (UnimplementedFooServiceHandler) List(context.Context, *connect.Request[proto.ListFoosRequest]) (*connect.Response[proto.ListFoosResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("proto.FooService.List is not implemented"))
+}
@@ -0,0 +1,69 @@
+// Package webhook signs the webhooks delivered by Astigo and lets their receivers verify them.
+//
+// Every delivery carries the Unix time it was sent at in the X-Astigo-Timestamp header and, in the X-Astigo-Signature
+// header, the hex encoded HMAC-SHA256 of "<timestamp>.<body>" keyed by the secret of the subscription, prefixed by
+// "sha256=". Receivers should reject deliveries whose timestamp is too old to protect themselves against replays.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderID        = "X-Astigo-Delivery"
+	HeaderEvent     = "X-Astigo-Event"
+	HeaderTimestamp = "X-Astigo-Timestamp"
+	HeaderSignature = "X-Astigo-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature does not match the body and the timestamp.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrExpiredTimestamp is returned when the timestamp is outside the tolerated window.
+	ErrExpiredTimestamp = errors.New("expired webhook timestamp")
+)
+
+// Sign returns the value of the signature header of a body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	return signaturePrefix + hex.EncodeToString(mac(secret, strconv.FormatInt(timestamp.Unix(), 10), body))
+}
+
+// Verify checks the signature and timestamp headers of a received body. A timestamp further than tolerance from now
+// is rejected; a zero tolerance disables the check.
+func Verify(secret, signature, timestamp string, body []byte, tolerance time.Duration, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed timestamp %q", ErrInvalidSignature, timestamp)
+	}
+	if tolerance > 0 {
+		if delta := now.Sub(time.Unix(seconds, 0)); delta > tolerance || delta < -tolerance {
+			return ErrExpiredTimestamp
+		}
+	}
+
+	expected, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil || !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+	if !hmac.Equal(expected, mac(secret, timestamp, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func mac(secret, timestamp string, body []byte) []byte {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte(timestamp))
+	h.Write([]byte("."))
+	h.Write(body)
+	return h.Sum(nil)
+}
@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+	sentAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	body := []byte(`{"id":"20000000-0000-0000-0000-000000000001"}`)
+	signature := Sign("whsec_secret", sentAt, body)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+
+	testCases := []struct {
+		name        string
+		secret      string
+		signature   string
+		timestamp   string
+		body        []byte
+		now         time.Time
+		expectedErr error
+	}{
+		{name: "Success Case - Valid", secret: "whsec_secret", signature: signature, timestamp: timestamp, body: body, now: sentAt.Add(time.Minute)},
+		{name: "Failure Case - Wrong Secret", secret: "whsec_other", signature: signature, timestamp: timestamp, body: body, now: sentAt, expectedErr: ErrInvalidSignature},
+		{name: "Failure Case - Tampered Body", secret: "whsec_secret", signature: signature, timestamp: timestamp, body: []byte(`{}`), now: sentAt, expectedErr: ErrInvalidSignature},
+		{name: "Failure Case - Tampered Timestamp", secret: "whsec_secret", signature: signature, timestamp: strconv.FormatInt(sentAt.Unix()+1, 10), body: body, now: sentAt, expectedErr: ErrInvalidSignature},
+		{name: "Failure Case - Missing Prefix", secret: "whsec_secret", signature: signature[len("sha256="):], timestamp: timestamp, body: body, now: sentAt, expectedErr: ErrInvalidSignature},
+		{name: "Failure Case - Malformed Timestamp", secret: "whsec_secret", signature: signature, timestamp: "yesterday", body: body, now: sentAt, expectedErr: ErrInvalidSignature},
+		{name: "Failure Case - Expired", secret: "whsec_secret", signature: signature, timestamp: timestamp, body: body, now: sentAt.Add(time.Hour), expectedErr: ErrExpiredTimestamp},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			err := Verify(testCase.secret, testCase.signature, testCase.timestamp, testCase.body, 5*time.Minute, testCase.now)
+			if testCase.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, testCase.expectedErr)
+		})
+	}
+}